@@ -0,0 +1,98 @@
+// Comando backup gera e restaura backups do Controlador direto no banco, sem passar pela API.
+//
+//	backup exportar [-arquivo caminho]
+//	backup restaurar -arquivo caminho [-modo substituir|mesclar]
+//
+// A conexão usa as mesmas variáveis DB_* do servidor.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/database"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+	"controlador/backend/internal/services"
+)
+
+func main() {
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	if len(os.Args) < 2 {
+		uso()
+	}
+	switch os.Args[1] {
+	case "exportar":
+		exportar(os.Args[2:])
+	case "restaurar":
+		restaurar(os.Args[2:])
+	default:
+		uso()
+	}
+}
+
+func uso() {
+	fmt.Fprintln(os.Stderr, "uso: backup exportar [-arquivo caminho] | backup restaurar -arquivo caminho [-modo substituir|mesclar]")
+	os.Exit(2)
+}
+
+func exportar(args []string) {
+	flags := flag.NewFlagSet("exportar", flag.ExitOnError)
+	caminho := flags.String("arquivo", "", "arquivo de destino (padrão: saída padrão)")
+	flags.Parse(args)
+
+	var saida io.Writer = os.Stdout
+	if *caminho != "" {
+		arquivo, err := os.Create(*caminho)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Não foi possível criar o arquivo de backup")
+		}
+		defer arquivo.Close()
+		saida = arquivo
+	}
+
+	database.Connect()
+	repo := repositories.NewPgBackupRepository(database.DB)
+	if err := services.NewGerarBackupService(database.DB, repo).Execute(context.Background(), saida); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao gerar backup")
+	}
+	log.Info().Msg("Backup gerado com sucesso.")
+}
+
+func restaurar(args []string) {
+	flags := flag.NewFlagSet("restaurar", flag.ExitOnError)
+	caminho := flags.String("arquivo", "", "arquivo de backup a restaurar")
+	modo := flags.String("modo", string(models.RestauracaoSubstituir), "substituir (apaga os dados atuais) ou mesclar")
+	flags.Parse(args)
+	if *caminho == "" {
+		uso()
+	}
+
+	arquivo, err := os.Open(*caminho)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Não foi possível abrir o arquivo de backup")
+	}
+	defer arquivo.Close()
+
+	database.Connect()
+	repo := repositories.NewPgBackupRepository(database.DB)
+	resumo, err := services.NewRestaurarBackupService(database.DB, repo).Execute(context.Background(), arquivo, models.ModoRestauracao(*modo))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Falha ao restaurar backup")
+	}
+	log.Info().
+		Str("modo", string(resumo.Modo)).
+		Interface("tabelas", resumo.Tabelas).
+		Int("reutilizados", resumo.Reutilizados).
+		Int("ignorados", resumo.Ignorados).
+		Msg("Backup restaurado com sucesso.")
+}
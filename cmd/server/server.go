@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"controlador/backend/internal/cambio"
+	"controlador/backend/internal/config"
 	"controlador/backend/internal/database"
 	"controlador/backend/internal/handlers"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/notificacao"
 	"controlador/backend/internal/repositories"
 	"controlador/backend/internal/router"
 	"controlador/backend/internal/services"
+	"controlador/backend/internal/storage"
+	"controlador/backend/internal/webhook"
 
 )
 
@@ -25,39 +33,190 @@ func main() {
 
 	// --- INJEÇÃO DE DEPENDÊNCIAS ---
 
+	// Armazenamento de arquivos (anexos)
+	anexoStorage, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Falha ao configurar o armazenamento de anexos")
+	}
+	tamanhoMaximoAnexoMB, err := strconv.ParseInt(config.Getenv("ANEXOS_TAMANHO_MAXIMO_MB", "10"), 10, 64)
+	if err != nil {
+		log.Fatal().Err(err).Msg("ANEXOS_TAMANHO_MAXIMO_MB inválido")
+	}
+	provedorCambio, err := cambio.NewFromEnv()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Falha ao configurar o provedor de câmbio")
+	}
+	moedaBase, err := models.ParseMoeda(config.Getenv("MOEDA_BASE", models.MoedaPadrao))
+	if err != nil {
+		log.Fatal().Err(err).Msg("MOEDA_BASE inválida")
+	}
+	canaisNotificacao, err := notificacao.NewFromEnv()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Falha ao configurar os canais de notificação")
+	}
+	ttlIdempotencia, err := time.ParseDuration(config.Getenv("IDEMPOTENCY_TTL", "24h"))
+	if err != nil || ttlIdempotencia <= 0 {
+		log.Fatal().Err(err).Msg("IDEMPOTENCY_TTL inválido")
+	}
+	retencaoStream, err := time.ParseDuration(config.Getenv("STREAM_RETENCAO", "24h"))
+	if err != nil || retencaoStream <= 0 {
+		log.Fatal().Err(err).Msg("STREAM_RETENCAO inválido")
+	}
+
 	// Repositórios
 	ativoRepo := repositories.NewPgAtivoRepository(database.DB)
 	transacaoRepo := repositories.NewPgTransacaoRepository(database.DB)
 	categoriaRepo := repositories.NewPgCategoriaRepository(database.DB)
 	transacaoRecorrenteRepo := repositories.NewPgTransacaoRecorrenteRepository(database.DB)
+	transacaoHistoricoRepo := repositories.NewPgTransacaoHistoricoRepository(database.DB)
+	relatorioRepo := repositories.NewPgRelatorioRepository(database.DB)
+	tagRepo := repositories.NewPgTagRepository(database.DB)
+	anexoRepo := repositories.NewPgAnexoRepository(database.DB)
+	regraRepo := repositories.NewPgRegraRepository(database.DB)
+	idempotenciaRepo := repositories.NewPgIdempotenciaRepository(database.DB)
+	conciliacaoRepo := repositories.NewPgConciliacaoRepository(database.DB)
+	taxaCambioRepo := repositories.NewPgTaxaCambioRepository(database.DB)
+	transferenciaRepo := repositories.NewPgTransferenciaRepository(database.DB)
+	investimentoRepo := repositories.NewPgInvestimentoRepository(database.DB)
+	financiamentoRepo := repositories.NewPgFinanciamentoRepository(database.DB)
+	metaRepo := repositories.NewPgMetaRepository(database.DB)
+	notificacaoRepo := repositories.NewPgNotificacaoRepository(database.DB)
+	orcamentoRepo := repositories.NewPgOrcamentoRepository(database.DB)
+	webhookRepo := repositories.NewPgWebhookRepository(database.DB)
+	streamRepo := repositories.NewPgStreamRepository(database.DB)
+	backupRepo := repositories.NewPgBackupRepository(database.DB)
 
 	// Serviços
-	createAtivoSvc := services.NewCreateAtivoService(ativoRepo)
+	conversorMoedas := services.NewConversorMoedas(taxaCambioRepo, provedorCambio)
+	notificadorSvc := services.NewNotificadorService(notificacaoRepo, canaisNotificacao)
+	publicadorEventos := services.NewPublicadorEventos(webhookRepo)
+	createAtivoSvc := services.NewCreateAtivoService(database.DB, ativoRepo, transacaoRepo, categoriaRepo, publicadorEventos)
 	listAtivoSvc := services.NewListAtivosService(ativoRepo)
-	deactivateAtivoSvc := services.NewDeactivateAtivoService(ativoRepo)
-	createTransacaoSvc := services.NewCreateTransacaoService(database.DB, transacaoRepo, ativoRepo, categoriaRepo)
+	getAtivoSvc := services.NewGetAtivoService(ativoRepo)
+	updateAtivoSvc := services.NewUpdateAtivoService(database.DB, ativoRepo)
+	deactivateAtivoSvc := services.NewDeactivateAtivoService(database.DB, ativoRepo, transacaoRecorrenteRepo, publicadorEventos)
+	reactivateAtivoSvc := services.NewReactivateAtivoService(database.DB, ativoRepo, transacaoRecorrenteRepo)
+	saldoProjetadoSvc := services.NewSaldoProjetadoService(ativoRepo, transacaoRepo)
+	recalcularSaldoSvc := services.NewRecalcularSaldoService(database.DB, ativoRepo, transacaoRepo)
+	jurosChequeEspecialSvc := services.NewProcessarJurosChequeEspecialService(database.DB, ativoRepo, transacaoRepo, categoriaRepo, publicadorEventos)
+	motorRegras := services.NewMotorRegras(regraRepo)
+	detectorDuplicatas := services.NewDetectorDuplicatas(transacaoRepo)
+	createTransacaoSvc := services.NewCreateTransacaoService(database.DB, transacaoRepo, ativoRepo, categoriaRepo, motorRegras, detectorDuplicatas, publicadorEventos)
 	listTransacoesSvc := services.NewListTransacoesService(transacaoRepo)
-	reverseTransacaoSvc := services.NewReverseTransacaoService(database.DB, transacaoRepo, ativoRepo)
+	reverseTransacaoSvc := services.NewReverseTransacaoService(database.DB, transacaoRepo, ativoRepo, publicadorEventos)
+	updateTransacaoSvc := services.NewUpdateTransacaoService(database.DB, transacaoRepo, ativoRepo, categoriaRepo, transacaoHistoricoRepo)
+	deleteTransacaoSvc := services.NewDeleteTransacaoService(database.DB, transacaoRepo, ativoRepo, transacaoHistoricoRepo, anexoRepo, anexoStorage)
+	listTransacaoHistoricoSvc := services.NewListTransacaoHistoricoService(transacaoHistoricoRepo)
+	listDuplicatasSvc := services.NewListDuplicatasService(detectorDuplicatas)
+	efetivarTransacaoSvc := services.NewEfetivarTransacaoService(database.DB, transacaoRepo, ativoRepo, transacaoHistoricoRepo)
+	cancelarTransacaoSvc := services.NewCancelarTransacaoService(database.DB, transacaoRepo, transacaoHistoricoRepo)
+	efetivarTransferenciaSvc := services.NewEfetivarTransferenciaService(database.DB, transacaoRepo, transferenciaRepo, efetivarTransacaoSvc)
+	processarAgendadasSvc := services.NewProcessarAgendadasService(transacaoRepo, transferenciaRepo, efetivarTransacaoSvc, efetivarTransferenciaSvc)
+	mesclarTransacoesSvc := services.NewMesclarTransacoesService(database.DB, transacaoRepo, transacaoHistoricoRepo, reverseTransacaoSvc, cancelarTransacaoSvc)
 	createCategoriaSvc := services.NewCreateCategoriaService(categoriaRepo)
 	listCategoriaSvc := services.NewListCategoriasService(categoriaRepo)
 	
 	createRecorrenciaSvc := services.NewCreateTransacaoRecorrenteService(transacaoRecorrenteRepo, ativoRepo, categoriaRepo)
 	// ALTERAÇÃO: Corrigido para instanciar o serviço a partir do pacote 'services'.
 	listRecorrenciasSvc := services.NewListTransacoesRecorrentesService(transacaoRecorrenteRepo)
-	processarRecorrenciasSvc := services.NewProcessarRecorrenciasService(transacaoRecorrenteRepo, createTransacaoSvc)
+	processarRecorrenciasSvc := services.NewProcessarRecorrenciasService(transacaoRecorrenteRepo, createTransacaoSvc, notificadorSvc, publicadorEventos)
+	relatorioCategoriasSvc := services.NewRelatorioCategoriasService(relatorioRepo, conversorMoedas, moedaBase)
+	relatorioTagsSvc := services.NewRelatorioTagsService(relatorioRepo, conversorMoedas, moedaBase)
+	relatorioMensalSvc := services.NewRelatorioMensalService(relatorioRepo, conversorMoedas, moedaBase)
+	exportarTransacoesSvc := services.NewExportarTransacoesService(transacaoRepo)
+	exportarRelatoriosSvc := services.NewExportarRelatoriosService(relatorioCategoriasSvc, relatorioMensalSvc)
+	listTagsSvc := services.NewListTagsService(tagRepo)
+	uploadAnexoSvc := services.NewUploadAnexoService(anexoStorage, anexoRepo, transacaoRepo, tamanhoMaximoAnexoMB<<20)
+	listAnexosSvc := services.NewListAnexosService(anexoRepo)
+	downloadAnexoSvc := services.NewDownloadAnexoService(anexoStorage, anexoRepo)
+	deleteAnexoSvc := services.NewDeleteAnexoService(anexoStorage, anexoRepo)
+	createRegraSvc := services.NewCreateRegraService(regraRepo, categoriaRepo)
+	listRegrasSvc := services.NewListRegrasService(regraRepo)
+	deleteRegraSvc := services.NewDeleteRegraService(regraRepo)
+	aplicarRegrasSvc := services.NewAplicarRegrasService(database.DB, regraRepo, transacaoRepo, transacaoHistoricoRepo)
+	idempotenciaSvc := services.NewIdempotenciaService(idempotenciaRepo, ttlIdempotencia)
+	createConciliacaoSvc := services.NewCreateConciliacaoService(database.DB, conciliacaoRepo, ativoRepo)
+	listConciliacoesSvc := services.NewListConciliacoesService(conciliacaoRepo)
+	resumoConciliacaoSvc := services.NewResumoConciliacaoService(conciliacaoRepo, transacaoRepo, ativoRepo)
+	marcarConciliadasSvc := services.NewMarcarConciliadasService(database.DB, conciliacaoRepo, transacaoRepo, ativoRepo)
+	concluirConciliacaoSvc := services.NewConcluirConciliacaoService(database.DB, conciliacaoRepo, transacaoRepo, ativoRepo)
+	deleteConciliacaoSvc := services.NewDeleteConciliacaoService(database.DB, conciliacaoRepo)
+	desbloquearTransacaoSvc := services.NewDesbloquearTransacaoService(database.DB, transacaoRepo)
+	createTaxaCambioSvc := services.NewCreateTaxaCambioService(taxaCambioRepo)
+	listTaxasCambioSvc := services.NewListTaxasCambioService(taxaCambioRepo)
+	createTransferenciaSvc := services.NewCreateTransferenciaService(database.DB, transacaoRepo, ativoRepo, categoriaRepo, transferenciaRepo, conversorMoedas, publicadorEventos)
+	createTituloSvc := services.NewCreateTituloService(investimentoRepo)
+	listTitulosSvc := services.NewListTitulosService(investimentoRepo)
+	createOperacaoInvestimentoSvc := services.NewCreateOperacaoInvestimentoService(database.DB, investimentoRepo, transacaoRepo, ativoRepo, categoriaRepo, publicadorEventos)
+	listOperacoesInvestimentoSvc := services.NewListOperacoesInvestimentoService(investimentoRepo)
+	deleteOperacaoInvestimentoSvc := services.NewDeleteOperacaoInvestimentoService(database.DB, investimentoRepo, transacaoRepo, ativoRepo, transacaoHistoricoRepo)
+	registrarCotacaoSvc := services.NewRegistrarCotacaoService(database.DB, investimentoRepo)
+	importarCotacoesSvc := services.NewImportarCotacoesService(database.DB, investimentoRepo)
+	posicoesInvestimentoSvc := services.NewPosicoesInvestimentoService(investimentoRepo)
+	alocacaoCarteiraSvc := services.NewAlocacaoCarteiraService(posicoesInvestimentoSvc, conversorMoedas, moedaBase)
+	simularFinanciamentoSvc := services.NewSimularFinanciamentoService()
+	createFinanciamentoSvc := services.NewCreateFinanciamentoService(database.DB, financiamentoRepo, ativoRepo)
+	listFinanciamentosSvc := services.NewListFinanciamentosService(financiamentoRepo)
+	getFinanciamentoSvc := services.NewGetFinanciamentoService(financiamentoRepo)
+	pagarParcelaSvc := services.NewPagarParcelaFinanciamentoService(database.DB, financiamentoRepo, transacaoRepo, ativoRepo, categoriaRepo, publicadorEventos)
+	amortizarFinanciamentoSvc := services.NewAmortizarFinanciamentoService(database.DB, financiamentoRepo, transacaoRepo, ativoRepo, categoriaRepo, publicadorEventos)
+	processarParcelasSvc := services.NewProcessarParcelasFinanciamentoService(financiamentoRepo, pagarParcelaSvc)
+	createMetaSvc := services.NewCreateMetaService(database.DB, metaRepo, ativoRepo, moedaBase)
+	listMetasSvc := services.NewListMetasService(metaRepo, ativoRepo, transacaoRepo, conversorMoedas)
+	getMetaSvc := services.NewGetMetaService(metaRepo, ativoRepo, transacaoRepo, conversorMoedas)
+	deleteMetaSvc := services.NewDeleteMetaService(metaRepo)
+	metasEmRiscoSvc := services.NewMetasEmRiscoService(listMetasSvc)
+	salvarOrcamentoSvc := services.NewSalvarOrcamentoService(orcamentoRepo, categoriaRepo)
+	listOrcamentosSvc := services.NewListOrcamentosService(orcamentoRepo)
+	deleteOrcamentoSvc := services.NewDeleteOrcamentoService(orcamentoRepo)
+	listNotificacoesSvc := services.NewListNotificacoesService(notificacaoRepo)
+	marcarNotificacaoLidaSvc := services.NewMarcarNotificacaoLidaService(notificacaoRepo)
+	listPreferenciasNotificacaoSvc := services.NewListPreferenciasNotificacaoService(notificacaoRepo)
+	updatePreferenciaNotificacaoSvc := services.NewUpdatePreferenciaNotificacaoService(notificacaoRepo, notificadorSvc)
+	createWebhookSvc := services.NewCreateWebhookService(webhookRepo)
+	listWebhooksSvc := services.NewListWebhooksService(webhookRepo)
+	deleteWebhookSvc := services.NewDeleteWebhookService(webhookRepo)
+	entregasWebhookSvc := services.NewEntregasWebhookService(webhookRepo)
+	despacharWebhooksSvc := services.NewDespacharWebhooksService(database.DB, webhookRepo, webhook.NewCliente(10*time.Second))
+	streamEventosSvc := services.NewStreamEventosService(database.DB, streamRepo, retencaoStream)
+	go streamEventosSvc.Ouvir(context.Background())
+	gerarBackupSvc := services.NewGerarBackupService(database.DB, backupRepo)
+	restaurarBackupSvc := services.NewRestaurarBackupService(database.DB, backupRepo)
+	verificarNotificacoesSvc := services.NewVerificarNotificacoesService(notificadorSvc, transacaoRecorrenteRepo, ativoRepo, orcamentoRepo, relatorioCategoriasSvc, conversorMoedas, moedaBase)
 
 	// Handlers
-	ativoHandler := handlers.NewAtivoHandler(createAtivoSvc, listAtivoSvc, deactivateAtivoSvc)
-	transacaoHandler := handlers.NewTransacaoHandler(createTransacaoSvc, listTransacoesSvc, reverseTransacaoSvc)
+	ativoHandler := handlers.NewAtivoHandler(createAtivoSvc, listAtivoSvc, getAtivoSvc, updateAtivoSvc, deactivateAtivoSvc, reactivateAtivoSvc, saldoProjetadoSvc, jurosChequeEspecialSvc, recalcularSaldoSvc)
+	transacaoHandler := handlers.NewTransacaoHandler(createTransacaoSvc, listTransacoesSvc, reverseTransacaoSvc, updateTransacaoSvc, deleteTransacaoSvc, listTransacaoHistoricoSvc, listDuplicatasSvc, mesclarTransacoesSvc, efetivarTransacaoSvc, cancelarTransacaoSvc, processarAgendadasSvc)
 	categoriaHandler := handlers.NewCategoriaHandler(createCategoriaSvc, listCategoriaSvc)
 	transacaoRecorrenteHandler := handlers.NewTransacaoRecorrenteHandler(createRecorrenciaSvc, listRecorrenciasSvc, processarRecorrenciasSvc)
+	relatorioHandler := handlers.NewRelatorioHandler(relatorioCategoriasSvc, relatorioTagsSvc, relatorioMensalSvc)
+	exportacaoHandler := handlers.NewExportacaoHandler(exportarTransacoesSvc, exportarRelatoriosSvc)
+	tagHandler := handlers.NewTagHandler(listTagsSvc)
+	anexoHandler := handlers.NewAnexoHandler(uploadAnexoSvc, listAnexosSvc, downloadAnexoSvc, deleteAnexoSvc)
+	regraHandler := handlers.NewRegraHandler(createRegraSvc, listRegrasSvc, deleteRegraSvc, aplicarRegrasSvc)
+	idempotenciaHandler := handlers.NewIdempotenciaHandler(idempotenciaSvc)
+	cambioHandler := handlers.NewCambioHandler(createTaxaCambioSvc, listTaxasCambioSvc)
+	transferenciaHandler := handlers.NewTransferenciaHandler(createTransferenciaSvc, efetivarTransferenciaSvc)
+	financiamentoHandler := handlers.NewFinanciamentoHandler(simularFinanciamentoSvc, createFinanciamentoSvc, listFinanciamentosSvc, getFinanciamentoSvc, pagarParcelaSvc, amortizarFinanciamentoSvc, processarParcelasSvc)
+	metaHandler := handlers.NewMetaHandler(createMetaSvc, listMetasSvc, getMetaSvc, deleteMetaSvc, metasEmRiscoSvc)
+	orcamentoHandler := handlers.NewOrcamentoHandler(salvarOrcamentoSvc, listOrcamentosSvc, deleteOrcamentoSvc)
+	streamHandler := handlers.NewStreamHandler(streamEventosSvc)
+	backupHandler := handlers.NewBackupHandler(gerarBackupSvc, restaurarBackupSvc)
+	webhookHandler := handlers.NewWebhookHandler(createWebhookSvc, listWebhooksSvc, deleteWebhookSvc, entregasWebhookSvc, despacharWebhooksSvc)
+	notificacaoHandler := handlers.NewNotificacaoHandler(listNotificacoesSvc, marcarNotificacaoLidaSvc, listPreferenciasNotificacaoSvc, updatePreferenciaNotificacaoSvc, verificarNotificacoesSvc)
+	investimentoHandler := handlers.NewInvestimentoHandler(createTituloSvc, listTitulosSvc, createOperacaoInvestimentoSvc, listOperacoesInvestimentoSvc, deleteOperacaoInvestimentoSvc, registrarCotacaoSvc, importarCotacoesSvc, posicoesInvestimentoSvc, alocacaoCarteiraSvc)
+	conciliacaoHandler := handlers.NewConciliacaoHandler(createConciliacaoSvc, listConciliacoesSvc, resumoConciliacaoSvc, marcarConciliadasSvc, concluirConciliacaoSvc, deleteConciliacaoSvc, desbloquearTransacaoSvc)
+	docsHandler, err := handlers.NewDocsHandler()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Falha ao gerar a documentação da API")
+	}
 
 
 	// --- SETUP DO SERVIDOR ---
-	r := router.SetupRouter(ativoHandler, transacaoHandler, categoriaHandler, transacaoRecorrenteHandler)
+	r := router.SetupRouter(ativoHandler, transacaoHandler, categoriaHandler, transacaoRecorrenteHandler, relatorioHandler, tagHandler, anexoHandler, regraHandler, conciliacaoHandler, cambioHandler, transferenciaHandler, investimentoHandler, financiamentoHandler, metaHandler, notificacaoHandler, orcamentoHandler, webhookHandler, streamHandler, exportacaoHandler, backupHandler, idempotenciaHandler, docsHandler, idempotenciaSvc)
 
 	log.Info().Msg("Servidor iniciado na porta :8080")
 	if err := r.Run(":8080"); err != nil {
 		log.Fatal().Err(err).Msg("Falha ao iniciar o servidor Gin")
 	}
-}
\ No newline at end of file
+}
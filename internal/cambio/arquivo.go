@@ -0,0 +1,127 @@
+package cambio
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProvedorArquivo lê cotações de um arquivo CSV com as colunas
+// data (AAAA-MM-DD), moeda_origem, moeda_destino e taxa. Uma linha de cabeçalho é opcional.
+// O arquivo é relido sempre que sua data de modificação muda.
+type ProvedorArquivo struct {
+	caminho string
+
+	mu         sync.Mutex
+	modificado time.Time
+	cotacoes   map[string][]cotacaoDia
+}
+
+type cotacaoDia struct {
+	data time.Time
+	taxa float64
+}
+
+func NewProvedorArquivo(caminho string) (*ProvedorArquivo, error) {
+	p := &ProvedorArquivo{caminho: caminho}
+	if err := p.recarregar(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *ProvedorArquivo) Nome() string { return "ARQUIVO" }
+
+// Cotacao busca a cotação do par na data ou, na falta dela, a mais recente anterior.
+// Se só houver o par inverso, usa o inverso da taxa.
+func (p *ProvedorArquivo) Cotacao(ctx context.Context, origem, destino string, data time.Time) (float64, error) {
+	if err := p.recarregar(); err != nil {
+		return 0, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if taxa, ok := vigente(p.cotacoes[origem+"/"+destino], data); ok {
+		return taxa, nil
+	}
+	if taxa, ok := vigente(p.cotacoes[destino+"/"+origem], data); ok {
+		return 1 / taxa, nil
+	}
+	return 0, ErrCotacaoIndisponivel
+}
+
+// vigente retorna a última cotação com data até 'data'; a lista está ordenada por data.
+func vigente(lista []cotacaoDia, data time.Time) (float64, bool) {
+	i := sort.Search(len(lista), func(i int) bool { return lista[i].data.After(data) })
+	if i == 0 {
+		return 0, false
+	}
+	return lista[i-1].taxa, true
+}
+
+func (p *ProvedorArquivo) recarregar() error {
+	info, err := os.Stat(p.caminho)
+	if errors.Is(err, os.ErrNotExist) {
+		// Sem arquivo, o provedor simplesmente não tem cotações.
+		p.mu.Lock()
+		p.cotacoes, p.modificado = nil, time.Time{}
+		p.mu.Unlock()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if info.ModTime().Equal(p.modificado) && p.cotacoes != nil {
+		return nil
+	}
+
+	f, err := os.Open(p.caminho)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cotacoes := make(map[string][]cotacaoDia)
+	leitor := csv.NewReader(f)
+	leitor.FieldsPerRecord = 4
+	leitor.TrimLeadingSpace = true
+	for linha := 1; ; linha++ {
+		registro, err := leitor.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("arquivo de cotações inválido: %w", err)
+		}
+		data, err := time.Parse("2006-01-02", registro[0])
+		if err != nil {
+			if linha == 1 {
+				continue // cabeçalho
+			}
+			return fmt.Errorf("arquivo de cotações, linha %d: data inválida %q", linha, registro[0])
+		}
+		taxa, err := strconv.ParseFloat(registro[3], 64)
+		if err != nil || taxa <= 0 {
+			return fmt.Errorf("arquivo de cotações, linha %d: taxa inválida %q", linha, registro[3])
+		}
+		par := strings.ToUpper(registro[1]) + "/" + strings.ToUpper(registro[2])
+		cotacoes[par] = append(cotacoes[par], cotacaoDia{data: data, taxa: taxa})
+	}
+	for _, lista := range cotacoes {
+		sort.Slice(lista, func(i, j int) bool { return lista[i].data.Before(lista[j].data) })
+	}
+
+	p.cotacoes, p.modificado = cotacoes, info.ModTime()
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package cambio obtém cotações de moedas de fontes externas.
+package cambio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"controlador/backend/internal/config"
+)
+
+// ErrCotacaoIndisponivel é retornado quando o provedor não tem cotação para o par e a data.
+var ErrCotacaoIndisponivel = errors.New("cotação indisponível no provedor")
+
+// Provedor abstrai de onde vêm as cotações usadas quando não há taxa cadastrada manualmente.
+type Provedor interface {
+	// Nome identifica o provedor na fonte das taxas gravadas.
+	Nome() string
+	// Cotacao retorna quanto vale 1 unidade de 'origem' em 'destino' na data informada,
+	// ou a cotação mais recente anterior a ela.
+	Cotacao(ctx context.Context, origem, destino string, data time.Time) (float64, error)
+}
+
+// NewFromEnv cria o provedor configurado pela variável CAMBIO_PROVEDOR.
+// Vazio (padrão) desativa a consulta automática: somente taxas cadastradas são usadas.
+// "arquivo" lê as cotações do CSV em CAMBIO_ARQUIVO, útil para uso offline.
+func NewFromEnv() (Provedor, error) {
+	switch provedor := os.Getenv("CAMBIO_PROVEDOR"); provedor {
+	case "":
+		return nil, nil
+	case "arquivo":
+		return NewProvedorArquivo(config.Getenv("CAMBIO_ARQUIVO", "./data/cotacoes.csv"))
+	default:
+		return nil, fmt.Errorf("CAMBIO_PROVEDOR desconhecido: %s", provedor)
+	}
+}
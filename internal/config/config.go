@@ -0,0 +1,12 @@
+// Package config reúne a leitura das variáveis de ambiente compartilhada pelos pacotes.
+package config
+
+import "os"
+
+// Getenv retorna o valor da variável de ambiente 'chave', ou 'padrao' se ela estiver vazia.
+func Getenv(chave, padrao string) string {
+	if v := os.Getenv(chave); v != "" {
+		return v
+	}
+	return padrao
+}
@@ -30,7 +30,7 @@ func Migrate() {
 	// ALTERAÇÃO: Comando para apagar todas as tabelas antes de criá-las.
 	// A palavra-chave 'CASCADE' garante que as dependências (foreign keys) sejam resolvidas.
 	// ATENÇÃO: ISTO APAGA TODOS OS DADOS A CADA REINICIALIZAÇÃO. USE APENAS EM DESENVOLVIMENTO.
-	dropTablesSQL := `DROP TABLE IF EXISTS transacoes_recorrentes, transacoes, categorias, ativos_financeiros CASCADE;`
+	dropTablesSQL := `DROP TABLE IF EXISTS eventos_stream, eventos_stream_sequencia, webhook_entregas, webhook_assinaturas, eventos_outbox, notificacoes, preferencias_notificacao, orcamentos, meta_ativos, metas, amortizacoes_extras, parcelas_financiamento, financiamentos, cotacoes_titulos, operacoes_investimento, titulos, transferencias, taxas_cambio, juros_cheque_especial, chaves_idempotencia, regras, anexos, transacao_recorrente_tags, transacao_tags, tags, transacao_divisoes, transacoes_historico, transacoes_recorrentes, transacoes, conciliacoes, categorias, ativos_financeiros CASCADE;`
 	if _, err := DB.Exec(context.Background(), dropTablesSQL); err != nil {
 		log.Fatal().Err(err).Msg("Falha ao apagar tabelas existentes.")
 	}
@@ -46,6 +46,10 @@ func Migrate() {
 		tipo VARCHAR(50) NOT NULL,
 		saldo_atual NUMERIC(15, 2) DEFAULT 0.00,
 		limite_disponivel NUMERIC(15, 2) DEFAULT 0.00,
+		limite_total NUMERIC(15, 2) NOT NULL DEFAULT 0.00,
+		moeda CHAR(3) NOT NULL DEFAULT 'BRL',
+		limite_cheque_especial NUMERIC(15, 2) NOT NULL DEFAULT 0.00,
+		taxa_juros_cheque_especial NUMERIC(7, 4) NOT NULL DEFAULT 0.00,
 		is_active BOOLEAN NOT NULL DEFAULT TRUE,
 		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
@@ -67,6 +71,25 @@ func Migrate() {
 	}
 	log.Info().Msg("Migração da tabela 'categorias' concluída.")
 
+	// Migração de Conciliações
+	// Só pode haver uma conciliação aberta por ativo.
+	createConciliacoesSQL := `
+	CREATE TABLE IF NOT EXISTS conciliacoes (
+		id UUID PRIMARY KEY,
+		ativo_financeiro_id UUID NOT NULL REFERENCES ativos_financeiros(id) ON DELETE CASCADE,
+		data_extrato DATE NOT NULL,
+		saldo_extrato NUMERIC(15, 2) NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'ABERTA',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		concluida_em TIMESTAMPTZ NULL,
+		CONSTRAINT chk_status_conciliacao CHECK (status IN ('ABERTA', 'CONCLUIDA'))
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_conciliacoes_aberta ON conciliacoes (ativo_financeiro_id) WHERE status = 'ABERTA';`
+	if _, err := DB.Exec(context.Background(), createConciliacoesSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'conciliacoes'.")
+	}
+	log.Info().Msg("Migração da tabela 'conciliacoes' concluída.")
+
 	// Migração de Transações
 	createTransacoesSQL := `
 	CREATE TABLE IF NOT EXISTS transacoes (
@@ -76,14 +99,71 @@ func Migrate() {
 		descricao VARCHAR(255) NOT NULL,
 		valor NUMERIC(15, 2) NOT NULL,
 		tipo VARCHAR(50) NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'EFETIVADA',
+		moeda CHAR(3) NOT NULL DEFAULT 'BRL',
+		data TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		notas TEXT NOT NULL DEFAULT '',
 		reversal_of UUID NULL REFERENCES transacoes(id),
-		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-	);`
+		motivo_estorno TEXT NULL,
+		estorno_parcial BOOLEAN NOT NULL DEFAULT FALSE,
+		valor_estornado NUMERIC(15, 2) NOT NULL DEFAULT 0.00,
+		conciliada BOOLEAN NOT NULL DEFAULT FALSE,
+		bloqueada BOOLEAN NOT NULL DEFAULT FALSE,
+		conciliacao_id UUID NULL REFERENCES conciliacoes(id) ON DELETE SET NULL,
+		origem VARCHAR(30) NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		-- Somente o lançamento de saldo inicial pode ter valor negativo, e ele não é estornável.
+		CONSTRAINT chk_valor_estornado CHECK (valor_estornado >= 0 AND valor_estornado <= GREATEST(valor, 0)),
+		CONSTRAINT chk_status CHECK (status IN ('AGENDADA', 'PENDENTE', 'EFETIVADA', 'CANCELADA'))
+	);
+	CREATE INDEX IF NOT EXISTS idx_transacoes_agendadas ON transacoes (data) WHERE status = 'AGENDADA';`
 	if _, err := DB.Exec(context.Background(), createTransacoesSQL); err != nil {
 		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'transacoes'.")
 	}
+
+	// Garante no banco que uma transação tenha no máximo um estorno total.
+	// Estornos parciais são limitados pela restrição 'chk_valor_estornado'.
+	createEstornoUnicoSQL := `
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_transacoes_estorno_total
+		ON transacoes (reversal_of)
+		WHERE reversal_of IS NOT NULL AND estorno_parcial = FALSE;`
+	if _, err := DB.Exec(context.Background(), createEstornoUnicoSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao criar índice de estorno único em 'transacoes'.")
+	}
 	log.Info().Msg("Migração da tabela 'transacoes' concluída.")
 
+	// Migração das Divisões (rateio) de Transações
+	createTransacaoDivisoesSQL := `
+	CREATE TABLE IF NOT EXISTS transacao_divisoes (
+		id UUID PRIMARY KEY,
+		transacao_id UUID NOT NULL REFERENCES transacoes(id) ON DELETE CASCADE,
+		categoria_id UUID NOT NULL REFERENCES categorias(id),
+		valor NUMERIC(15, 2) NOT NULL CHECK (valor > 0),
+		memo VARCHAR(255) NOT NULL DEFAULT ''
+	);
+	CREATE INDEX IF NOT EXISTS idx_transacao_divisoes_transacao ON transacao_divisoes (transacao_id);`
+	if _, err := DB.Exec(context.Background(), createTransacaoDivisoesSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'transacao_divisoes'.")
+	}
+	log.Info().Msg("Migração da tabela 'transacao_divisoes' concluída.")
+
+	// Migração do Histórico de Transações
+	// Sem chave estrangeira para 'transacoes': o histórico sobrevive à exclusão da transação.
+	createTransacoesHistoricoSQL := `
+	CREATE TABLE IF NOT EXISTS transacoes_historico (
+		id UUID PRIMARY KEY,
+		transacao_id UUID NOT NULL,
+		versao INT NOT NULL,
+		operacao VARCHAR(50) NOT NULL,
+		dados JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		UNIQUE (transacao_id, versao)
+	);`
+	if _, err := DB.Exec(context.Background(), createTransacoesHistoricoSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'transacoes_historico'.")
+	}
+	log.Info().Msg("Migração da tabela 'transacoes_historico' concluída.")
+
 	// Migração de Transações Recorrentes
 	createTransacoesRecorrentesSQL := `
 	CREATE TABLE IF NOT EXISTS transacoes_recorrentes (
@@ -95,6 +175,8 @@ func Migrate() {
 		tipo VARCHAR(50) NOT NULL,
 		dia_do_vencimento INT NOT NULL CHECK (dia_do_vencimento >= 1 AND dia_do_vencimento <= 31),
 		ativa BOOLEAN NOT NULL DEFAULT TRUE,
+		-- Marca as recorrências pausadas pela desativação do ativo, que são retomadas na reativação.
+		pausada_por_desativacao BOOLEAN NOT NULL DEFAULT FALSE,
 		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 	);`
@@ -102,4 +184,412 @@ func Migrate() {
 		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'transacoes_recorrentes'.")
 	}
 	log.Info().Msg("Migração da tabela 'transacoes_recorrentes' concluída.")
-}
\ No newline at end of file
+
+	// Migração de Tags e de seus vínculos com transações e recorrências
+	createTagsSQL := `
+	CREATE TABLE IF NOT EXISTS tags (
+		id UUID PRIMARY KEY,
+		nome VARCHAR(50) NOT NULL UNIQUE
+	);
+	CREATE TABLE IF NOT EXISTS transacao_tags (
+		transacao_id UUID NOT NULL REFERENCES transacoes(id) ON DELETE CASCADE,
+		tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+		PRIMARY KEY (transacao_id, tag_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_transacao_tags_tag ON transacao_tags (tag_id);
+	CREATE TABLE IF NOT EXISTS transacao_recorrente_tags (
+		transacao_recorrente_id UUID NOT NULL REFERENCES transacoes_recorrentes(id) ON DELETE CASCADE,
+		tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+		PRIMARY KEY (transacao_recorrente_id, tag_id)
+	);`
+	if _, err := DB.Exec(context.Background(), createTagsSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabelas de tags.")
+	}
+	log.Info().Msg("Migração das tabelas de tags concluída.")
+
+	// Migração de Anexos
+	createAnexosSQL := `
+	CREATE TABLE IF NOT EXISTS anexos (
+		id UUID PRIMARY KEY,
+		transacao_id UUID NOT NULL REFERENCES transacoes(id) ON DELETE CASCADE,
+		nome_arquivo VARCHAR(255) NOT NULL,
+		content_type VARCHAR(100) NOT NULL,
+		tamanho BIGINT NOT NULL,
+		chave VARCHAR(512) NOT NULL,
+		chave_miniatura VARCHAR(512) NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	CREATE INDEX IF NOT EXISTS idx_anexos_transacao ON anexos (transacao_id);`
+	if _, err := DB.Exec(context.Background(), createAnexosSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'anexos'.")
+	}
+	log.Info().Msg("Migração da tabela 'anexos' concluída.")
+
+	// Migração de Regras de Categorização
+	createRegrasSQL := `
+	CREATE TABLE IF NOT EXISTS regras (
+		id UUID PRIMARY KEY,
+		nome VARCHAR(255) NOT NULL,
+		prioridade INT NOT NULL DEFAULT 100,
+		ativa BOOLEAN NOT NULL DEFAULT TRUE,
+		condicoes JSONB NOT NULL,
+		acoes JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`
+	if _, err := DB.Exec(context.Background(), createRegrasSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'regras'.")
+	}
+	log.Info().Msg("Migração da tabela 'regras' concluída.")
+
+	// Migração de Chaves de Idempotência
+	createChavesIdempotenciaSQL := `
+	CREATE TABLE IF NOT EXISTS chaves_idempotencia (
+		chave VARCHAR(255) PRIMARY KEY,
+		fingerprint CHAR(64) NOT NULL,
+		status_code INT NULL,
+		content_type VARCHAR(255) NOT NULL DEFAULT '',
+		corpo BYTEA NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		expires_at TIMESTAMPTZ NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_chaves_idempotencia_expiracao ON chaves_idempotencia (expires_at);`
+	if _, err := DB.Exec(context.Background(), createChavesIdempotenciaSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'chaves_idempotencia'.")
+	}
+	log.Info().Msg("Migração da tabela 'chaves_idempotencia' concluída.")
+
+	// Migração dos Lançamentos de Juros de Cheque Especial
+	// A chave primária garante no máximo um lançamento de juros por conta e dia.
+	createJurosChequeEspecialSQL := `
+	CREATE TABLE IF NOT EXISTS juros_cheque_especial (
+		ativo_financeiro_id UUID NOT NULL REFERENCES ativos_financeiros(id) ON DELETE CASCADE,
+		data DATE NOT NULL,
+		transacao_id UUID NOT NULL REFERENCES transacoes(id) ON DELETE CASCADE,
+		saldo_base NUMERIC(15, 2) NOT NULL,
+		valor NUMERIC(15, 2) NOT NULL,
+		PRIMARY KEY (ativo_financeiro_id, data)
+	);`
+	if _, err := DB.Exec(context.Background(), createJurosChequeEspecialSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'juros_cheque_especial'.")
+	}
+	log.Info().Msg("Migração da tabela 'juros_cheque_especial' concluída.")
+
+	// Migração da Tabela de Taxas de Câmbio
+	// Uma cotação por par de moedas e dia; a mais recente até a data da conversão é a vigente.
+	createTaxasCambioSQL := `
+	CREATE TABLE IF NOT EXISTS taxas_cambio (
+		id UUID PRIMARY KEY,
+		moeda_origem CHAR(3) NOT NULL,
+		moeda_destino CHAR(3) NOT NULL,
+		data DATE NOT NULL,
+		taxa NUMERIC(18, 8) NOT NULL CHECK (taxa > 0),
+		fonte VARCHAR(50) NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		CONSTRAINT uq_taxa_cambio_dia UNIQUE (moeda_origem, moeda_destino, data),
+		CONSTRAINT chk_taxa_cambio_moedas CHECK (moeda_origem <> moeda_destino)
+	);`
+	if _, err := DB.Exec(context.Background(), createTaxasCambioSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'taxas_cambio'.")
+	}
+	log.Info().Msg("Migração da tabela 'taxas_cambio' concluída.")
+
+	// Migração da Tabela de Transferências
+	// As pontas não podem ser excluídas enquanto a transferência existir: uma ponta órfã manteria o efeito no saldo.
+	createTransferenciasSQL := `
+	CREATE TABLE IF NOT EXISTS transferencias (
+		id UUID PRIMARY KEY,
+		transacao_origem_id UUID NOT NULL UNIQUE REFERENCES transacoes(id) ON DELETE RESTRICT,
+		transacao_destino_id UUID NOT NULL UNIQUE REFERENCES transacoes(id) ON DELETE RESTRICT,
+		moeda_origem CHAR(3) NOT NULL,
+		moeda_destino CHAR(3) NOT NULL,
+		valor_origem NUMERIC(15, 2) NOT NULL,
+		valor_destino NUMERIC(15, 2) NOT NULL,
+		taxa NUMERIC(18, 8) NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`
+	if _, err := DB.Exec(context.Background(), createTransferenciasSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'transferencias'.")
+	}
+	log.Info().Msg("Migração da tabela 'transferencias' concluída.")
+
+	// Migração da Tabela de Títulos
+	createTitulosSQL := `
+	CREATE TABLE IF NOT EXISTS titulos (
+		id UUID PRIMARY KEY,
+		codigo VARCHAR(30) NOT NULL UNIQUE,
+		nome VARCHAR(255) NOT NULL,
+		classe VARCHAR(30) NOT NULL,
+		moeda CHAR(3) NOT NULL DEFAULT 'BRL',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`
+	if _, err := DB.Exec(context.Background(), createTitulosSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'titulos'.")
+	}
+	log.Info().Msg("Migração da tabela 'titulos' concluída.")
+
+	// Migração da Tabela de Operações de Investimento
+	// Cada operação é liquidada por uma transação no ativo financeiro, removida junto com ela.
+	createOperacoesInvestimentoSQL := `
+	CREATE TABLE IF NOT EXISTS operacoes_investimento (
+		id UUID PRIMARY KEY,
+		titulo_id UUID NOT NULL REFERENCES titulos(id),
+		ativo_financeiro_id UUID NOT NULL REFERENCES ativos_financeiros(id) ON DELETE CASCADE,
+		tipo VARCHAR(20) NOT NULL,
+		data DATE NOT NULL,
+		quantidade NUMERIC(20, 8) NOT NULL DEFAULT 0,
+		preco_unitario NUMERIC(18, 8) NOT NULL DEFAULT 0,
+		taxas NUMERIC(15, 2) NOT NULL DEFAULT 0,
+		valor_total NUMERIC(15, 2) NOT NULL,
+		transacao_id UUID NOT NULL UNIQUE REFERENCES transacoes(id),
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		CONSTRAINT chk_operacao_tipo CHECK (tipo IN ('COMPRA', 'VENDA', 'DIVIDENDO', 'JCP'))
+	);
+	CREATE INDEX IF NOT EXISTS idx_operacoes_investimento_titulo ON operacoes_investimento (titulo_id, data);`
+	if _, err := DB.Exec(context.Background(), createOperacoesInvestimentoSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'operacoes_investimento'.")
+	}
+	log.Info().Msg("Migração da tabela 'operacoes_investimento' concluída.")
+
+	// Migração da Tabela de Cotações de Títulos
+	createCotacoesTitulosSQL := `
+	CREATE TABLE IF NOT EXISTS cotacoes_titulos (
+		titulo_id UUID NOT NULL REFERENCES titulos(id) ON DELETE CASCADE,
+		data DATE NOT NULL,
+		preco NUMERIC(18, 8) NOT NULL CHECK (preco > 0),
+		fonte VARCHAR(20) NOT NULL,
+		PRIMARY KEY (titulo_id, data)
+	);`
+	if _, err := DB.Exec(context.Background(), createCotacoesTitulosSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'cotacoes_titulos'.")
+	}
+	log.Info().Msg("Migração da tabela 'cotacoes_titulos' concluída.")
+
+	// Migração da Tabela de Financiamentos
+	// Um ativo de empréstimo tem no máximo um contrato de financiamento.
+	createFinanciamentosSQL := `
+	CREATE TABLE IF NOT EXISTS financiamentos (
+		id UUID PRIMARY KEY,
+		ativo_financeiro_id UUID NOT NULL UNIQUE REFERENCES ativos_financeiros(id) ON DELETE CASCADE,
+		ativo_pagamento_id UUID NOT NULL REFERENCES ativos_financeiros(id),
+		sistema VARCHAR(10) NOT NULL,
+		taxa_juros_mensal NUMERIC(9, 6) NOT NULL CHECK (taxa_juros_mensal >= 0),
+		valor_principal NUMERIC(15, 2) NOT NULL CHECK (valor_principal > 0),
+		saldo_devedor NUMERIC(15, 2) NOT NULL CHECK (saldo_devedor >= 0),
+		prazo_meses INTEGER NOT NULL CHECK (prazo_meses > 0),
+		data_primeira_parcela DATE NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'ATIVO',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		CONSTRAINT chk_financiamento_sistema CHECK (sistema IN ('SAC', 'PRICE')),
+		CONSTRAINT chk_financiamento_status CHECK (status IN ('ATIVO', 'QUITADO'))
+	);`
+	if _, err := DB.Exec(context.Background(), createFinanciamentosSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'financiamentos'.")
+	}
+	log.Info().Msg("Migração da tabela 'financiamentos' concluída.")
+
+	// Migração da Tabela de Parcelas de Financiamento
+	// As parcelas pendentes são regravadas a cada amortização extraordinária.
+	createParcelasFinanciamentoSQL := `
+	CREATE TABLE IF NOT EXISTS parcelas_financiamento (
+		id UUID PRIMARY KEY,
+		financiamento_id UUID NOT NULL REFERENCES financiamentos(id) ON DELETE CASCADE,
+		numero INTEGER NOT NULL,
+		data_vencimento DATE NOT NULL,
+		valor NUMERIC(15, 2) NOT NULL,
+		juros NUMERIC(15, 2) NOT NULL,
+		amortizacao NUMERIC(15, 2) NOT NULL,
+		saldo_devedor NUMERIC(15, 2) NOT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'PENDENTE',
+		transacao_id UUID NULL REFERENCES transacoes(id),
+		paga_em TIMESTAMPTZ NULL,
+		CONSTRAINT uq_parcela_numero UNIQUE (financiamento_id, numero),
+		CONSTRAINT chk_parcela_status CHECK (status IN ('PENDENTE', 'PAGA'))
+	);
+	CREATE INDEX IF NOT EXISTS idx_parcelas_pendentes ON parcelas_financiamento (data_vencimento) WHERE status = 'PENDENTE';`
+	if _, err := DB.Exec(context.Background(), createParcelasFinanciamentoSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'parcelas_financiamento'.")
+	}
+	log.Info().Msg("Migração da tabela 'parcelas_financiamento' concluída.")
+
+	// Migração da Tabela de Amortizações Extraordinárias
+	createAmortizacoesExtrasSQL := `
+	CREATE TABLE IF NOT EXISTS amortizacoes_extras (
+		id UUID PRIMARY KEY,
+		financiamento_id UUID NOT NULL REFERENCES financiamentos(id) ON DELETE CASCADE,
+		data DATE NOT NULL,
+		valor NUMERIC(15, 2) NOT NULL CHECK (valor > 0),
+		modo VARCHAR(10) NOT NULL,
+		transacao_id UUID NOT NULL REFERENCES transacoes(id),
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`
+	if _, err := DB.Exec(context.Background(), createAmortizacoesExtrasSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabela 'amortizacoes_extras'.")
+	}
+	log.Info().Msg("Migração da tabela 'amortizacoes_extras' concluída.")
+
+	// Migração da Tabela de Metas
+	createMetasSQL := `
+	CREATE TABLE IF NOT EXISTS metas (
+		id UUID PRIMARY KEY,
+		nome VARCHAR(255) NOT NULL,
+		valor_alvo NUMERIC(15, 2) NOT NULL CHECK (valor_alvo > 0),
+		data_alvo DATE NOT NULL,
+		moeda CHAR(3) NOT NULL DEFAULT 'BRL',
+		tag VARCHAR(50) NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS meta_ativos (
+		meta_id UUID NOT NULL REFERENCES metas(id) ON DELETE CASCADE,
+		ativo_financeiro_id UUID NOT NULL REFERENCES ativos_financeiros(id) ON DELETE CASCADE,
+		PRIMARY KEY (meta_id, ativo_financeiro_id)
+	);`
+	if _, err := DB.Exec(context.Background(), createMetasSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabelas de metas.")
+	}
+	log.Info().Msg("Migração das tabelas de metas concluída.")
+
+	// Migração das Tabelas de Notificações e Orçamentos
+	createNotificacoesSQL := `
+	CREATE TABLE IF NOT EXISTS preferencias_notificacao (
+		evento VARCHAR(50) PRIMARY KEY,
+		ativo BOOLEAN NOT NULL DEFAULT TRUE,
+		canais TEXT[] NOT NULL DEFAULT '{INAPP}',
+		antecedencia_dias INTEGER NOT NULL DEFAULT 3 CHECK (antecedencia_dias BETWEEN 0 AND 31),
+		limite NUMERIC(15, 2) NOT NULL DEFAULT 0,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS notificacoes (
+		id UUID PRIMARY KEY,
+		evento VARCHAR(50) NOT NULL,
+		chave VARCHAR(255) NOT NULL UNIQUE,
+		titulo VARCHAR(255) NOT NULL,
+		mensagem TEXT NOT NULL,
+		canais TEXT[] NOT NULL,
+		lida_em TIMESTAMPTZ NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	CREATE INDEX IF NOT EXISTS idx_notificacoes_created_at ON notificacoes (created_at DESC);
+	CREATE TABLE IF NOT EXISTS orcamentos (
+		categoria_id UUID PRIMARY KEY REFERENCES categorias(id) ON DELETE CASCADE,
+		valor_mensal NUMERIC(15, 2) NOT NULL CHECK (valor_mensal > 0),
+		percentual_alerta NUMERIC(5, 2) NOT NULL DEFAULT 80 CHECK (percentual_alerta > 0 AND percentual_alerta <= 100),
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);`
+	if _, err := DB.Exec(context.Background(), createNotificacoesSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabelas de notificações e orçamentos.")
+	}
+	log.Info().Msg("Migração das tabelas de notificações e orçamentos concluída.")
+
+	// Migração das Tabelas de Webhooks (outbox, assinaturas e entregas)
+	createWebhooksSQL := `
+	CREATE TABLE IF NOT EXISTS eventos_outbox (
+		id UUID PRIMARY KEY,
+		tipo VARCHAR(50) NOT NULL,
+		dados JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		distribuido_em TIMESTAMPTZ NULL
+	);
+	CREATE INDEX IF NOT EXISTS idx_eventos_outbox_pendentes ON eventos_outbox (created_at) WHERE distribuido_em IS NULL;
+	CREATE TABLE IF NOT EXISTS webhook_assinaturas (
+		id UUID PRIMARY KEY,
+		url TEXT NOT NULL,
+		segredo VARCHAR(64) NOT NULL,
+		eventos TEXT[] NOT NULL,
+		ativo BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	CREATE TABLE IF NOT EXISTS webhook_entregas (
+		id UUID PRIMARY KEY,
+		assinatura_id UUID NOT NULL REFERENCES webhook_assinaturas(id) ON DELETE CASCADE,
+		evento_id UUID NOT NULL REFERENCES eventos_outbox(id) ON DELETE CASCADE,
+		status VARCHAR(20) NOT NULL DEFAULT 'PENDENTE',
+		tentativas INTEGER NOT NULL DEFAULT 0,
+		proxima_tentativa_em TIMESTAMPTZ NULL,
+		ultimo_status_http INTEGER NULL,
+		ultimo_erro TEXT NULL,
+		entregue_em TIMESTAMPTZ NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		UNIQUE (assinatura_id, evento_id)
+	);
+	CREATE INDEX IF NOT EXISTS idx_webhook_entregas_pendentes ON webhook_entregas (proxima_tentativa_em) WHERE status = 'PENDENTE';`
+	if _, err := DB.Exec(context.Background(), createWebhooksSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar tabelas de webhooks.")
+	}
+	log.Info().Msg("Migração das tabelas de webhooks concluída.")
+
+	// Migração do Stream de Eventos
+	// Os gatilhos gravam o evento na transação da alteração e avisam os servidores com NOTIFY, que
+	// o Postgres só entrega quando ela é confirmada. O aviso leva apenas o ID do evento, para não
+	// esbarrar no limite de tamanho do NOTIFY.
+	// Quem retoma o stream pede os eventos posteriores ao último ID recebido, então os IDs precisam
+	// seguir a ordem de confirmação: com uma sequência, uma transação mais lenta confirmaria um ID
+	// menor depois de o cliente já ter recebido um maior, e o evento se perderia na retomada. Por
+	// isso os gatilhos são adiados para o fim da transação e tiram o ID de um contador de linha
+	// única, cujo bloqueio só é liberado na confirmação. Como o bloqueio é o último a ser tomado, a
+	// transação que o detém não espera por nenhuma outra.
+	createStreamSQL := `
+	CREATE TABLE IF NOT EXISTS eventos_stream (
+		id BIGINT PRIMARY KEY,
+		tipo VARCHAR(50) NOT NULL,
+		ativo_financeiro_id UUID NOT NULL,
+		dados JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+	CREATE INDEX IF NOT EXISTS idx_eventos_stream_created_at ON eventos_stream (created_at);
+	CREATE TABLE IF NOT EXISTS eventos_stream_sequencia (
+		unica BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (unica),
+		ultimo_id BIGINT NOT NULL
+	);
+	INSERT INTO eventos_stream_sequencia (ultimo_id)
+	SELECT COALESCE(MAX(id), 0) FROM eventos_stream
+	ON CONFLICT (unica) DO NOTHING;
+	CREATE OR REPLACE FUNCTION registrar_evento_stream() RETURNS trigger AS $$
+	DECLARE
+		evento_id BIGINT;
+	BEGIN
+		-- A restauração de um backup carrega o histórico inteiro; nada disso é novidade para o stream.
+		IF current_setting('controlador.restaurando_backup', true) = 'on' THEN
+			RETURN NULL;
+		END IF;
+		UPDATE eventos_stream_sequencia SET ultimo_id = ultimo_id + 1 RETURNING ultimo_id INTO evento_id;
+		IF TG_TABLE_NAME = 'ativos_financeiros' THEN
+			INSERT INTO eventos_stream (id, tipo, ativo_financeiro_id, dados)
+			VALUES (evento_id, 'ativo.saldo_alterado', NEW.id, jsonb_build_object(
+				'id', NEW.id, 'nome', NEW.nome, 'tipo', NEW.tipo, 'moeda', NEW.moeda,
+				'saldo_atual', NEW.saldo_atual, 'limite_disponivel', NEW.limite_disponivel,
+				'saldo_anterior', OLD.saldo_atual, 'limite_disponivel_anterior', OLD.limite_disponivel));
+		ELSE
+			INSERT INTO eventos_stream (id, tipo, ativo_financeiro_id, dados)
+			VALUES (evento_id, 'transacao.criada', NEW.ativo_financeiro_id, jsonb_build_object(
+				'id', NEW.id, 'ativo_financeiro_id', NEW.ativo_financeiro_id, 'categoria_id', NEW.categoria_id,
+				'descricao', NEW.descricao, 'valor', NEW.valor, 'tipo', NEW.tipo, 'status', NEW.status,
+				'moeda', NEW.moeda, 'data', NEW.data, 'reversal_of', NEW.reversal_of, 'origem', NEW.origem,
+				'created_at', NEW.created_at));
+		END IF;
+		PERFORM pg_notify('eventos_stream', evento_id::text);
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql;
+	DROP TRIGGER IF EXISTS trg_stream_saldo_ativo ON ativos_financeiros;
+	CREATE CONSTRAINT TRIGGER trg_stream_saldo_ativo
+		AFTER UPDATE OF saldo_atual, limite_disponivel ON ativos_financeiros
+		DEFERRABLE INITIALLY DEFERRED
+		FOR EACH ROW
+		WHEN (OLD.saldo_atual IS DISTINCT FROM NEW.saldo_atual OR OLD.limite_disponivel IS DISTINCT FROM NEW.limite_disponivel)
+		EXECUTE FUNCTION registrar_evento_stream();
+	DROP TRIGGER IF EXISTS trg_stream_transacao_criada ON transacoes;
+	CREATE CONSTRAINT TRIGGER trg_stream_transacao_criada
+		AFTER INSERT ON transacoes
+		DEFERRABLE INITIALLY DEFERRED
+		FOR EACH ROW
+		EXECUTE FUNCTION registrar_evento_stream();`
+	if _, err := DB.Exec(context.Background(), createStreamSQL); err != nil {
+		log.Fatal().Err(err).Msg("Falha ao migrar o stream de eventos.")
+	}
+	log.Info().Msg("Migração do stream de eventos concluída.")
+}
@@ -0,0 +1,90 @@
+// Package erros define os erros de domínio da aplicação. Cada erro tem um código estável, que
+// os clientes podem usar para decidir o que fazer sem depender do texto da mensagem, e um tipo,
+// que a camada HTTP traduz para o status da resposta.
+package erros
+
+import "errors"
+
+// Tipo classifica o erro pelo motivo da falha, independentemente do recurso envolvido.
+type Tipo int
+
+const (
+	// TipoInvalido indica uma requisição malformada: JSON inválido, campo ausente, formato errado.
+	TipoInvalido Tipo = iota + 1
+	// TipoNaoEncontrado indica que o recurso pedido, ou referenciado, não existe.
+	TipoNaoEncontrado
+	// TipoConflito indica que o estado atual do recurso impede a operação.
+	TipoConflito
+	// TipoRegraNegocio indica uma requisição bem formada que viola uma regra de negócio.
+	TipoRegraNegocio
+	// TipoMuitoGrande indica um conteúdo acima do tamanho aceito.
+	TipoMuitoGrande
+	// TipoNaoSuportado indica um tipo de conteúdo que não é aceito.
+	TipoNaoSuportado
+)
+
+// Erro é um erro de domínio. Os erros conhecidos são declarados uma única vez, como variáveis
+// dos pacotes que os produzem, e podem ser embrulhados com fmt.Errorf("%w: ...") para detalhar
+// a mensagem sem perder o código.
+type Erro struct {
+	Tipo     Tipo
+	Codigo   string
+	Mensagem string
+	// Campos detalha, campo a campo, os problemas de uma requisição inválida.
+	Campos []CampoInvalido
+}
+
+// CampoInvalido aponta um campo da requisição e o problema encontrado nele.
+type CampoInvalido struct {
+	Campo    string `json:"campo"`
+	Mensagem string `json:"mensagem"`
+}
+
+func (e *Erro) Error() string {
+	return e.Mensagem
+}
+
+func Novo(tipo Tipo, codigo, mensagem string) *Erro {
+	return &Erro{Tipo: tipo, Codigo: codigo, Mensagem: mensagem}
+}
+
+func Invalido(codigo, mensagem string) *Erro {
+	return Novo(TipoInvalido, codigo, mensagem)
+}
+
+func NaoEncontrado(codigo, mensagem string) *Erro {
+	return Novo(TipoNaoEncontrado, codigo, mensagem)
+}
+
+func Conflito(codigo, mensagem string) *Erro {
+	return Novo(TipoConflito, codigo, mensagem)
+}
+
+func RegraNegocio(codigo, mensagem string) *Erro {
+	return Novo(TipoRegraNegocio, codigo, mensagem)
+}
+
+func MuitoGrande(codigo, mensagem string) *Erro {
+	return Novo(TipoMuitoGrande, codigo, mensagem)
+}
+
+func NaoSuportado(codigo, mensagem string) *Erro {
+	return Novo(TipoNaoSuportado, codigo, mensagem)
+}
+
+// CodigoDadosInvalidos identifica as requisições rejeitadas pela validação dos campos.
+const CodigoDadosInvalidos = "dados_invalidos"
+
+// Validacao cria o erro de uma requisição com campos inválidos.
+func Validacao(mensagem string, campos ...CampoInvalido) *Erro {
+	return &Erro{Tipo: TipoInvalido, Codigo: CodigoDadosInvalidos, Mensagem: mensagem, Campos: campos}
+}
+
+// Como encontra o erro de domínio na cadeia de 'err'.
+func Como(err error) (*Erro, bool) {
+	var e *Erro
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
@@ -0,0 +1,55 @@
+package exportacao
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// bomUTF8 faz o Excel abrir o CSV como UTF-8 em vez da codificação regional.
+const bomUTF8 = "\ufeff"
+
+type planilhaCSV struct {
+	w          *csv.Writer
+	localidade Localidade
+	valores    []string
+}
+
+func novaPlanilhaCSV(w io.Writer, localidade Localidade) (*planilhaCSV, error) {
+	if _, err := io.WriteString(w, bomUTF8); err != nil {
+		return nil, err
+	}
+	escritor := csv.NewWriter(w)
+	escritor.Comma = localidade.SeparadorCSV
+	return &planilhaCSV{w: escritor, localidade: localidade}, nil
+}
+
+func (p *planilhaCSV) Linha(celulas ...Celula) error {
+	p.valores = p.valores[:0]
+	for _, c := range celulas {
+		switch c.tipo {
+		case celulaNumero:
+			p.valores = append(p.valores, p.localidade.Numero(c.numero))
+		case celulaData:
+			p.valores = append(p.valores, p.localidade.Data(c.data))
+		default:
+			p.valores = append(p.valores, neutralizarFormula(c.texto))
+		}
+	}
+	return p.w.Write(p.valores)
+}
+
+func (p *planilhaCSV) Fechar() error {
+	p.w.Flush()
+	return p.w.Error()
+}
+
+// neutralizarFormula impede que um texto vindo do usuário, como a descrição de uma transação,
+// seja interpretado como fórmula pela planilha que abrir o CSV: o apóstrofo inicial faz o Excel
+// e o LibreOffice tratarem a célula como texto.
+func neutralizarFormula(texto string) string {
+	if texto != "" && strings.ContainsRune("=+-@\t\r", rune(texto[0])) {
+		return "'" + texto
+	}
+	return texto
+}
@@ -0,0 +1,132 @@
+// Package exportacao escreve transações e relatórios em CSV, XLSX e OFX diretamente no destino,
+// linha a linha, sem montar o arquivo inteiro em memória.
+package exportacao
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"controlador/backend/internal/erros"
+)
+
+var (
+	ErrFormatoInvalido    = erros.Invalido("formato_invalido", "formato de exportação inválido: use csv, ofx ou xlsx")
+	ErrFormatoNaoTabular  = erros.Invalido("formato_nao_tabular", "o formato OFX só está disponível para a exportação de transações")
+	ErrLocalidadeInvalida = erros.Invalido("localidade_invalida", "localidade inválida: use pt-BR ou en-US")
+)
+
+type Formato string
+
+const (
+	FormatoCSV  Formato = "csv"
+	FormatoOFX  Formato = "ofx"
+	FormatoXLSX Formato = "xlsx"
+)
+
+// ParseFormato converte o parâmetro 'formato'; vazio resulta em CSV.
+func ParseFormato(v string) (Formato, error) {
+	switch f := Formato(strings.ToLower(strings.TrimSpace(v))); f {
+	case "":
+		return FormatoCSV, nil
+	case FormatoCSV, FormatoOFX, FormatoXLSX:
+		return f, nil
+	}
+	return "", ErrFormatoInvalido
+}
+
+func (f Formato) ContentType() string {
+	switch f {
+	case FormatoXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatoOFX:
+		return "application/x-ofx; charset=windows-1252"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// NomeArquivo monta o nome sugerido para download, com a extensão do formato.
+func (f Formato) NomeArquivo(base string) string {
+	return base + "." + string(f)
+}
+
+// Localidade define como números e datas aparecem nos arquivos CSV e XLSX. O OFX tem formato
+// próprio, fixo pela especificação, e não é afetado.
+type Localidade struct {
+	Codigo           string
+	SeparadorDecimal string
+	// SeparadorCSV é o separador de colunas; com vírgula decimal, o padrão é ponto e vírgula.
+	SeparadorCSV rune
+	FormatoData  string
+	// FormatoDataXLSX é o formato de exibição das células de data na planilha.
+	FormatoDataXLSX string
+}
+
+var (
+	PtBR = Localidade{Codigo: "pt-BR", SeparadorDecimal: ",", SeparadorCSV: ';', FormatoData: "02/01/2006", FormatoDataXLSX: "dd/mm/yyyy"}
+	EnUS = Localidade{Codigo: "en-US", SeparadorDecimal: ".", SeparadorCSV: ',', FormatoData: "01/02/2006", FormatoDataXLSX: "mm/dd/yyyy"}
+)
+
+// ParseLocalidade converte o parâmetro 'localidade'; vazio resulta em pt-BR.
+func ParseLocalidade(v string) (Localidade, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "pt-br", "pt_br":
+		return PtBR, nil
+	case "en-us", "en_us":
+		return EnUS, nil
+	}
+	return Localidade{}, ErrLocalidadeInvalida
+}
+
+// Numero formata um valor com duas casas e sem separador de milhar, para que planilhas e
+// ferramentas da localidade o reconheçam como número.
+func (l Localidade) Numero(v float64) string {
+	return strings.Replace(strconv.FormatFloat(v, 'f', 2, 64), ".", l.SeparadorDecimal, 1)
+}
+
+func (l Localidade) Data(t time.Time) string {
+	return t.Format(l.FormatoData)
+}
+
+type tipoCelula int
+
+const (
+	celulaTexto tipoCelula = iota
+	celulaNumero
+	celulaData
+)
+
+// Celula é um valor de uma linha de planilha; o tipo decide a formatação em cada formato.
+type Celula struct {
+	tipo   tipoCelula
+	texto  string
+	numero float64
+	data   time.Time
+}
+
+func Texto(v string) Celula   { return Celula{tipo: celulaTexto, texto: v} }
+func Numero(v float64) Celula { return Celula{tipo: celulaNumero, numero: v} }
+func Data(v time.Time) Celula { return Celula{tipo: celulaData, data: v} }
+
+// Planilha recebe as linhas de uma exportação tabular. Fechar conclui o arquivo e precisa ser
+// chamado mesmo sem nenhuma linha.
+type Planilha interface {
+	Linha(celulas ...Celula) error
+	Fechar() error
+}
+
+// NovaPlanilha cria uma planilha em CSV ou XLSX escrevendo em 'w'; 'nome' é o nome da aba no XLSX.
+func NovaPlanilha(w io.Writer, formato Formato, localidade Localidade, nome string) (Planilha, error) {
+	switch formato {
+	case FormatoCSV:
+		return novaPlanilhaCSV(w, localidade)
+	case FormatoXLSX:
+		return novaPlanilhaXLSX(w, localidade, nome)
+	case FormatoOFX:
+		return nil, ErrFormatoNaoTabular
+	}
+	return nil, fmt.Errorf("%w: %s", ErrFormatoInvalido, formato)
+}
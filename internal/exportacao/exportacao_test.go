@@ -0,0 +1,56 @@
+package exportacao
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVNeutralizaFormulas(t *testing.T) {
+	localidade, err := ParseLocalidade("pt-BR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	planilha, err := NovaPlanilha(&buf, FormatoCSV, localidade, "Teste")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := planilha.Linha(Texto("=HYPERLINK(\"http://x\")"), Texto("+1"), Texto("-2"), Texto("@SUM(A1)"), Texto("Mercado"), Numero(-10.5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := planilha.Fechar(); err != nil {
+		t.Fatal(err)
+	}
+
+	linha := strings.TrimSpace(strings.TrimPrefix(buf.String(), bomUTF8))
+	esperado := `"'=HYPERLINK(""http://x"")";'+1;'-2;'@SUM(A1);Mercado;-10,50`
+	if linha != esperado {
+		t.Errorf("linha = %s\nesperado = %s", linha, esperado)
+	}
+}
+
+func TestOFXCabecalhoEWindows1252(t *testing.T) {
+	var buf bytes.Buffer
+	e := NovoEscritorOFX(&buf)
+	dia := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	if err := e.Conta(ContaOFX{ID: "conta", Tipo: ContaCorrenteOFX, Moeda: "BRL", Inicio: dia, Fim: dia}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Lancamento(LancamentoOFX{ID: "t1", Data: dia, Valor: -12.3, Descricao: "Pão de açúcar ☕"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Fechar(); err != nil {
+		t.Fatal(err)
+	}
+
+	saida := buf.Bytes()
+	if !bytes.Contains(saida, []byte("ENCODING:USASCII\r\nCHARSET:1252\r\n")) {
+		t.Errorf("cabeçalho sem ENCODING:USASCII/CHARSET:1252:\n%s", saida[:200])
+	}
+	// "ã" e "ç" em Windows-1252 são 0xE3 e 0xE7; o emoji não existe na página de código.
+	if !bytes.Contains(saida, []byte("<NAME>P\xe3o de a\xe7\xfacar ?\r\n")) {
+		t.Errorf("NAME não convertido para Windows-1252:\n%q", saida)
+	}
+}
@@ -0,0 +1,133 @@
+package exportacao
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// TipoContaOFX é o ACCTTYPE do extrato.
+type TipoContaOFX string
+
+const (
+	ContaCorrenteOFX TipoContaOFX = "CHECKING"
+	PoupancaOFX      TipoContaOFX = "SAVINGS"
+	CreditoOFX       TipoContaOFX = "CREDITLINE"
+)
+
+// ContaOFX identifica o extrato de um ativo. 'Saldo' é o saldo atual, informado em LEDGERBAL
+// com a data de geração do arquivo.
+type ContaOFX struct {
+	ID     string
+	Tipo   TipoContaOFX
+	Moeda  string
+	Saldo  float64
+	Inicio time.Time
+	Fim    time.Time
+}
+
+// LancamentoOFX é uma transação do extrato; 'Valor' é negativo nas saídas.
+type LancamentoOFX struct {
+	ID        string
+	Data      time.Time
+	Valor     float64
+	Descricao string
+	Memo      string
+}
+
+// EscritorOFX escreve um arquivo OFX 1.0.2 com um extrato por conta. Os lançamentos devem chegar
+// agrupados por conta: cada chamada a Conta encerra o extrato anterior. O SGML do OFX 1.0.2 não
+// aceita UTF-8, então o texto é gravado em Windows-1252 (ENCODING:USASCII, CHARSET:1252), a
+// codificação que os programas de finanças esperam dos bancos brasileiros.
+type EscritorOFX struct {
+	w          *bufio.Writer
+	agora      time.Time
+	contaAtual *ContaOFX
+}
+
+func NovoEscritorOFX(w io.Writer) *EscritorOFX {
+	return &EscritorOFX{w: bufio.NewWriter(w)}
+}
+
+func (e *EscritorOFX) iniciar() {
+	if !e.agora.IsZero() {
+		return
+	}
+	e.agora = time.Now()
+	e.w.WriteString("OFXHEADER:100\r\nDATA:OFXSGML\r\nVERSION:102\r\nSECURITY:NONE\r\nENCODING:USASCII\r\nCHARSET:1252\r\nCOMPRESSION:NONE\r\nOLDFILEUID:NONE\r\nNEWFILEUID:NONE\r\n\r\n")
+	fmt.Fprintf(e.w, "<OFX>\r\n<SIGNONMSGSRSV1>\r\n<SONRS>\r\n<STATUS>\r\n<CODE>0\r\n<SEVERITY>INFO\r\n</STATUS>\r\n<DTSERVER>%s\r\n<LANGUAGE>POR\r\n</SONRS>\r\n</SIGNONMSGSRSV1>\r\n<BANKMSGSRSV1>\r\n", dataHoraOFX(e.agora))
+}
+
+// Conta abre o extrato de um novo ativo, encerrando o anterior.
+func (e *EscritorOFX) Conta(conta ContaOFX) error {
+	e.iniciar()
+	e.encerrarConta()
+	e.contaAtual = &conta
+	fmt.Fprintf(e.w, "<STMTTRNRS>\r\n<TRNUID>%s\r\n<STATUS>\r\n<CODE>0\r\n<SEVERITY>INFO\r\n</STATUS>\r\n<STMTRS>\r\n<CURDEF>%s\r\n", textoOFX(conta.ID, 36), conta.Moeda)
+	fmt.Fprintf(e.w, "<BANKACCTFROM>\r\n<BANKID>0000\r\n<ACCTID>%s\r\n<ACCTTYPE>%s\r\n</BANKACCTFROM>\r\n", textoOFX(conta.ID, 22), conta.Tipo)
+	_, err := fmt.Fprintf(e.w, "<BANKTRANLIST>\r\n<DTSTART>%s\r\n<DTEND>%s\r\n", dataOFX(conta.Inicio), dataOFX(conta.Fim))
+	return err
+}
+
+func (e *EscritorOFX) Lancamento(l LancamentoOFX) error {
+	tipo := "CREDIT"
+	if l.Valor < 0 {
+		tipo = "DEBIT"
+	}
+	fmt.Fprintf(e.w, "<STMTTRN>\r\n<TRNTYPE>%s\r\n<DTPOSTED>%s\r\n<TRNAMT>%s\r\n<FITID>%s\r\n<NAME>%s\r\n",
+		tipo, dataOFX(l.Data), strconv.FormatFloat(l.Valor, 'f', 2, 64), textoOFX(l.ID, 255), textoOFX(l.Descricao, 32))
+	if l.Memo != "" {
+		fmt.Fprintf(e.w, "<MEMO>%s\r\n", textoOFX(l.Memo, 255))
+	}
+	_, err := e.w.WriteString("</STMTTRN>\r\n")
+	return err
+}
+
+func (e *EscritorOFX) encerrarConta() {
+	if e.contaAtual == nil {
+		return
+	}
+	fmt.Fprintf(e.w, "</BANKTRANLIST>\r\n<LEDGERBAL>\r\n<BALAMT>%s\r\n<DTASOF>%s\r\n</LEDGERBAL>\r\n</STMTRS>\r\n</STMTTRNRS>\r\n",
+		strconv.FormatFloat(e.contaAtual.Saldo, 'f', 2, 64), dataHoraOFX(e.agora))
+	e.contaAtual = nil
+}
+
+// Fechar encerra o último extrato e o arquivo.
+func (e *EscritorOFX) Fechar() error {
+	e.iniciar()
+	e.encerrarConta()
+	e.w.WriteString("</BANKMSGSRSV1>\r\n</OFX>\r\n")
+	return e.w.Flush()
+}
+
+func dataOFX(t time.Time) string {
+	return t.Format("20060102")
+}
+
+func dataHoraOFX(t time.Time) string {
+	return t.Format("20060102150405")
+}
+
+// textoOFX escapa os caracteres reservados do SGML, remove quebras de linha, corta no tamanho
+// máximo do campo e converte para Windows-1252; caracteres sem equivalente viram '?'.
+func textoOFX(v string, limite int) string {
+	v = strings.Join(strings.Fields(v), " ")
+	if r := []rune(v); len(r) > limite {
+		v = string(r[:limite])
+	}
+	v = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(v)
+	var b strings.Builder
+	for _, r := range v {
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			c = '?'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
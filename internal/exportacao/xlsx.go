@@ -0,0 +1,139 @@
+package exportacao
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// O XLSX é um zip de partes XML. As partes fixas são gravadas na criação e a planilha é escrita
+// por último, linha a linha, com textos em linha (inlineStr) para dispensar a tabela de textos
+// compartilhados, que exigiria conhecer todos os valores antes de escrever.
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+	// Estilos: 0 padrão, 1 número com duas casas (o Excel usa os separadores da máquina) e 2 data
+	// no formato da localidade.
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="%s"/></numFmts><fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs><cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles></styleSheet>`
+	xlsxInicioPlanilha = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxFimPlanilha = `</sheetData></worksheet>`
+)
+
+// epocaXLSX é o dia zero das datas seriais do Excel (com o ajuste do falso 29/02/1900).
+var epocaXLSX = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type planilhaXLSX struct {
+	zip   *zip.Writer
+	w     *bufio.Writer
+	linha int
+}
+
+func novaPlanilhaXLSX(w io.Writer, localidade Localidade, nome string) (*planilhaXLSX, error) {
+	z := zip.NewWriter(w)
+	partes := []struct{ nome, conteudo string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escaparXML(nomeAbaXLSX(nome)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", fmt.Sprintf(xlsxStyles, localidade.FormatoDataXLSX)},
+	}
+	for _, p := range partes {
+		parte, err := z.Create(p.nome)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(parte, p.conteudo); err != nil {
+			return nil, err
+		}
+	}
+
+	planilha, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(planilha)
+	if _, err := buf.WriteString(xlsxInicioPlanilha); err != nil {
+		return nil, err
+	}
+	return &planilhaXLSX{zip: z, w: buf}, nil
+}
+
+func (p *planilhaXLSX) Linha(celulas ...Celula) error {
+	p.linha++
+	fmt.Fprintf(p.w, `<row r="%d">`, p.linha)
+	for i, c := range celulas {
+		ref := colunaXLSX(i) + strconv.Itoa(p.linha)
+		switch c.tipo {
+		case celulaNumero:
+			fmt.Fprintf(p.w, `<c r="%s" s="1"><v>%s</v></c>`, ref, strconv.FormatFloat(c.numero, 'f', -1, 64))
+		case celulaData:
+			fmt.Fprintf(p.w, `<c r="%s" s="2"><v>%d</v></c>`, ref, serialXLSX(c.data))
+		default:
+			fmt.Fprintf(p.w, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escaparXML(c.texto))
+		}
+	}
+	_, err := p.w.WriteString(`</row>`)
+	return err
+}
+
+func (p *planilhaXLSX) Fechar() error {
+	if _, err := p.w.WriteString(xlsxFimPlanilha); err != nil {
+		return err
+	}
+	if err := p.w.Flush(); err != nil {
+		return err
+	}
+	return p.zip.Close()
+}
+
+// colunaXLSX converte o índice da coluna (a partir de 0) na letra usada nas referências: A, B, ..., Z, AA.
+func colunaXLSX(i int) string {
+	coluna := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		coluna = string(rune('A'+(i-1)%26)) + coluna
+	}
+	return coluna
+}
+
+// serialXLSX converte a data no número de dias usado pelo Excel, desprezando o horário.
+func serialXLSX(t time.Time) int {
+	ano, mes, dia := t.Date()
+	return int(time.Date(ano, mes, dia, 0, 0, 0, 0, time.UTC).Sub(epocaXLSX).Hours() / 24)
+}
+
+// nomeAbaXLSX remove os caracteres proibidos em nomes de aba e respeita o limite de 31 caracteres.
+func nomeAbaXLSX(nome string) string {
+	nome = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, nome)
+	if r := []rune(nome); len(r) > 31 {
+		nome = string(r[:31])
+	}
+	if nome == "" {
+		return "Planilha1"
+	}
+	return nome
+}
+
+func escaparXML(v string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(v))
+	return b.String()
+}
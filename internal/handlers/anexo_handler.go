@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type AnexoHandler struct {
+	uploadService   *services.UploadAnexoService
+	listService     *services.ListAnexosService
+	downloadService *services.DownloadAnexoService
+	deleteService   *services.DeleteAnexoService
+}
+
+func NewAnexoHandler(uploadSvc *services.UploadAnexoService, listSvc *services.ListAnexosService, downloadSvc *services.DownloadAnexoService, deleteSvc *services.DeleteAnexoService) *AnexoHandler {
+	return &AnexoHandler{
+		uploadService:   uploadSvc,
+		listService:     listSvc,
+		downloadService: downloadSvc,
+		deleteService:   deleteSvc,
+	}
+}
+
+// UploadAnexo recebe o arquivo no campo 'arquivo' de um formulário multipart.
+func (h *AnexoHandler) UploadAnexo(c *gin.Context) {
+	arquivo, err := c.FormFile("arquivo")
+	if err != nil {
+		c.Error(errArquivoAusente)
+		return
+	}
+	conteudo, err := arquivo.Open()
+	if err != nil {
+		c.Error(errArquivoIlegivel)
+		return
+	}
+	defer conteudo.Close()
+
+	anexo, err := h.uploadService.Execute(c.Request.Context(), c.Param("id"), arquivo.Filename, conteudo)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, anexo)
+}
+
+func (h *AnexoHandler) ListAnexos(c *gin.Context) {
+	anexos, err := h.listService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, anexos)
+}
+
+func (h *AnexoHandler) DownloadAnexo(c *gin.Context) {
+	h.enviarAnexo(c, false)
+}
+
+func (h *AnexoHandler) DownloadMiniatura(c *gin.Context) {
+	h.enviarAnexo(c, true)
+}
+
+func (h *AnexoHandler) enviarAnexo(c *gin.Context, miniatura bool) {
+	anexo, conteudo, err := h.downloadService.Execute(c.Request.Context(), c.Param("id"), miniatura)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conteudo.Close()
+
+	if miniatura {
+		c.DataFromReader(http.StatusOK, -1, "image/jpeg", conteudo, nil)
+		return
+	}
+	c.DataFromReader(http.StatusOK, anexo.Tamanho, anexo.ContentType, conteudo, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", anexo.NomeArquivo),
+	})
+}
+
+func (h *AnexoHandler) DeleteAnexo(c *gin.Context) {
+	if err := h.deleteService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
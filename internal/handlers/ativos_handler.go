@@ -8,58 +8,90 @@ import (
 
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/services"
-
 )
 
 type AtivoHandler struct {
-	createService     *services.CreateAtivoService
-	listService       *services.ListAtivosService
-	deactivateService *services.DeactivateAtivoService
+	createService         *services.CreateAtivoService
+	listService           *services.ListAtivosService
+	getService            *services.GetAtivoService
+	updateService         *services.UpdateAtivoService
+	deactivateService     *services.DeactivateAtivoService
+	reactivateService     *services.ReactivateAtivoService
+	saldoProjetadoService *services.SaldoProjetadoService
+	jurosService          *services.ProcessarJurosChequeEspecialService
+	recalcularService     *services.RecalcularSaldoService
 }
 
 // CORREÇÃO: Adicionado o deactivateSvc como parâmetro no construtor original.
-func NewAtivoHandler(createSvc *services.CreateAtivoService, listSvc *services.ListAtivosService, deactivateSvc *services.DeactivateAtivoService) *AtivoHandler {
+func NewAtivoHandler(createSvc *services.CreateAtivoService, listSvc *services.ListAtivosService, getSvc *services.GetAtivoService, updateSvc *services.UpdateAtivoService, deactivateSvc *services.DeactivateAtivoService, reactivateSvc *services.ReactivateAtivoService, saldoProjetadoSvc *services.SaldoProjetadoService, jurosSvc *services.ProcessarJurosChequeEspecialService, recalcularSvc *services.RecalcularSaldoService) *AtivoHandler {
 	return &AtivoHandler{
-		createService:     createSvc,
-		listService:       listSvc,
-		deactivateService: deactivateSvc,
+		createService:         createSvc,
+		listService:           listSvc,
+		getService:            getSvc,
+		updateService:         updateSvc,
+		deactivateService:     deactivateSvc,
+		reactivateService:     reactivateSvc,
+		saldoProjetadoService: saldoProjetadoSvc,
+		jurosService:          jurosSvc,
+		recalcularService:     recalcularSvc,
 	}
 }
 
 // ALTERAÇÃO: Adicionado o método que faltava.
+// As recorrências pausadas junto com o ativo aparecem em GET /ativos/:id/recorrencias.
 func (h *AtivoHandler) DeactivateAtivoFinanceiro(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do ativo é obrigatório"})
+	if _, err := h.deactivateService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
 		return
 	}
 
-	err := h.deactivateService.Execute(c.Request.Context(), id)
+	c.Status(http.StatusNoContent)
+}
+
+// ReactivateAtivoFinanceiro desfaz a desativação do ativo e retoma as recorrências pausadas por ela.
+func (h *AtivoHandler) ReactivateAtivoFinanceiro(c *gin.Context) {
+	resultado, err := h.reactivateService.Execute(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		// No serviço 'deactivate_ativo_service', o erro retornado quando não encontra é ErrAtivoNaoEncontrado.
-		// Precisamos verificar se ele foi definido e importado para usar 'errors.Is'.
-		// Por simplicidade, vamos tratar os erros de forma mais genérica por enquanto.
-		// Em uma versão futura, podemos refatorar para usar 'errors.Is(err, services.ErrAtivoNaoEncontrado)'.
-		log.Error().Err(err).Msg("Erro ao desativar ativo")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao desativar ativo"})
+		c.Error(err)
 		return
 	}
-	
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, resultado)
+}
+
+func (h *AtivoHandler) GetAtivoFinanceiro(c *gin.Context) {
+	ativo, err := h.getService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, ativo)
+}
+
+func (h *AtivoHandler) UpdateAtivoFinanceiro(c *gin.Context) {
+	var input services.UpdateAtivoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	ativo, err := h.updateService.Execute(c.Request.Context(), c.Param("id"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, ativo)
 }
 
 func (h *AtivoHandler) CreateAtivoFinanceiro(c *gin.Context) {
 	var input models.AtivoFinanceiro
 	if err := c.ShouldBindJSON(&input); err != nil {
-		log.Error().Err(err).Msg("Erro ao fazer bind do JSON para criar ativo")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(dadosInvalidos(err))
 		return
 	}
 
 	novoAtivo, err := h.createService.Execute(c.Request.Context(), input)
 	if err != nil {
-		log.Error().Err(err).Msg("Erro ao criar ativo financeiro")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao salvar o ativo financeiro"})
+		c.Error(err)
 		return
 	}
 
@@ -69,10 +101,46 @@ func (h *AtivoHandler) CreateAtivoFinanceiro(c *gin.Context) {
 func (h *AtivoHandler) GetAtivosFinanceiros(c *gin.Context) {
 	ativos, err := h.listService.Execute(c.Request.Context())
 	if err != nil {
-		log.Error().Err(err).Msg("Erro ao buscar ativos financeiros")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar ativos financeiros"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, ativos)
-}
\ No newline at end of file
+}
+
+// GetSaldoProjetado mostra o saldo atual do ativo e o saldo após as transações agendadas e pendentes.
+// Aceita '?ate=AAAA-MM-DD' para considerar apenas as transações até essa data.
+func (h *AtivoHandler) GetSaldoProjetado(c *gin.Context) {
+	ate, err := parseData(c.Query("ate"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	saldo, err := h.saldoProjetadoService.Execute(c.Request.Context(), c.Param("id"), ate)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, saldo)
+}
+
+func (h *AtivoHandler) ProcessarJurosChequeEspecial(c *gin.Context) {
+	log.Info().Msg("Requisição para acionar o worker de juros de cheque especial recebida.")
+	relatorio, err := h.jurosService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
+
+// RecalcularSaldo refaz o saldo e o limite disponível do ativo a partir das suas transações.
+func (h *AtivoHandler) RecalcularSaldo(c *gin.Context) {
+	recalculo, err := h.recalcularService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, recalculo)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/services"
+)
+
+type BackupHandler struct {
+	gerarService     *services.GerarBackupService
+	restaurarService *services.RestaurarBackupService
+}
+
+func NewBackupHandler(gerarSvc *services.GerarBackupService, restaurarSvc *services.RestaurarBackupService) *BackupHandler {
+	return &BackupHandler{
+		gerarService:     gerarSvc,
+		restaurarService: restaurarSvc,
+	}
+}
+
+// GetBackup baixa o backup completo dos dados em JSON.
+func (h *BackupHandler) GetBackup(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="controlador-backup-%s.json"`, time.Now().Format("2006-01-02")))
+	c.Status(http.StatusOK)
+
+	if err := h.gerarService.Execute(c.Request.Context(), c.Writer); err != nil {
+		if c.Writer.Written() {
+			log.Error().Err(err).Msg("Erro ao gerar backup (resposta interrompida)")
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Disposition")
+		c.Error(err)
+	}
+}
+
+// RestaurarBackup recebe o arquivo no campo 'arquivo' de um formulário multipart. '?modo=substituir'
+// (padrão) apaga os dados atuais antes da carga; '?modo=mesclar' soma o backup aos dados atuais.
+func (h *BackupHandler) RestaurarBackup(c *gin.Context) {
+	arquivo, err := c.FormFile("arquivo")
+	if err != nil {
+		c.Error(errArquivoAusente)
+		return
+	}
+	conteudo, err := arquivo.Open()
+	if err != nil {
+		c.Error(errArquivoIlegivel)
+		return
+	}
+	defer conteudo.Close()
+
+	resumo, err := h.restaurarService.Execute(c.Request.Context(), conteudo, models.ModoRestauracao(c.Query("modo")))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resumo)
+}
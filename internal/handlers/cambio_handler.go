@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/services"
+)
+
+type CambioHandler struct {
+	createService *services.CreateTaxaCambioService
+	listService   *services.ListTaxasCambioService
+}
+
+func NewCambioHandler(createSvc *services.CreateTaxaCambioService, listSvc *services.ListTaxasCambioService) *CambioHandler {
+	return &CambioHandler{
+		createService: createSvc,
+		listService:   listSvc,
+	}
+}
+
+// createTaxaCambioRequest é o corpo de POST /taxas-cambio; a data usa o formato AAAA-MM-DD e,
+// ausente, vale o dia de hoje.
+type createTaxaCambioRequest struct {
+	MoedaOrigem  string  `json:"moeda_origem" binding:"required"`
+	MoedaDestino string  `json:"moeda_destino" binding:"required"`
+	Data         string  `json:"data"`
+	Taxa         float64 `json:"taxa" binding:"required"`
+}
+
+func (h *CambioHandler) CreateTaxaCambio(c *gin.Context) {
+	var req createTaxaCambioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	data, err := parseData(req.Data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := models.TaxaCambio{MoedaOrigem: req.MoedaOrigem, MoedaDestino: req.MoedaDestino, Taxa: req.Taxa}
+	if data != nil {
+		input.Data = *data
+	}
+	taxa, err := h.createService.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, taxa)
+}
+
+// GetTaxasCambio aceita '?moeda_origem=' e '?moeda_destino=' para filtrar o par.
+func (h *CambioHandler) GetTaxasCambio(c *gin.Context) {
+	taxas, err := h.listService.Execute(c.Request.Context(), c.Query("moeda_origem"), c.Query("moeda_destino"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, taxas)
+}
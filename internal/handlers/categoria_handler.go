@@ -1,15 +1,12 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/rs/zerolog/log"
 
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/services"
-
 )
 
 type CategoriaHandler struct {
@@ -28,19 +25,13 @@ func NewCategoriaHandler(createSvc *services.CreateCategoriaService, listSvc *se
 func (h *CategoriaHandler) CreateCategoria(c *gin.Context) {
 	var input models.Categoria
 	if err := c.ShouldBindJSON(&input); err != nil {
-		log.Error().Err(err).Msg("Erro no bind do JSON para criar categoria")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "corpo da requisição inválido"})
+		c.Error(dadosInvalidos(err))
 		return
 	}
 
 	novaCategoria, err := h.createService.Execute(c.Request.Context(), input)
 	if err != nil {
-		log.Error().Err(err).Msg("Erro no serviço de criação de categoria")
-		if errors.Is(err, services.ErrCategoriaJaExiste) {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao criar categoria"})
+		c.Error(err)
 		return
 	}
 
@@ -50,8 +41,7 @@ func (h *CategoriaHandler) CreateCategoria(c *gin.Context) {
 func (h *CategoriaHandler) GetCategorias(c *gin.Context) {
 	categorias, err := h.listService.Execute(c.Request.Context())
 	if err != nil {
-		log.Error().Err(err).Msg("Erro ao buscar categorias")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao buscar categorias"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, categorias)
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type ConciliacaoHandler struct {
+	createService      *services.CreateConciliacaoService
+	listService        *services.ListConciliacoesService
+	resumoService      *services.ResumoConciliacaoService
+	marcarService      *services.MarcarConciliadasService
+	concluirService    *services.ConcluirConciliacaoService
+	deleteService      *services.DeleteConciliacaoService
+	desbloquearService *services.DesbloquearTransacaoService
+}
+
+func NewConciliacaoHandler(createSvc *services.CreateConciliacaoService, listSvc *services.ListConciliacoesService, resumoSvc *services.ResumoConciliacaoService, marcarSvc *services.MarcarConciliadasService, concluirSvc *services.ConcluirConciliacaoService, deleteSvc *services.DeleteConciliacaoService, desbloquearSvc *services.DesbloquearTransacaoService) *ConciliacaoHandler {
+	return &ConciliacaoHandler{
+		createService:      createSvc,
+		listService:        listSvc,
+		resumoService:      resumoSvc,
+		marcarService:      marcarSvc,
+		concluirService:    concluirSvc,
+		deleteService:      deleteSvc,
+		desbloquearService: desbloquearSvc,
+	}
+}
+
+// createConciliacaoRequest é o corpo de POST /ativos/:id/conciliacoes; a data usa o formato AAAA-MM-DD.
+type createConciliacaoRequest struct {
+	DataExtrato  string   `json:"data_extrato" binding:"required"`
+	SaldoExtrato *float64 `json:"saldo_extrato" binding:"required"`
+}
+
+func (h *ConciliacaoHandler) CreateConciliacao(c *gin.Context) {
+	var req createConciliacaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	data, err := parseData(req.DataExtrato)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	conciliacao, err := h.createService.Execute(c.Request.Context(), c.Param("id"), services.CreateConciliacaoInput{
+		DataExtrato:  *data,
+		SaldoExtrato: *req.SaldoExtrato,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, conciliacao)
+}
+
+func (h *ConciliacaoHandler) ListConciliacoes(c *gin.Context) {
+	conciliacoes, err := h.listService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, conciliacoes)
+}
+
+// GetConciliacao mostra o saldo conciliado, a diferença para o extrato e as transações conferíveis.
+func (h *ConciliacaoHandler) GetConciliacao(c *gin.Context) {
+	resumo, err := h.resumoService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resumo)
+}
+
+func (h *ConciliacaoHandler) MarcarTransacoes(c *gin.Context) {
+	var input services.MarcarConciliadasInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	resumo, err := h.marcarService.Execute(c.Request.Context(), c.Param("id"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resumo)
+}
+
+func (h *ConciliacaoHandler) ConcluirConciliacao(c *gin.Context) {
+	resumo, err := h.concluirService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resumo)
+}
+
+func (h *ConciliacaoHandler) DeleteConciliacao(c *gin.Context) {
+	if err := h.deleteService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DesbloquearTransacao libera explicitamente uma transação conciliada para alteração.
+func (h *ConciliacaoHandler) DesbloquearTransacao(c *gin.Context) {
+	transacao, err := h.desbloquearService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, transacao)
+}
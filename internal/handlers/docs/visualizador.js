@@ -0,0 +1,251 @@
+// Visualizador da especificação OpenAPI servida em /api/docs/openapi.json. Não depende de
+// nenhum recurso externo, para que a documentação funcione sem acesso à internet.
+(function () {
+  "use strict";
+
+  var METODOS = ["get", "post", "put", "patch", "delete"];
+  var spec;
+
+  function el(tag, atributos, filhos) {
+    var e = document.createElement(tag);
+    Object.keys(atributos || {}).forEach(function (k) {
+      if (k === "texto") e.textContent = atributos[k];
+      else e.setAttribute(k, atributos[k]);
+    });
+    (filhos || []).forEach(function (f) {
+      if (f) e.appendChild(typeof f === "string" ? document.createTextNode(f) : f);
+    });
+    return e;
+  }
+
+  function resolver(esquema) {
+    var visitados = 0;
+    while (esquema && esquema.$ref && visitados++ < 20) {
+      esquema = spec.components.schemas[esquema.$ref.replace("#/components/schemas/", "")];
+    }
+    return esquema || {};
+  }
+
+  // exemplo monta um valor que segue o esquema, usado como ponto de partida dos corpos.
+  function exemplo(esquema, requisicao, profundidade) {
+    esquema = resolver(esquema);
+    if (profundidade > 6) return null;
+    if (esquema.allOf) {
+      var junto = {};
+      esquema.allOf.forEach(function (parte) {
+        var valor = exemplo(parte, requisicao, profundidade);
+        if (valor && typeof valor === "object" && !Array.isArray(valor)) Object.assign(junto, valor);
+      });
+      return junto;
+    }
+    if (esquema.anyOf) {
+      var alternativa = esquema.anyOf.filter(function (a) { return a.type !== "null"; })[0];
+      return alternativa ? exemplo(alternativa, requisicao, profundidade) : null;
+    }
+    if (esquema.enum) return esquema.enum[0];
+    var tipo = Array.isArray(esquema.type) ? esquema.type.filter(function (t) { return t !== "null"; })[0] : esquema.type;
+    switch (tipo) {
+      case "object":
+        var objeto = {};
+        Object.keys(esquema.properties || {}).sort().forEach(function (nome) {
+          var propriedade = esquema.properties[nome];
+          if (requisicao && propriedade.readOnly) return;
+          objeto[nome] = exemplo(propriedade, requisicao, profundidade + 1);
+        });
+        return objeto;
+      case "array":
+        return esquema.items ? [exemplo(esquema.items, requisicao, profundidade + 1)] : [];
+      case "integer":
+      case "number":
+        return 0;
+      case "boolean":
+        return false;
+      case "string":
+        if (esquema.format === "date-time") return new Date().toISOString();
+        if (esquema.format === "date") return new Date().toISOString().slice(0, 10);
+        return "";
+    }
+    return null;
+  }
+
+  function json(valor) {
+    return JSON.stringify(valor, null, 2);
+  }
+
+  function tabelaParametros(parametros) {
+    var linhas = parametros.map(function (p) {
+      var esquema = p.schema || {};
+      var tipo = esquema.type + (esquema.format ? " (" + esquema.format + ")" : "");
+      if (esquema.enum) tipo += ": " + esquema.enum.join(", ");
+      return el("tr", {}, [
+        el("td", {}, [el("code", { texto: p.name }), p.required ? el("span", { class: "obrigatorio", texto: " *" }) : null]),
+        el("td", { texto: p.in }),
+        el("td", { texto: tipo }),
+        el("td", { texto: p.description || "" })
+      ]);
+    });
+    return el("table", {}, [
+      el("thead", {}, [el("tr", {}, [el("th", { texto: "Nome" }), el("th", { texto: "Em" }), el("th", { texto: "Tipo" }), el("th", { texto: "Descrição" })])]),
+      el("tbody", {}, linhas)
+    ]);
+  }
+
+  function parametrosDe(op) {
+    return (op.parameters || []).map(function (p) {
+      return p.$ref ? spec.components.parameters[p.$ref.replace("#/components/parameters/", "")] : p;
+    });
+  }
+
+  function respostas(op) {
+    var linhas = Object.keys(op.responses || {}).sort().map(function (status) {
+      var resposta = op.responses[status];
+      var conteudo = Object.keys(resposta.content || {}).map(function (tipo) {
+        var esquema = resposta.content[tipo].schema;
+        var bloco = el("div", {}, [el("code", { texto: tipo })]);
+        if (/json$/.test(tipo) && esquema) bloco.appendChild(el("pre", { texto: json(exemplo(esquema, false, 0)) }));
+        return bloco;
+      });
+      return el("tr", {}, [el("td", {}, [el("code", { texto: status })]), el("td", { texto: resposta.description || "" }), el("td", {}, conteudo)]);
+    });
+    return el("table", {}, [
+      el("thead", {}, [el("tr", {}, [el("th", { texto: "Status" }), el("th", { texto: "Descrição" }), el("th", { texto: "Conteúdo" })])]),
+      el("tbody", {}, linhas)
+    ]);
+  }
+
+  // experimentar monta o formulário que envia a requisição ao próprio servidor.
+  function experimentar(metodo, caminho, op) {
+    var parametros = parametrosDe(op);
+    var campos = {};
+    var linhas = parametros.map(function (p) {
+      campos[p.in + ":" + p.name] = el("input", { placeholder: p.in });
+      return el("tr", {}, [el("td", {}, [el("code", { texto: p.name })]), el("td", {}, [campos[p.in + ":" + p.name]])]);
+    });
+
+    var corpoJSON = op.requestBody && op.requestBody.content && op.requestBody.content["application/json"];
+    var arquivo = op.requestBody && op.requestBody.content && op.requestBody.content["multipart/form-data"];
+    var textoCorpo = corpoJSON ? el("textarea", {}, [json(exemplo(corpoJSON.schema, true, 0))]) : null;
+    var campoArquivo = arquivo ? el("input", { type: "file" }) : null;
+    var botao = el("button", { type: "button", texto: "Enviar" });
+    var saida = el("div", {});
+
+    botao.addEventListener("click", function () {
+      var url = caminho;
+      var query = new URLSearchParams();
+      var cabecalhos = {};
+      parametros.forEach(function (p) {
+        var valor = campos[p.in + ":" + p.name].value;
+        if (!valor) return;
+        if (p.in === "path") url = url.replace("{" + p.name + "}", encodeURIComponent(valor));
+        else if (p.in === "query") query.append(p.name, valor);
+        else if (p.in === "header") cabecalhos[p.name] = valor;
+      });
+      if (query.toString()) url += "?" + query.toString();
+
+      var opcoes = { method: metodo.toUpperCase(), headers: cabecalhos };
+      if (textoCorpo && textoCorpo.value.trim()) {
+        cabecalhos["Content-Type"] = "application/json";
+        opcoes.body = textoCorpo.value;
+      } else if (campoArquivo && campoArquivo.files.length) {
+        var formulario = new FormData();
+        formulario.append(Object.keys(arquivo.schema.properties)[0], campoArquivo.files[0]);
+        opcoes.body = formulario;
+      }
+
+      botao.disabled = true;
+      saida.replaceChildren(el("p", { class: "aviso", texto: "Enviando…" }));
+      fetch(url, opcoes).then(function (resposta) {
+        return resposta.text().then(function (texto) {
+          try { texto = json(JSON.parse(texto)); } catch (e) { /* não é JSON */ }
+          saida.replaceChildren(
+            el("p", {}, [el("span", { class: "status", texto: resposta.status + " " + resposta.statusText }), " ", el("code", { texto: resposta.headers.get("Content-Type") || "" })]),
+            texto ? el("pre", { texto: texto }) : null
+          );
+        });
+      }).catch(function (erro) {
+        saida.replaceChildren(el("p", { class: "erro", texto: String(erro) }));
+      }).finally(function () {
+        botao.disabled = false;
+      });
+    });
+
+    return el("div", { class: "experimentar" }, [
+      el("h3", { texto: "Experimentar" }),
+      linhas.length ? el("table", {}, [el("tbody", {}, linhas)]) : null,
+      textoCorpo ? el("h3", { texto: "Corpo (application/json)" }) : null,
+      textoCorpo,
+      campoArquivo,
+      el("div", {}, [botao]),
+      saida
+    ]);
+  }
+
+  function operacao(metodo, caminho, op) {
+    var corpo = el("div", { class: "corpo-operacao" }, [
+      op.description ? el("p", { texto: op.description }) : null
+    ]);
+    var parametros = parametrosDe(op);
+    if (parametros.length) corpo.append(el("h3", { texto: "Parâmetros" }), tabelaParametros(parametros));
+    if (op.requestBody) {
+      corpo.appendChild(el("h3", { texto: "Corpo" + (op.requestBody.required ? "" : " (opcional)") }));
+      Object.keys(op.requestBody.content).forEach(function (tipo) {
+        var esquema = op.requestBody.content[tipo].schema;
+        corpo.append(el("code", { texto: tipo }), el("pre", { texto: json(exemplo(esquema, true, 0)) }));
+        var obrigatorios = [].concat(resolver(esquema).required || [], ((esquema.allOf || [])[1] || {}).required || []);
+        if (obrigatorios.length) corpo.appendChild(el("p", {}, ["Obrigatórios: ", el("code", { texto: obrigatorios.join(", ") })]));
+      });
+    }
+    corpo.append(el("h3", { texto: "Respostas" }), respostas(op), experimentar(metodo, caminho, op));
+
+    return el("details", { class: "operacao" }, [
+      el("summary", {}, [
+        el("span", { class: "metodo " + metodo, texto: metodo.toUpperCase() }),
+        el("span", { class: "caminho", texto: caminho }),
+        el("span", { class: "resumo", texto: op.summary || "" })
+      ]),
+      corpo
+    ]);
+  }
+
+  function renderizar() {
+    var info = spec.info || {};
+    document.title = info.title + " - API";
+    document.getElementById("cabecalho").replaceChildren(
+      el("h1", {}, [info.title || "API", el("span", { class: "versao", texto: info.version || "" })]),
+      el("p", { texto: info.description || "" }),
+      el("p", {}, [el("a", { href: "/api/docs/openapi.json", texto: "openapi.json" })])
+    );
+
+    var porTag = {};
+    Object.keys(spec.paths).sort().forEach(function (caminho) {
+      METODOS.forEach(function (metodo) {
+        var op = spec.paths[caminho][metodo];
+        if (!op) return;
+        var tag = (op.tags || ["Outros"])[0];
+        (porTag[tag] = porTag[tag] || []).push(operacao(metodo, caminho, op));
+      });
+    });
+
+    var tags = (spec.tags || []).map(function (t) { return t.name; });
+    Object.keys(porTag).forEach(function (t) { if (tags.indexOf(t) < 0) tags.push(t); });
+    var descricoes = {};
+    (spec.tags || []).forEach(function (t) { descricoes[t.name] = t.description; });
+
+    document.getElementById("operacoes").replaceChildren.apply(
+      document.getElementById("operacoes"),
+      tags.filter(function (t) { return porTag[t]; }).map(function (t) {
+        return el("section", { class: "tag" }, [el("h2", { texto: t }), descricoes[t] ? el("p", { texto: descricoes[t] }) : null].concat(porTag[t]));
+      })
+    );
+  }
+
+  fetch("/api/docs/openapi.json").then(function (resposta) {
+    if (!resposta.ok) throw new Error("HTTP " + resposta.status);
+    return resposta.json();
+  }).then(function (dados) {
+    spec = dados;
+    renderizar();
+  }).catch(function (erro) {
+    document.getElementById("operacoes").replaceChildren(el("p", { class: "erro", texto: "Não foi possível carregar a especificação: " + erro.message }));
+  });
+})();
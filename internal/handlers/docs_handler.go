@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"embed"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/openapi"
+)
+
+// arquivosDocs é a página que apresenta a especificação, embutida no binário para que
+// /api/docs funcione sem acesso à internet.
+//
+//go:embed docs
+var arquivosDocs embed.FS
+
+var errArquivoDocsNaoEncontrado = erros.NaoEncontrado("arquivo_nao_encontrado", "arquivo da documentação não encontrado")
+
+type DocsHandler struct {
+	doc           *openapi.Documento
+	especificacao []byte
+	validacao     *openapi.Especificacao
+	arquivos      fs.FS
+}
+
+// NewDocsHandler gera a especificação OpenAPI uma única vez.
+func NewDocsHandler() (*DocsHandler, error) {
+	doc := documentacao()
+	especificacao, err := doc.JSON()
+	if err != nil {
+		return nil, err
+	}
+	validacao, err := openapi.Ler(especificacao)
+	if err != nil {
+		return nil, err
+	}
+	arquivos, err := fs.Sub(arquivosDocs, "docs")
+	if err != nil {
+		return nil, err
+	}
+	return &DocsHandler{doc: doc, especificacao: especificacao, validacao: validacao, arquivos: arquivos}, nil
+}
+
+// Verificar confere que a documentação descreve exatamente as rotas registradas. É chamado pelos
+// testes do roteador, para que uma rota sem descrição seja barrada antes de chegar ao servidor.
+func (h *DocsHandler) Verificar(rotas gin.RoutesInfo) error {
+	return h.doc.Verificar(rotas)
+}
+
+// Especificacao devolve a especificação publicada, com que as requisições são validadas.
+func (h *DocsHandler) Especificacao() *openapi.Especificacao {
+	return h.validacao
+}
+
+func (h *DocsHandler) GetEspecificacao(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.especificacao)
+}
+
+func (h *DocsHandler) GetPagina(c *gin.Context) {
+	h.enviarArquivo(c, "index.html")
+}
+
+// GetArquivo entrega os arquivos estáticos usados pela página da documentação.
+func (h *DocsHandler) GetArquivo(c *gin.Context) {
+	h.enviarArquivo(c, c.Param("arquivo"))
+}
+
+func (h *DocsHandler) enviarArquivo(c *gin.Context, nome string) {
+	conteudo, err := fs.ReadFile(h.arquivos, nome)
+	if err != nil {
+		c.Error(errArquivoDocsNaoEncontrado)
+		return
+	}
+	c.Data(http.StatusOK, mime.TypeByExtension(path.Ext(nome)), conteudo)
+}
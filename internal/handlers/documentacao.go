@@ -0,0 +1,418 @@
+package handlers
+
+import (
+	"net/http"
+
+	"controlador/backend/internal/middleware"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/openapi"
+	"controlador/backend/internal/services"
+)
+
+// Conjuntos de status de erro compartilhados por rotas que chamam os mesmos serviços.
+var (
+	errosEdicaoTransacao = []int{http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}
+	errosConciliacao     = errosEdicaoTransacao
+	errosFinanciamento   = errosEdicaoTransacao
+	errosProgressoMeta   = []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}
+	errosRelatorio       = []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}
+	errosListagem        = []int{http.StatusInternalServerError}
+	errosBusca           = []int{http.StatusNotFound, http.StatusInternalServerError}
+)
+
+// Parâmetros de query compartilhados por várias rotas.
+var (
+	parametrosPeriodo = []openapi.Parametro{
+		{Nome: "inicio", Em: "query", Formato: "date", Descricao: "Primeiro dia do período (AAAA-MM-DD), inclusivo."},
+		{Nome: "fim", Em: "query", Formato: "date", Descricao: "Último dia do período (AAAA-MM-DD), inclusivo."},
+	}
+	parametroMoedaRelatorio = openapi.Parametro{Nome: "moeda", Em: "query", Descricao: "Moeda ISO 4217 em que os valores são somados; padrão é a moeda base."}
+	parametroMesesRitmo     = openapi.Parametro{Nome: "meses", Em: "query", Tipo: "integer", Descricao: "Janela, em meses, usada para medir o ritmo de aportes (padrão 3)."}
+	parametrosExportacao    = []openapi.Parametro{
+		{Nome: "formato", Em: "query", Enum: []string{"csv", "ofx", "xlsx"}, Descricao: "Formato do arquivo; padrão csv. Relatórios não aceitam ofx."},
+		{Nome: "localidade", Em: "query", Enum: []string{"pt-BR", "en-US"}, Descricao: "Define separador decimal, formato de datas e separador do CSV; padrão pt-BR."},
+	}
+	parametrosFiltroTransacoes = append([]openapi.Parametro{
+		{Nome: "ativo_id", Em: "query"},
+		{Nome: "categoria_id", Em: "query"},
+		{Nome: "tag", Em: "query"},
+		{Nome: "status", Em: "query", Descricao: "Um ou mais status, separados por vírgula."},
+	}, parametrosPeriodo...)
+	tiposExportacao         = []string{"text/csv", "application/x-ofx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}
+	tiposRelatorioExportado = []string{"text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}
+)
+
+func concatenar(listas ...[]openapi.Parametro) []openapi.Parametro {
+	var todos []openapi.Parametro
+	for _, lista := range listas {
+		todos = append(todos, lista...)
+	}
+	return todos
+}
+
+// documentacao descreve todas as rotas de router.SetupRouter. Os esquemas vêm dos tipos aqui
+// informados; a lista de operações é conferida com as rotas registradas pelos testes do roteador.
+func documentacao() *openapi.Documento {
+	doc := openapi.Novo("Controlador", "1.0.0", "API do Controlador de finanças pessoais. Erros são respondidos como application/problem+json (RFC 7807), com o código estável do erro no campo 'code'.")
+	doc.RespostaErro(middleware.Problema{}, middleware.TipoConteudoProblema)
+
+	openapi.Valores(doc, models.AtivoContaCorrente, models.AtivoCartaoCredito, models.AtivoPoupanca, models.AtivoDinheiro, models.AtivoInvestimento, models.AtivoValeRefeicao, models.AtivoEmprestimo)
+	openapi.Valores(doc, models.TransacaoRecebimento, models.TransacaoDebito, models.TransacaoCredito, models.TransacaoEstorno, models.TransacaoSaldoInicial)
+	openapi.Valores(doc, models.OrigemInvestimento, models.OrigemFinanciamento, models.OrigemTransferencia)
+	openapi.Valores(doc, models.StatusAgendada, models.StatusPendente, models.StatusEfetivada, models.StatusCancelada)
+	openapi.Valores(doc, models.CondicaoDescricao, models.CondicaoAtivo, models.CondicaoValor, models.CondicaoTipo)
+	openapi.Valores(doc, models.OperadorContem, models.OperadorRegex, models.OperadorIgual, models.OperadorEntre)
+	openapi.Valores(doc, models.AcaoDefinirCategoria, models.AcaoAdicionarTag, models.AcaoRenomearDescricao)
+	openapi.Valores(doc, models.ConciliacaoAberta, models.ConciliacaoConcluida)
+	openapi.Valores(doc, models.ClasseAcao, models.ClasseFII, models.ClasseETF, models.ClasseTesouroDireto, models.ClasseRendaFixa, models.ClasseFundo, models.ClasseOutro)
+	openapi.Valores(doc, models.OperacaoCompra, models.OperacaoVenda, models.OperacaoDividendo, models.OperacaoJCP)
+	openapi.Valores(doc, models.SistemaSAC, models.SistemaPrice)
+	openapi.Valores(doc, models.FinanciamentoAtivo, models.FinanciamentoQuitado)
+	openapi.Valores(doc, models.ParcelaPendente, models.ParcelaPaga)
+	openapi.Valores(doc, models.ReduzirPrazo, models.ReduzirParcela)
+	openapi.Valores(doc, models.EventosNotificacao...)
+	openapi.Valores(doc, models.CanalInApp, models.CanalEmail, models.CanalWebhook)
+	openapi.Valores(doc, append(models.EventosWebhook, models.WebhookTodosEventos)...)
+	openapi.Valores(doc, models.EntregaPendente, models.EntregaEntregue, models.EntregaFalhou)
+	openapi.Valores(doc, models.RestauracaoSubstituir, models.RestauracaoMesclar)
+	openapi.Valores(doc, models.EventosStream...)
+	openapi.Valores(doc, models.HistoricoAtualizacao, models.HistoricoExclusao)
+
+	doc.CamposExtras(models.Transacao{}, map[string]any{"valor_estornavel": float64(0)})
+	doc.CamposExtras(models.AtivoFinanceiro{}, map[string]any{
+		"cheque_especial_utilizado":            float64(0),
+		"cheque_especial_disponivel":           float64(0),
+		"percentual_cheque_especial_utilizado": float64(0),
+	})
+
+	doc.Tag("Ativos", "Contas, cartões e demais ativos financeiros.")
+	doc.Tag("Transações", "Lançamentos, estornos, agendamentos e histórico de edições.")
+	doc.Tag("Transferências e câmbio", "Transferências entre ativos e taxas de câmbio.")
+	doc.Tag("Investimentos", "Títulos, operações, cotações e posições.")
+	doc.Tag("Financiamentos", "Financiamentos, parcelas e amortizações.")
+	doc.Tag("Metas", "Metas de economia e progresso.")
+	doc.Tag("Conciliação", "Conferência das transações com o extrato do banco.")
+	doc.Tag("Anexos", "Comprovantes vinculados às transações.")
+	doc.Tag("Categorias e orçamentos", "Categorias e orçamentos mensais por categoria.")
+	doc.Tag("Notificações", "Notificações e preferências de envio.")
+	doc.Tag("Webhooks", "Assinaturas de webhook e entregas.")
+	doc.Tag("Recorrências", "Transações recorrentes.")
+	doc.Tag("Regras", "Regras de categorização automática.")
+	doc.Tag("Relatórios", "Relatórios e exportações.")
+	doc.Tag("Backup", "Backup e restauração de todos os dados.")
+	doc.Tag("Stream", "Eventos em tempo real (Server-Sent Events).")
+	doc.Tag("Administração", "Rotinas periódicas, normalmente disparadas por um agendador.")
+	doc.Tag("Documentação", "Esta especificação e a página que a apresenta.")
+
+	doc.Adicionar(
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/ping", Tag: "Administração", Resumo: "Verifica se o servidor está no ar",
+			Status: http.StatusOK, Resposta: struct {
+				Message string `json:"message"`
+			}{}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/docs", Handler: "GetPagina", Tag: "Documentação", Resumo: "Página que apresenta a especificação",
+			Status: http.StatusOK, TiposConteudo: []string{"text/html"}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/docs/openapi.json", Handler: "GetEspecificacao", Tag: "Documentação", Resumo: "Especificação OpenAPI 3.1 da API",
+			Status: http.StatusOK, Resposta: map[string]any{}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/docs/arquivos/:arquivo", Handler: "GetArquivo", Tag: "Documentação", Resumo: "Arquivos estáticos da página da documentação",
+			Status: http.StatusOK, TiposConteudo: []string{"text/css", "text/javascript"}, Erros: []int{http.StatusNotFound}},
+
+		// Ativos
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/ativos", Handler: "CreateAtivoFinanceiro", Tag: "Ativos", Resumo: "Cria um ativo",
+			Descricao: "Um saldo inicial diferente de zero gera o lançamento SALDO_INICIAL na data 'data_saldo_inicial' (padrão: hoje).",
+			Corpo:     models.AtivoFinanceiro{}, Obrigatorios: []string{"nome", "tipo"},
+			Status: http.StatusCreated, Resposta: models.AtivoFinanceiro{}, Erros: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/ativos", Handler: "GetAtivosFinanceiros", Tag: "Ativos", Resumo: "Lista os ativos",
+			Status: http.StatusOK, Resposta: []models.AtivoFinanceiro{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/ativos/:id", Handler: "GetAtivoFinanceiro", Tag: "Ativos", Resumo: "Busca um ativo",
+			Status: http.StatusOK, Resposta: models.AtivoFinanceiro{}, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodPatch, Caminho: "/api/v1/ativos/:id", Handler: "UpdateAtivoFinanceiro", Tag: "Ativos", Resumo: "Altera um ativo",
+			Descricao: "'ajuste_limite' e 'limite_total' alteram o mesmo limite e não podem ser enviados juntos.",
+			Corpo:     services.UpdateAtivoInput{},
+			Status:    http.StatusOK, Resposta: models.AtivoFinanceiro{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/ativos/:id", Handler: "DeactivateAtivoFinanceiro", Tag: "Ativos", Resumo: "Desativa um ativo",
+			Descricao: "Pausa junto as recorrências do ativo, que passam a ter pausada_por_desativacao em GET /api/v1/ativos/{id}/recorrencias.",
+			Status:    http.StatusNoContent, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/ativos/:id/reativar", Handler: "ReactivateAtivoFinanceiro", Tag: "Ativos", Resumo: "Reativa um ativo",
+			Status: http.StatusOK, Resposta: models.AlteracaoStatusAtivo{}, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/ativos/:id/recalcular-saldo", Handler: "RecalcularSaldo", Tag: "Ativos", Resumo: "Recalcula saldo e limite a partir das transações",
+			Status: http.StatusOK, Resposta: models.RecalculoSaldo{}, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/ativos/:id/saldo-projetado", Handler: "GetSaldoProjetado", Tag: "Ativos", Resumo: "Projeta o saldo com as transações agendadas e pendentes",
+			Parametros: []openapi.Parametro{{Nome: "ate", Em: "query", Formato: "date", Descricao: "Data limite da projeção (AAAA-MM-DD)."}},
+			Status:     http.StatusOK, Resposta: models.SaldoProjetado{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError}},
+
+		// Transações
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transacoes", Handler: "CreateTransacao", Tag: "Transações", Resumo: "Cria uma transação",
+			Descricao: "'categoria_id' pode ser omitida quando há 'divisoes'; a data ausente vale agora. Transações parecidas já registradas voltam em 'possiveis_duplicatas', com o cabeçalho Warning.",
+			Corpo:     models.Transacao{}, Obrigatorios: []string{"ativo_financeiro_id", "valor", "tipo"},
+			Status: http.StatusCreated, Resposta: models.Transacao{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/transacoes", Handler: "GetTransacoes", Tag: "Transações", Resumo: "Lista as transações",
+			Parametros: parametrosFiltroTransacoes,
+			Status:     http.StatusOK, Resposta: []models.Transacao{}, Erros: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/transacoes/duplicatas", Handler: "GetDuplicatas", Tag: "Transações", Resumo: "Lista pares de possíveis duplicatas",
+			Parametros: parametrosPeriodo,
+			Status:     http.StatusOK, Resposta: []models.ParDuplicata{}, Erros: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/transacoes/exportar", Handler: "ExportarTransacoes", Tag: "Relatórios", Resumo: "Exporta as transações",
+			Descricao:  "Aceita os mesmos filtros da listagem. O OFX inclui apenas transações efetivadas.",
+			Parametros: concatenar(parametrosFiltroTransacoes, parametrosExportacao),
+			Status:     http.StatusOK, TiposConteudo: tiposExportacao, Erros: errosRelatorio},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transacoes/:id/reverter", Handler: "ReverseTransacao", Tag: "Transações", Resumo: "Estorna uma transação",
+			Descricao: "Sem corpo, o estorno é total e sem motivo registrado.",
+			Corpo:     services.ReverseTransacaoInput{}, CorpoOpcional: true,
+			Status: http.StatusCreated, Resposta: models.Transacao{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodPatch, Caminho: "/api/v1/transacoes/:id", Handler: "UpdateTransacao", Tag: "Transações", Resumo: "Altera uma transação",
+			Descricao: "Somente os campos presentes são alterados; a versão anterior vai para o histórico.",
+			Corpo:     services.UpdateTransacaoInput{},
+			Status:    http.StatusOK, Resposta: models.Transacao{}, Erros: append([]int{http.StatusBadRequest}, errosEdicaoTransacao...)},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/transacoes/:id", Handler: "DeleteTransacao", Tag: "Transações", Resumo: "Exclui uma transação",
+			Status: http.StatusNoContent, Erros: errosEdicaoTransacao},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/transacoes/:id/historico", Handler: "GetTransacaoHistorico", Tag: "Transações", Resumo: "Lista as versões anteriores da transação",
+			Status: http.StatusOK, Resposta: []models.TransacaoHistorico{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transacoes/:id/mesclar", Handler: "MesclarTransacoes", Tag: "Transações", Resumo: "Mescla uma duplicata na transação",
+			Corpo:  services.MesclarTransacoesInput{},
+			Status: http.StatusOK, Resposta: struct {
+				Transacao *models.Transacao `json:"transacao"`
+				Estorno   *models.Transacao `json:"estorno"`
+			}{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transacoes/:id/efetivar", Handler: "EfetivarTransacao", Tag: "Transações", Resumo: "Efetiva uma transação agendada ou pendente",
+			Descricao: "As pontas de uma transferência são efetivadas juntas, por POST /api/v1/transferencias/{id}/efetivar.",
+			Status:    http.StatusOK, Resposta: models.Transacao{}, Erros: errosEdicaoTransacao},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transacoes/:id/cancelar", Handler: "CancelarTransacao", Tag: "Transações", Resumo: "Cancela uma transação agendada ou pendente",
+			Status: http.StatusOK, Resposta: models.Transacao{}, Erros: errosEdicaoTransacao},
+
+		// Transferências e câmbio
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transferencias", Handler: "CreateTransferencia", Tag: "Transferências e câmbio", Resumo: "Transfere valor entre dois ativos",
+			Corpo:  services.CreateTransferenciaInput{},
+			Status: http.StatusCreated, Resposta: models.Transferencia{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transferencias/:id/efetivar", Handler: "EfetivarTransferencia", Tag: "Transferências e câmbio", Resumo: "Efetiva uma transferência agendada",
+			Descricao: "As duas pontas são efetivadas juntas; se uma delas não puder ser, como por saldo insuficiente na origem, nenhuma é.",
+			Status:    http.StatusOK, Resposta: models.Transferencia{}, Erros: []int{http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/taxas-cambio", Handler: "CreateTaxaCambio", Tag: "Transferências e câmbio", Resumo: "Registra uma taxa de câmbio",
+			Descricao: "'data' usa o formato AAAA-MM-DD e, ausente, vale o dia de hoje.",
+			Corpo:     createTaxaCambioRequest{},
+			Status:    http.StatusCreated, Resposta: models.TaxaCambio{}, Erros: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/taxas-cambio", Handler: "GetTaxasCambio", Tag: "Transferências e câmbio", Resumo: "Lista as taxas de câmbio",
+			Parametros: []openapi.Parametro{{Nome: "moeda_origem", Em: "query"}, {Nome: "moeda_destino", Em: "query"}},
+			Status:     http.StatusOK, Resposta: []models.TaxaCambio{}, Erros: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+
+		// Investimentos
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/investimentos/titulos", Handler: "CreateTitulo", Tag: "Investimentos", Resumo: "Cadastra um título",
+			Corpo: models.Titulo{}, Obrigatorios: []string{"codigo", "nome", "classe"},
+			Status: http.StatusCreated, Resposta: models.Titulo{}, Erros: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/investimentos/titulos", Handler: "GetTitulos", Tag: "Investimentos", Resumo: "Lista os títulos",
+			Status: http.StatusOK, Resposta: []models.Titulo{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/investimentos/titulos/:id/cotacoes", Handler: "RegistrarCotacao", Tag: "Investimentos", Resumo: "Registra a cotação de um título",
+			Descricao: "'data' usa o formato AAAA-MM-DD e, ausente, vale o dia de hoje.",
+			Corpo:     registrarCotacaoRequest{},
+			Status:    http.StatusCreated, Resposta: models.CotacaoTitulo{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/investimentos/cotacoes/importar", Handler: "ImportarCotacoes", Tag: "Investimentos", Resumo: "Importa cotações de um CSV",
+			Arquivo: "arquivo",
+			Status:  http.StatusOK, Resposta: struct {
+				Importadas int `json:"importadas"`
+			}{}, Erros: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/investimentos/operacoes", Handler: "CreateOperacao", Tag: "Investimentos", Resumo: "Registra uma compra, venda ou provento",
+			Corpo:  services.CreateOperacaoInvestimentoInput{},
+			Status: http.StatusCreated, Resposta: models.OperacaoInvestimento{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/investimentos/operacoes", Handler: "GetOperacoes", Tag: "Investimentos", Resumo: "Lista as operações",
+			Parametros: []openapi.Parametro{{Nome: "titulo_id", Em: "query"}},
+			Status:     http.StatusOK, Resposta: []models.OperacaoInvestimento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/investimentos/operacoes/:id", Handler: "DeleteOperacao", Tag: "Investimentos", Resumo: "Exclui uma operação",
+			Status: http.StatusNoContent, Erros: []int{http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/investimentos/posicoes", Handler: "GetPosicoes", Tag: "Investimentos", Resumo: "Posição atual em cada título",
+			Status: http.StatusOK, Resposta: []models.PosicaoInvestimento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/investimentos/alocacao", Handler: "GetAlocacao", Tag: "Investimentos", Resumo: "Alocação da carteira por classe",
+			Parametros: []openapi.Parametro{parametroMoedaRelatorio},
+			Status:     http.StatusOK, Resposta: models.AlocacaoCarteira{}, Erros: errosRelatorio},
+
+		// Financiamentos
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/financiamentos/simular", Handler: "SimularFinanciamento", Tag: "Financiamentos", Resumo: "Simula as parcelas de um financiamento",
+			Corpo:  simularFinanciamentoRequest{},
+			Status: http.StatusOK, Resposta: services.SimulacaoFinanciamento{}, Erros: []int{http.StatusBadRequest, http.StatusUnprocessableEntity}},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/financiamentos", Handler: "CreateFinanciamento", Tag: "Financiamentos", Resumo: "Cria um financiamento",
+			Corpo:  createFinanciamentoRequest{},
+			Status: http.StatusCreated, Resposta: models.Financiamento{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/financiamentos", Handler: "GetFinanciamentos", Tag: "Financiamentos", Resumo: "Lista os financiamentos",
+			Status: http.StatusOK, Resposta: []models.Financiamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/financiamentos/:id", Handler: "GetFinanciamento", Tag: "Financiamentos", Resumo: "Busca um financiamento com as parcelas",
+			Status: http.StatusOK, Resposta: models.Financiamento{}, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/financiamentos/:id/pagar-parcela", Handler: "PagarParcela", Tag: "Financiamentos", Resumo: "Paga a próxima parcela pendente",
+			Corpo: pagarParcelaRequest{}, CorpoOpcional: true,
+			Status: http.StatusOK, Resposta: models.ParcelaFinanciamento{}, Erros: append([]int{http.StatusBadRequest}, errosFinanciamento...)},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/financiamentos/:id/amortizacoes", Handler: "AmortizarFinanciamento", Tag: "Financiamentos", Resumo: "Registra uma amortização extra",
+			Corpo:  amortizarFinanciamentoRequest{},
+			Status: http.StatusOK, Resposta: models.Financiamento{}, Erros: append([]int{http.StatusBadRequest}, errosFinanciamento...)},
+
+		// Metas
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/metas", Handler: "CreateMeta", Tag: "Metas", Resumo: "Cria uma meta",
+			Corpo:  createMetaRequest{},
+			Status: http.StatusCreated, Resposta: models.Meta{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/metas", Handler: "GetMetas", Tag: "Metas", Resumo: "Lista as metas com o progresso",
+			Parametros: []openapi.Parametro{parametroMesesRitmo},
+			Status:     http.StatusOK, Resposta: []models.Meta{}, Erros: errosProgressoMeta},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/metas/em-risco", Handler: "GetMetasEmRisco", Tag: "Metas", Resumo: "Lista as metas que não serão atingidas no ritmo atual",
+			Parametros: []openapi.Parametro{parametroMesesRitmo},
+			Status:     http.StatusOK, Resposta: []models.Meta{}, Erros: errosProgressoMeta},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/metas/:id", Handler: "GetMeta", Tag: "Metas", Resumo: "Busca uma meta com o progresso",
+			Parametros: []openapi.Parametro{parametroMesesRitmo},
+			Status:     http.StatusOK, Resposta: models.Meta{}, Erros: errosProgressoMeta},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/metas/:id", Handler: "DeleteMeta", Tag: "Metas", Resumo: "Exclui uma meta",
+			Status: http.StatusNoContent, Erros: errosBusca},
+
+		// Conciliação
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/ativos/:id/conciliacoes", Handler: "CreateConciliacao", Tag: "Conciliação", Resumo: "Abre uma conciliação do ativo",
+			Corpo:  createConciliacaoRequest{},
+			Status: http.StatusCreated, Resposta: models.Conciliacao{}, Erros: append([]int{http.StatusBadRequest}, errosConciliacao...)},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/ativos/:id/conciliacoes", Handler: "ListConciliacoes", Tag: "Conciliação", Resumo: "Lista as conciliações do ativo",
+			Status: http.StatusOK, Resposta: []models.Conciliacao{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/conciliacoes/:id", Handler: "GetConciliacao", Tag: "Conciliação", Resumo: "Resumo de uma conciliação",
+			Status: http.StatusOK, Resposta: models.ResumoConciliacao{}, Erros: errosConciliacao},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/conciliacoes/:id/transacoes", Handler: "MarcarTransacoes", Tag: "Conciliação", Resumo: "Marca transações como conciliadas",
+			Corpo:  services.MarcarConciliadasInput{},
+			Status: http.StatusOK, Resposta: models.ResumoConciliacao{}, Erros: append([]int{http.StatusBadRequest}, errosConciliacao...)},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/conciliacoes/:id/concluir", Handler: "ConcluirConciliacao", Tag: "Conciliação", Resumo: "Conclui a conciliação e bloqueia as transações",
+			Status: http.StatusOK, Resposta: models.ResumoConciliacao{}, Erros: errosConciliacao},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/conciliacoes/:id", Handler: "DeleteConciliacao", Tag: "Conciliação", Resumo: "Descarta uma conciliação aberta",
+			Status: http.StatusNoContent, Erros: errosConciliacao},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transacoes/:id/desbloquear", Handler: "DesbloquearTransacao", Tag: "Conciliação", Resumo: "Desbloqueia uma transação conciliada",
+			Status: http.StatusOK, Resposta: models.Transacao{}, Erros: errosConciliacao},
+
+		// Anexos
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/transacoes/:id/anexos", Handler: "UploadAnexo", Tag: "Anexos", Resumo: "Anexa um arquivo à transação",
+			Arquivo: "arquivo",
+			Status:  http.StatusCreated, Resposta: models.Anexo{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/transacoes/:id/anexos", Handler: "ListAnexos", Tag: "Anexos", Resumo: "Lista os anexos da transação",
+			Status: http.StatusOK, Resposta: []models.Anexo{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/anexos/:id", Handler: "DownloadAnexo", Tag: "Anexos", Resumo: "Baixa o arquivo anexado",
+			Status: http.StatusOK, TiposConteudo: []string{"application/octet-stream"}, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/anexos/:id/miniatura", Handler: "DownloadMiniatura", Tag: "Anexos", Resumo: "Baixa a miniatura de um anexo de imagem",
+			Status: http.StatusOK, TiposConteudo: []string{"image/jpeg"}, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/anexos/:id", Handler: "DeleteAnexo", Tag: "Anexos", Resumo: "Exclui um anexo",
+			Status: http.StatusNoContent, Erros: errosBusca},
+
+		// Categorias e orçamentos
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/categorias", Handler: "CreateCategoria", Tag: "Categorias e orçamentos", Resumo: "Cria uma categoria",
+			Corpo: models.Categoria{}, Obrigatorios: []string{"nome"},
+			Status: http.StatusCreated, Resposta: models.Categoria{}, Erros: []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/categorias", Handler: "GetCategorias", Tag: "Categorias e orçamentos", Resumo: "Lista as categorias",
+			Status: http.StatusOK, Resposta: []models.Categoria{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/orcamentos", Handler: "GetOrcamentos", Tag: "Categorias e orçamentos", Resumo: "Lista os orçamentos",
+			Status: http.StatusOK, Resposta: []models.Orcamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPut, Caminho: "/api/v1/categorias/:id/orcamento", Handler: "SalvarOrcamento", Tag: "Categorias e orçamentos", Resumo: "Define o orçamento mensal da categoria",
+			Corpo:  services.SalvarOrcamentoInput{},
+			Status: http.StatusOK, Resposta: models.Orcamento{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/categorias/:id/orcamento", Handler: "DeleteOrcamento", Tag: "Categorias e orçamentos", Resumo: "Remove o orçamento da categoria",
+			Status: http.StatusNoContent, Erros: errosBusca},
+
+		// Notificações
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/notificacoes", Handler: "GetNotificacoes", Tag: "Notificações", Resumo: "Lista as notificações",
+			Parametros: []openapi.Parametro{{Nome: "nao_lidas", Em: "query", Tipo: "boolean", Descricao: "Com true, lista apenas as não lidas."}},
+			Status:     http.StatusOK, Resposta: []models.Notificacao{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/notificacoes/:id/lida", Handler: "MarcarLida", Tag: "Notificações", Resumo: "Marca uma notificação como lida",
+			Status: http.StatusNoContent, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/notificacoes/lidas", Handler: "MarcarTodasLidas", Tag: "Notificações", Resumo: "Marca todas as notificações como lidas",
+			Status: http.StatusOK, Resposta: struct {
+				Marcadas int64 `json:"marcadas"`
+			}{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/notificacoes/preferencias", Handler: "GetPreferencias", Tag: "Notificações", Resumo: "Lista as preferências por evento",
+			Status: http.StatusOK, Resposta: []models.PreferenciaNotificacao{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPut, Caminho: "/api/v1/notificacoes/preferencias/:evento", Handler: "UpdatePreferencia", Tag: "Notificações", Resumo: "Altera a preferência de um evento",
+			Corpo:  services.UpdatePreferenciaNotificacaoInput{},
+			Status: http.StatusOK, Resposta: models.PreferenciaNotificacao{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+
+		// Webhooks
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/webhooks", Handler: "CreateWebhook", Tag: "Webhooks", Resumo: "Assina eventos por webhook",
+			Descricao: "O segredo usado para assinar as entregas só é devolvido nesta resposta. Cada entrega traz X-Controlador-Timestamp (segundos Unix) e X-Controlador-Assinatura, o HMAC-SHA256 de \"<timestamp>.<corpo>\" no formato sha256=<hex>.",
+			Corpo:     services.CreateWebhookInput{},
+			Status:    http.StatusCreated, Resposta: models.WebhookAssinatura{}, Erros: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/webhooks", Handler: "GetWebhooks", Tag: "Webhooks", Resumo: "Lista as assinaturas",
+			Status: http.StatusOK, Resposta: []models.WebhookAssinatura{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/webhooks/:id", Handler: "DeleteWebhook", Tag: "Webhooks", Resumo: "Remove uma assinatura",
+			Status: http.StatusNoContent, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/webhooks/:id/entregas", Handler: "GetEntregas", Tag: "Webhooks", Resumo: "Lista as entregas de uma assinatura",
+			Status: http.StatusOK, Resposta: []models.EntregaWebhook{}, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/webhooks/entregas/:id/reenviar", Handler: "ReenviarEntrega", Tag: "Webhooks", Resumo: "Agenda o reenvio de uma entrega",
+			Status: http.StatusOK, Resposta: models.EntregaWebhook{}, Erros: errosBusca},
+
+		// Recorrências
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/recorrencias", Handler: "CreateTransacaoRecorrente", Tag: "Recorrências", Resumo: "Cria uma transação recorrente",
+			Corpo: models.TransacaoRecorrente{}, Obrigatorios: []string{"ativo_financeiro_id", "categoria_id", "valor", "tipo", "dia_do_vencimento"},
+			Status: http.StatusCreated, Resposta: models.TransacaoRecorrente{}, Erros: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/ativos/:id/recorrencias", Handler: "ListTransacoesRecorrentesPorAtivo", Tag: "Recorrências", Resumo: "Lista as recorrências do ativo",
+			Status: http.StatusOK, Resposta: []models.TransacaoRecorrente{}, Erros: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+
+		// Regras
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/regras", Handler: "CreateRegra", Tag: "Regras", Resumo: "Cria uma regra",
+			Corpo: models.Regra{}, Obrigatorios: []string{"nome", "condicoes", "acoes"},
+			Status: http.StatusCreated, Resposta: models.Regra{}, Erros: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/regras", Handler: "GetRegras", Tag: "Regras", Resumo: "Lista as regras",
+			Status: http.StatusOK, Resposta: []models.Regra{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodDelete, Caminho: "/api/v1/regras/:id", Handler: "DeleteRegra", Tag: "Regras", Resumo: "Exclui uma regra",
+			Status: http.StatusNoContent, Erros: errosBusca},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/regras/aplicar", Handler: "AplicarRegras", Tag: "Regras", Resumo: "Aplica as regras às transações existentes",
+			Descricao: "Com 'simular', apenas devolve o que mudaria. As datas usam o formato AAAA-MM-DD.",
+			Corpo:     aplicarRegrasRequest{},
+			Status:    http.StatusOK, Resposta: []models.DiferencaRegra{}, Erros: []int{http.StatusBadRequest, http.StatusInternalServerError}},
+
+		// Tags e relatórios
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/tags", Handler: "GetTags", Tag: "Relatórios", Resumo: "Lista as tags em uso",
+			Parametros: []openapi.Parametro{{Nome: "q", Em: "query", Descricao: "Filtra as tags que começam com o termo."}},
+			Status:     http.StatusOK, Resposta: []models.Tag{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/relatorios/categorias", Handler: "GetRelatorioCategorias", Tag: "Relatórios", Resumo: "Totais por categoria",
+			Parametros: concatenar(parametrosPeriodo, []openapi.Parametro{parametroMoedaRelatorio}),
+			Status:     http.StatusOK, Resposta: []models.RelatorioCategoria{}, Erros: errosRelatorio},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/relatorios/tags", Handler: "GetRelatorioTags", Tag: "Relatórios", Resumo: "Totais por tag",
+			Parametros: concatenar(parametrosPeriodo, []openapi.Parametro{parametroMoedaRelatorio}),
+			Status:     http.StatusOK, Resposta: []models.RelatorioTag{}, Erros: errosRelatorio},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/relatorios/mensal", Handler: "GetRelatorioMensal", Tag: "Relatórios", Resumo: "Receitas e despesas por mês",
+			Parametros: concatenar(parametrosPeriodo, []openapi.Parametro{parametroMoedaRelatorio}),
+			Status:     http.StatusOK, Resposta: []models.RelatorioMensal{}, Erros: errosRelatorio},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/relatorios/categorias/exportar", Handler: "ExportarRelatorioCategorias", Tag: "Relatórios", Resumo: "Exporta os totais por categoria",
+			Parametros: concatenar(parametrosPeriodo, []openapi.Parametro{parametroMoedaRelatorio}, parametrosExportacao),
+			Status:     http.StatusOK, TiposConteudo: tiposRelatorioExportado, Erros: errosRelatorio},
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/relatorios/mensal/exportar", Handler: "ExportarRelatorioMensal", Tag: "Relatórios", Resumo: "Exporta as receitas e despesas por mês",
+			Parametros: concatenar(parametrosPeriodo, []openapi.Parametro{parametroMoedaRelatorio}, parametrosExportacao),
+			Status:     http.StatusOK, TiposConteudo: tiposRelatorioExportado, Erros: errosRelatorio},
+
+		// Stream
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/stream", Handler: "GetStream", Tag: "Stream", Resumo: "Recebe alterações de saldo e novas transações em tempo real",
+			Descricao: "Cada evento SSE tem 'id', 'event' (o tipo) e 'data' com o evento em JSON, no formato de EventoStream.",
+			Parametros: []openapi.Parametro{
+				{Nome: "ativo_id", Em: "query", Descricao: "Um ou mais ativos, separados por vírgula."},
+				{Nome: "tipo", Em: "query", Descricao: "Um ou mais tipos de evento, separados por vírgula."},
+				{Nome: "Last-Event-ID", Em: "header", Tipo: "integer", Descricao: "Reenvia os eventos posteriores a este, ao reconectar."},
+				{Nome: "ultimo_evento_id", Em: "query", Tipo: "integer", Descricao: "Alternativa ao cabeçalho Last-Event-ID."},
+			},
+			Status: http.StatusOK, TiposConteudo: []string{"text/event-stream"}, Erros: []int{http.StatusBadRequest}},
+
+		// Backup
+		openapi.Operacao{Metodo: http.MethodGet, Caminho: "/api/v1/backup", Handler: "GetBackup", Tag: "Backup", Resumo: "Baixa o backup completo dos dados",
+			Status: http.StatusOK, Resposta: models.ArquivoBackup{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/api/v1/backup/restaurar", Handler: "RestaurarBackup", Tag: "Backup", Resumo: "Restaura um backup",
+			Descricao:  "O arquivo é validado por inteiro e carregado em uma única transação.",
+			Parametros: []openapi.Parametro{{Nome: "modo", Em: "query", Enum: []string{string(models.RestauracaoSubstituir), string(models.RestauracaoMesclar)}, Descricao: "substituir (padrão) apaga os dados atuais; mesclar soma o backup a eles."}},
+			Arquivo:    "arquivo",
+			Status:     http.StatusOK, Resposta: models.ResumoRestauracao{}, Erros: []int{http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusInternalServerError}},
+
+		// Administração
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/processar-recorrencias", Handler: "ProcessarRecorrencias", Tag: "Administração", Resumo: "Gera as transações das recorrências vencidas",
+			Status: http.StatusOK, Resposta: services.RelatorioProcessamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/efetivar-agendadas", Handler: "ProcessarAgendadas", Tag: "Administração", Resumo: "Efetiva as transações agendadas que venceram",
+			Status: http.StatusOK, Resposta: services.RelatorioProcessamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/limpar-chaves-idempotencia", Handler: "LimparChavesExpiradas", Tag: "Administração", Resumo: "Remove chaves de idempotência expiradas",
+			Status: http.StatusOK, Resposta: struct {
+				Removidas int64 `json:"removidas"`
+			}{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/juros-cheque-especial", Handler: "ProcessarJurosChequeEspecial", Tag: "Administração", Resumo: "Lança os juros do cheque especial",
+			Status: http.StatusOK, Resposta: services.RelatorioProcessamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/parcelas-financiamento", Handler: "ProcessarParcelas", Tag: "Administração", Resumo: "Paga as parcelas de financiamento vencidas",
+			Status: http.StatusOK, Resposta: services.RelatorioProcessamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/notificacoes", Handler: "VerificarNotificacoes", Tag: "Administração", Resumo: "Gera as notificações pendentes",
+			Status: http.StatusOK, Resposta: services.RelatorioProcessamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/webhooks", Handler: "DespacharWebhooks", Tag: "Administração", Resumo: "Envia as entregas de webhook pendentes",
+			Status: http.StatusOK, Resposta: services.RelatorioProcessamento{}, Erros: errosListagem},
+		openapi.Operacao{Metodo: http.MethodPost, Caminho: "/admin/workers/limpar-eventos-stream", Handler: "LimparEventosStream", Tag: "Administração", Resumo: "Remove eventos antigos do stream",
+			Status: http.StatusOK, Resposta: struct {
+				Removidos int64 `json:"removidos"`
+			}{}, Erros: errosListagem},
+	)
+	return doc
+}
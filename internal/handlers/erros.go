@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"controlador/backend/internal/erros"
+)
+
+var (
+	errCorpoJSONInvalido = erros.Invalido("json_invalido", "o corpo da requisição não é um JSON válido")
+	errArquivoAusente    = erros.Invalido("arquivo_ausente", "envie o arquivo no campo 'arquivo' (multipart/form-data)")
+	errArquivoIlegivel   = erros.Invalido("arquivo_ilegivel", "não foi possível ler o arquivo enviado")
+)
+
+const mensagemCamposInvalidos = "a requisição tem campos inválidos"
+
+// As mensagens de validação usam o nome do campo no JSON, não o da struct.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(campo reflect.StructField) string {
+			nome, _, _ := strings.Cut(campo.Tag.Get("json"), ",")
+			if nome == "-" {
+				return ""
+			}
+			return nome
+		})
+	}
+}
+
+// dadosInvalidos traduz a falha ao ler o corpo JSON de uma requisição no erro de validação,
+// com um item por campo rejeitado.
+func dadosInvalidos(err error) error {
+	if _, ok := erros.Como(err); ok {
+		// Tipos enumerados do pacote models já rejeitam valores desconhecidos com um erro de domínio.
+		return err
+	}
+
+	var validacao validator.ValidationErrors
+	var tipo *json.UnmarshalTypeError
+	var sintaxe *json.SyntaxError
+	switch {
+	case errors.As(err, &validacao):
+		campos := make([]erros.CampoInvalido, len(validacao))
+		for i, fe := range validacao {
+			campos[i] = erros.CampoInvalido{Campo: nomeCampo(fe.Namespace()), Mensagem: mensagemRegra(fe)}
+		}
+		return erros.Validacao(mensagemCamposInvalidos, campos...)
+	case errors.As(err, &tipo):
+		return erros.Validacao(mensagemCamposInvalidos, erros.CampoInvalido{
+			Campo:    tipo.Field,
+			Mensagem: fmt.Sprintf("tipo inválido: recebido %s", tipo.Value),
+		})
+	case errors.Is(err, io.EOF):
+		return erros.Validacao("o corpo da requisição está vazio")
+	case errors.As(err, &sintaxe) || errors.Is(err, io.ErrUnexpectedEOF):
+		return errCorpoJSONInvalido
+	}
+	return erros.Validacao(err.Error())
+}
+
+// nomeCampo tira do caminho do validador o nome da struct raiz: "Transacao.divisoes[0].valor"
+// vira "divisoes[0].valor".
+func nomeCampo(caminho string) string {
+	if _, campo, ok := strings.Cut(caminho, "."); ok {
+		return campo
+	}
+	return caminho
+}
+
+func mensagemRegra(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "campo obrigatório"
+	case "min":
+		if fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map {
+			return fmt.Sprintf("deve ter ao menos %s item(ns)", fe.Param())
+		}
+		return fmt.Sprintf("deve ser no mínimo %s", fe.Param())
+	case "max":
+		if fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map {
+			return fmt.Sprintf("deve ter no máximo %s item(ns)", fe.Param())
+		}
+		return fmt.Sprintf("deve ser no máximo %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("deve ser maior que %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("deve ser maior ou igual a %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("deve ser um de: %s", fe.Param())
+	}
+	return fmt.Sprintf("não atende à regra %s", fe.Tag())
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/exportacao"
+	"controlador/backend/internal/services"
+)
+
+type ExportacaoHandler struct {
+	transacoesService *services.ExportarTransacoesService
+	relatoriosService *services.ExportarRelatoriosService
+}
+
+func NewExportacaoHandler(transacoesSvc *services.ExportarTransacoesService, relatoriosSvc *services.ExportarRelatoriosService) *ExportacaoHandler {
+	return &ExportacaoHandler{
+		transacoesService: transacoesSvc,
+		relatoriosService: relatoriosSvc,
+	}
+}
+
+// parseExportacao lê '?formato=' (csv, ofx ou xlsx; csv se ausente) e '?localidade=' (pt-BR ou
+// en-US; pt-BR se ausente), que define separador decimal, formato de datas e separador do CSV.
+func parseExportacao(c *gin.Context) (exportacao.Formato, exportacao.Localidade, error) {
+	formato, err := exportacao.ParseFormato(c.Query("formato"))
+	if err != nil {
+		return "", exportacao.Localidade{}, err
+	}
+	localidade, err := exportacao.ParseLocalidade(c.Query("localidade"))
+	return formato, localidade, err
+}
+
+// iniciarDownload prepara os cabeçalhos da resposta; o corpo é escrito pelo serviço.
+func iniciarDownload(c *gin.Context, formato exportacao.Formato, nome string) {
+	c.Header("Content-Type", formato.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, formato.NomeArquivo(nome)))
+	c.Status(http.StatusOK)
+}
+
+// respondErroExportacao entrega o erro ao middleware se nada do arquivo foi enviado; depois disso
+// só resta interromper a resposta, e o cliente recebe um arquivo incompleto.
+func respondErroExportacao(c *gin.Context, err error, mensagem string) {
+	if c.Writer.Written() {
+		log.Error().Err(err).Msg(mensagem + " (resposta interrompida)")
+		c.Abort()
+		return
+	}
+	// Sem isso o erro seria enviado com o Content-Type do arquivo, que o gin não sobrescreve.
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	c.Error(err)
+}
+
+// ExportarTransacoes aceita os mesmos filtros da listagem de transações.
+func (h *ExportacaoHandler) ExportarTransacoes(c *gin.Context) {
+	filtro, err := parseFiltroTransacoes(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	formato, localidade, err := parseExportacao(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	iniciarDownload(c, formato, "transacoes")
+	if err := h.transacoesService.Execute(c.Request.Context(), filtro, formato, localidade, c.Writer); err != nil {
+		respondErroExportacao(c, err, "Erro ao exportar transações")
+	}
+}
+
+// ExportarRelatorioCategorias aceita os parâmetros do relatório por categoria e os de exportação;
+// relatórios não são exportados em OFX.
+func (h *ExportacaoHandler) ExportarRelatorioCategorias(c *gin.Context) {
+	periodo, formato, localidade, ok := parseExportacaoRelatorio(c)
+	if !ok {
+		return
+	}
+
+	iniciarDownload(c, formato, "relatorio-categorias")
+	if err := h.relatoriosService.Categorias(c.Request.Context(), periodo, c.Query("moeda"), formato, localidade, c.Writer); err != nil {
+		respondErroExportacao(c, err, "Erro ao exportar relatório por categoria")
+	}
+}
+
+func (h *ExportacaoHandler) ExportarRelatorioMensal(c *gin.Context) {
+	periodo, formato, localidade, ok := parseExportacaoRelatorio(c)
+	if !ok {
+		return
+	}
+
+	iniciarDownload(c, formato, "relatorio-mensal")
+	if err := h.relatoriosService.Mensal(c.Request.Context(), periodo, c.Query("moeda"), formato, localidade, c.Writer); err != nil {
+		respondErroExportacao(c, err, "Erro ao exportar relatório mensal")
+	}
+}
+
+// parseExportacaoRelatorio lê período e parâmetros de exportação, registrando o erro se inválidos.
+func parseExportacaoRelatorio(c *gin.Context) (services.Periodo, exportacao.Formato, exportacao.Localidade, bool) {
+	periodo, err := parsePeriodo(c)
+	if err != nil {
+		c.Error(err)
+		return periodo, "", exportacao.Localidade{}, false
+	}
+	formato, localidade, err := parseExportacao(c)
+	if err == nil && formato == exportacao.FormatoOFX {
+		err = exportacao.ErrFormatoNaoTabular
+	}
+	if err != nil {
+		c.Error(err)
+		return periodo, "", exportacao.Localidade{}, false
+	}
+	return periodo, formato, localidade, true
+}
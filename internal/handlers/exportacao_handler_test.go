@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/exportacao"
+	"controlador/backend/internal/middleware"
+)
+
+func TestRespondErroExportacaoAntesDoArquivo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.Erros())
+	r.GET("/exportar", func(c *gin.Context) {
+		iniciarDownload(c, exportacao.FormatoOFX, "transacoes")
+		respondErroExportacao(c, erros.NaoEncontrado("ATIVO_NAO_ENCONTRADO", "ativo não encontrado"), "Erro ao exportar")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exportar", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, esperado 404", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("Content-Type = %q, esperado application/problem+json", got)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != "" {
+		t.Errorf("Content-Disposition = %q, esperado vazio", got)
+	}
+}
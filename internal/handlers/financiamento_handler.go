@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/services"
+)
+
+type FinanciamentoHandler struct {
+	simularService   *services.SimularFinanciamentoService
+	createService    *services.CreateFinanciamentoService
+	listService      *services.ListFinanciamentosService
+	getService       *services.GetFinanciamentoService
+	pagarService     *services.PagarParcelaFinanciamentoService
+	amortizarService *services.AmortizarFinanciamentoService
+	processarService *services.ProcessarParcelasFinanciamentoService
+}
+
+func NewFinanciamentoHandler(simularSvc *services.SimularFinanciamentoService, createSvc *services.CreateFinanciamentoService, listSvc *services.ListFinanciamentosService, getSvc *services.GetFinanciamentoService, pagarSvc *services.PagarParcelaFinanciamentoService, amortizarSvc *services.AmortizarFinanciamentoService, processarSvc *services.ProcessarParcelasFinanciamentoService) *FinanciamentoHandler {
+	return &FinanciamentoHandler{
+		simularService:   simularSvc,
+		createService:    createSvc,
+		listService:      listSvc,
+		getService:       getSvc,
+		pagarService:     pagarSvc,
+		amortizarService: amortizarSvc,
+		processarService: processarSvc,
+	}
+}
+
+// As datas dos corpos abaixo usam o formato AAAA-MM-DD.
+
+type simularFinanciamentoRequest struct {
+	services.SimulacaoFinanciamentoInput
+	DataPrimeiraParcela string `json:"data_primeira_parcela"`
+}
+
+type createFinanciamentoRequest struct {
+	services.CreateFinanciamentoInput
+	DataPrimeiraParcela string `json:"data_primeira_parcela"`
+}
+
+type pagarParcelaRequest struct {
+	Data string `json:"data"`
+}
+
+type amortizarFinanciamentoRequest struct {
+	services.AmortizarFinanciamentoInput
+	Data string `json:"data"`
+}
+
+func (h *FinanciamentoHandler) SimularFinanciamento(c *gin.Context) {
+	var req simularFinanciamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	data, err := parseData(req.DataPrimeiraParcela)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if data != nil {
+		req.SimulacaoFinanciamentoInput.DataPrimeiraParcela = *data
+	}
+
+	simulacao, err := h.simularService.Execute(req.SimulacaoFinanciamentoInput)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, simulacao)
+}
+
+func (h *FinanciamentoHandler) CreateFinanciamento(c *gin.Context) {
+	var req createFinanciamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	data, err := parseData(req.DataPrimeiraParcela)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if data != nil {
+		req.CreateFinanciamentoInput.DataPrimeiraParcela = *data
+	}
+
+	financiamento, err := h.createService.Execute(c.Request.Context(), req.CreateFinanciamentoInput)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, financiamento)
+}
+
+func (h *FinanciamentoHandler) GetFinanciamentos(c *gin.Context) {
+	financiamentos, err := h.listService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, financiamentos)
+}
+
+func (h *FinanciamentoHandler) GetFinanciamento(c *gin.Context) {
+	financiamento, err := h.getService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, financiamento)
+}
+
+// PagarParcela paga a próxima parcela pendente; o corpo é opcional.
+func (h *FinanciamentoHandler) PagarParcela(c *gin.Context) {
+	var req pagarParcelaRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(dadosInvalidos(err))
+			return
+		}
+	}
+	data, err := parseData(req.Data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	parcela, err := h.pagarService.Execute(c.Request.Context(), c.Param("id"), data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, parcela)
+}
+
+func (h *FinanciamentoHandler) AmortizarFinanciamento(c *gin.Context) {
+	var req amortizarFinanciamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	data, err := parseData(req.Data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if data != nil {
+		req.AmortizarFinanciamentoInput.Data = *data
+	}
+
+	financiamento, err := h.amortizarService.Execute(c.Request.Context(), c.Param("id"), req.AmortizarFinanciamentoInput)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, financiamento)
+}
+
+func (h *FinanciamentoHandler) ProcessarParcelas(c *gin.Context) {
+	log.Info().Msg("Requisição para acionar o worker de parcelas de financiamento recebida.")
+	relatorio, err := h.processarService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
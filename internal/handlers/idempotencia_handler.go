@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type IdempotenciaHandler struct {
+	service *services.IdempotenciaService
+}
+
+func NewIdempotenciaHandler(svc *services.IdempotenciaService) *IdempotenciaHandler {
+	return &IdempotenciaHandler{service: svc}
+}
+
+// LimparChavesExpiradas remove as chaves de idempotência vencidas; pensado para ser acionado periodicamente.
+func (h *IdempotenciaHandler) LimparChavesExpiradas(c *gin.Context) {
+	removidas, err := h.service.LimparExpiradas(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"removidas": removidas})
+}
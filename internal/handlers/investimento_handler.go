@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/services"
+)
+
+type InvestimentoHandler struct {
+	createTituloService     *services.CreateTituloService
+	listTitulosService      *services.ListTitulosService
+	createOperacaoService   *services.CreateOperacaoInvestimentoService
+	listOperacoesService    *services.ListOperacoesInvestimentoService
+	deleteOperacaoService   *services.DeleteOperacaoInvestimentoService
+	registrarCotacaoService *services.RegistrarCotacaoService
+	importarCotacoesService *services.ImportarCotacoesService
+	posicoesService         *services.PosicoesInvestimentoService
+	alocacaoService         *services.AlocacaoCarteiraService
+}
+
+func NewInvestimentoHandler(createTituloSvc *services.CreateTituloService, listTitulosSvc *services.ListTitulosService, createOperacaoSvc *services.CreateOperacaoInvestimentoService, listOperacoesSvc *services.ListOperacoesInvestimentoService, deleteOperacaoSvc *services.DeleteOperacaoInvestimentoService, registrarCotacaoSvc *services.RegistrarCotacaoService, importarCotacoesSvc *services.ImportarCotacoesService, posicoesSvc *services.PosicoesInvestimentoService, alocacaoSvc *services.AlocacaoCarteiraService) *InvestimentoHandler {
+	return &InvestimentoHandler{
+		createTituloService:     createTituloSvc,
+		listTitulosService:      listTitulosSvc,
+		createOperacaoService:   createOperacaoSvc,
+		listOperacoesService:    listOperacoesSvc,
+		deleteOperacaoService:   deleteOperacaoSvc,
+		registrarCotacaoService: registrarCotacaoSvc,
+		importarCotacoesService: importarCotacoesSvc,
+		posicoesService:         posicoesSvc,
+		alocacaoService:         alocacaoSvc,
+	}
+}
+
+func (h *InvestimentoHandler) CreateTitulo(c *gin.Context) {
+	var input models.Titulo
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	titulo, err := h.createTituloService.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, titulo)
+}
+
+func (h *InvestimentoHandler) GetTitulos(c *gin.Context) {
+	titulos, err := h.listTitulosService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, titulos)
+}
+
+func (h *InvestimentoHandler) CreateOperacao(c *gin.Context) {
+	var input services.CreateOperacaoInvestimentoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	operacao, err := h.createOperacaoService.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, operacao)
+}
+
+// GetOperacoes aceita '?titulo_id=' para listar as operações de um único título.
+func (h *InvestimentoHandler) GetOperacoes(c *gin.Context) {
+	operacoes, err := h.listOperacoesService.Execute(c.Request.Context(), c.Query("titulo_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, operacoes)
+}
+
+func (h *InvestimentoHandler) DeleteOperacao(c *gin.Context) {
+	if err := h.deleteOperacaoService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// registrarCotacaoRequest é o corpo de POST /investimentos/titulos/:id/cotacoes; a data usa o
+// formato AAAA-MM-DD e, ausente, vale o dia de hoje.
+type registrarCotacaoRequest struct {
+	Data  string  `json:"data"`
+	Preco float64 `json:"preco" binding:"required"`
+}
+
+func (h *InvestimentoHandler) RegistrarCotacao(c *gin.Context) {
+	var req registrarCotacaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	data, err := parseData(req.Data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	input := models.CotacaoTitulo{Preco: req.Preco}
+	if data != nil {
+		input.Data = *data
+	}
+	cotacao, err := h.registrarCotacaoService.Execute(c.Request.Context(), c.Param("id"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, cotacao)
+}
+
+// ImportarCotacoes recebe o CSV no campo 'arquivo' de um formulário multipart.
+func (h *InvestimentoHandler) ImportarCotacoes(c *gin.Context) {
+	arquivo, err := c.FormFile("arquivo")
+	if err != nil {
+		c.Error(errArquivoAusente)
+		return
+	}
+	conteudo, err := arquivo.Open()
+	if err != nil {
+		c.Error(errArquivoIlegivel)
+		return
+	}
+	defer conteudo.Close()
+
+	importadas, err := h.importarCotacoesService.Execute(c.Request.Context(), conteudo)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"importadas": importadas})
+}
+
+func (h *InvestimentoHandler) GetPosicoes(c *gin.Context) {
+	posicoes, err := h.posicoesService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, posicoes)
+}
+
+// GetAlocacao aceita '?moeda=' para escolher a moeda do relatório (a moeda base, se ausente).
+func (h *InvestimentoHandler) GetAlocacao(c *gin.Context) {
+	alocacao, err := h.alocacaoService.Execute(c.Request.Context(), c.Query("moeda"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, alocacao)
+}
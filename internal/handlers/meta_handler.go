@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type MetaHandler struct {
+	createService  *services.CreateMetaService
+	listService    *services.ListMetasService
+	getService     *services.GetMetaService
+	deleteService  *services.DeleteMetaService
+	emRiscoService *services.MetasEmRiscoService
+}
+
+func NewMetaHandler(createSvc *services.CreateMetaService, listSvc *services.ListMetasService, getSvc *services.GetMetaService, deleteSvc *services.DeleteMetaService, emRiscoSvc *services.MetasEmRiscoService) *MetaHandler {
+	return &MetaHandler{
+		createService:  createSvc,
+		listService:    listSvc,
+		getService:     getSvc,
+		deleteService:  deleteSvc,
+		emRiscoService: emRiscoSvc,
+	}
+}
+
+// createMetaRequest recebe a data alvo no formato AAAA-MM-DD.
+type createMetaRequest struct {
+	services.CreateMetaInput
+	DataAlvo string `json:"data_alvo" binding:"required"`
+}
+
+func (h *MetaHandler) CreateMeta(c *gin.Context) {
+	var req createMetaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	data, err := parseData(req.DataAlvo)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	req.CreateMetaInput.DataAlvo = *data
+
+	meta, err := h.createService.Execute(c.Request.Context(), req.CreateMetaInput)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, meta)
+}
+
+// parseMesesRitmo lê '?meses=', a janela em meses usada para medir o ritmo de aportes.
+func parseMesesRitmo(c *gin.Context) (int, error) {
+	valor := c.Query("meses")
+	if valor == "" {
+		return services.MesesRitmoPadrao, nil
+	}
+	meses, err := strconv.Atoi(valor)
+	if err != nil {
+		return 0, services.ErrMesesRitmoInvalido
+	}
+	return meses, nil
+}
+
+// GetMetas aceita '?meses=' (padrão 3) para a janela do ritmo de aportes.
+func (h *MetaHandler) GetMetas(c *gin.Context) {
+	meses, err := parseMesesRitmo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	metas, err := h.listService.Execute(c.Request.Context(), meses)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, metas)
+}
+
+func (h *MetaHandler) GetMeta(c *gin.Context) {
+	meses, err := parseMesesRitmo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	meta, err := h.getService.Execute(c.Request.Context(), c.Param("id"), meses)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, meta)
+}
+
+func (h *MetaHandler) DeleteMeta(c *gin.Context) {
+	if err := h.deleteService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetMetasEmRisco lista as metas que, no ritmo dos últimos '?meses=' meses, não chegam ao alvo na data.
+func (h *MetaHandler) GetMetasEmRisco(c *gin.Context) {
+	meses, err := parseMesesRitmo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	metas, err := h.emRiscoService.Execute(c.Request.Context(), meses)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, metas)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/services"
+)
+
+type NotificacaoHandler struct {
+	listService              *services.ListNotificacoesService
+	marcarLidaService        *services.MarcarNotificacaoLidaService
+	listPreferenciasService  *services.ListPreferenciasNotificacaoService
+	updatePreferenciaService *services.UpdatePreferenciaNotificacaoService
+	verificarService         *services.VerificarNotificacoesService
+}
+
+func NewNotificacaoHandler(listSvc *services.ListNotificacoesService, marcarLidaSvc *services.MarcarNotificacaoLidaService, listPreferenciasSvc *services.ListPreferenciasNotificacaoService, updatePreferenciaSvc *services.UpdatePreferenciaNotificacaoService, verificarSvc *services.VerificarNotificacoesService) *NotificacaoHandler {
+	return &NotificacaoHandler{
+		listService:              listSvc,
+		marcarLidaService:        marcarLidaSvc,
+		listPreferenciasService:  listPreferenciasSvc,
+		updatePreferenciaService: updatePreferenciaSvc,
+		verificarService:         verificarSvc,
+	}
+}
+
+// GetNotificacoes lista a caixa de entrada; '?nao_lidas=true' omite as já lidas.
+func (h *NotificacaoHandler) GetNotificacoes(c *gin.Context) {
+	notificacoes, err := h.listService.Execute(c.Request.Context(), c.Query("nao_lidas") == "true")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, notificacoes)
+}
+
+func (h *NotificacaoHandler) MarcarLida(c *gin.Context) {
+	if err := h.marcarLidaService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *NotificacaoHandler) MarcarTodasLidas(c *gin.Context) {
+	marcadas, err := h.marcarLidaService.Todas(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marcadas": marcadas})
+}
+
+func (h *NotificacaoHandler) GetPreferencias(c *gin.Context) {
+	preferencias, err := h.listPreferenciasService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, preferencias)
+}
+
+func (h *NotificacaoHandler) UpdatePreferencia(c *gin.Context) {
+	var input services.UpdatePreferenciaNotificacaoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	preferencia, err := h.updatePreferenciaService.Execute(c.Request.Context(), c.Param("evento"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, preferencia)
+}
+
+func (h *NotificacaoHandler) VerificarNotificacoes(c *gin.Context) {
+	log.Info().Msg("Requisição para acionar o worker de notificações recebida.")
+	relatorio, err := h.verificarService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type OrcamentoHandler struct {
+	salvarService *services.SalvarOrcamentoService
+	listService   *services.ListOrcamentosService
+	deleteService *services.DeleteOrcamentoService
+}
+
+func NewOrcamentoHandler(salvarSvc *services.SalvarOrcamentoService, listSvc *services.ListOrcamentosService, deleteSvc *services.DeleteOrcamentoService) *OrcamentoHandler {
+	return &OrcamentoHandler{salvarService: salvarSvc, listService: listSvc, deleteService: deleteSvc}
+}
+
+func (h *OrcamentoHandler) SalvarOrcamento(c *gin.Context) {
+	var input services.SalvarOrcamentoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	orcamento, err := h.salvarService.Execute(c.Request.Context(), c.Param("id"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, orcamento)
+}
+
+func (h *OrcamentoHandler) GetOrcamentos(c *gin.Context) {
+	orcamentos, err := h.listService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, orcamentos)
+}
+
+func (h *OrcamentoHandler) DeleteOrcamento(c *gin.Context) {
+	if err := h.deleteService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
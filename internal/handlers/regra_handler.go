@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/services"
+)
+
+type RegraHandler struct {
+	createService  *services.CreateRegraService
+	listService    *services.ListRegrasService
+	deleteService  *services.DeleteRegraService
+	aplicarService *services.AplicarRegrasService
+}
+
+func NewRegraHandler(createSvc *services.CreateRegraService, listSvc *services.ListRegrasService, deleteSvc *services.DeleteRegraService, aplicarSvc *services.AplicarRegrasService) *RegraHandler {
+	return &RegraHandler{
+		createService:  createSvc,
+		listService:    listSvc,
+		deleteService:  deleteSvc,
+		aplicarService: aplicarSvc,
+	}
+}
+
+func (h *RegraHandler) CreateRegra(c *gin.Context) {
+	var input models.Regra
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	regra, err := h.createService.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, regra)
+}
+
+func (h *RegraHandler) GetRegras(c *gin.Context) {
+	regras, err := h.listService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, regras)
+}
+
+func (h *RegraHandler) DeleteRegra(c *gin.Context) {
+	if err := h.deleteService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// aplicarRegrasRequest é o corpo de POST /regras/aplicar; as datas usam o formato AAAA-MM-DD.
+type aplicarRegrasRequest struct {
+	Inicio  string `json:"inicio"`
+	Fim     string `json:"fim"`
+	Simular bool   `json:"simular"`
+}
+
+// AplicarRegras reaplica as regras a um período. Com "simular": true, apenas devolve as diferenças.
+func (h *RegraHandler) AplicarRegras(c *gin.Context) {
+	var req aplicarRegrasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	input := services.AplicarRegrasInput{Simular: req.Simular}
+	var err error
+	if input.Periodo.Inicio, err = parseData(req.Inicio); err != nil {
+		c.Error(err)
+		return
+	}
+	if input.Periodo.Fim, err = parseData(req.Fim); err != nil {
+		c.Error(err)
+		return
+	}
+
+	diferencas, err := h.aplicarService.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, diferencas)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type RelatorioHandler struct {
+	categoriasService *services.RelatorioCategoriasService
+	tagsService       *services.RelatorioTagsService
+	mensalService     *services.RelatorioMensalService
+}
+
+func NewRelatorioHandler(categoriasSvc *services.RelatorioCategoriasService, tagsSvc *services.RelatorioTagsService, mensalSvc *services.RelatorioMensalService) *RelatorioHandler {
+	return &RelatorioHandler{
+		categoriasService: categoriasSvc,
+		tagsService:       tagsSvc,
+		mensalService:     mensalSvc,
+	}
+}
+
+// parsePeriodo lê os parâmetros 'inicio' e 'fim' (AAAA-MM-DD) da query string.
+func parsePeriodo(c *gin.Context) (services.Periodo, error) {
+	var periodo services.Periodo
+	var err error
+	if periodo.Inicio, err = parseData(c.Query("inicio")); err != nil {
+		return periodo, err
+	}
+	periodo.Fim, err = parseData(c.Query("fim"))
+	return periodo, err
+}
+
+// parseData converte uma data AAAA-MM-DD; texto vazio resulta em nil.
+func parseData(valor string) (*time.Time, error) {
+	if valor == "" {
+		return nil, nil
+	}
+	data, err := time.Parse("2006-01-02", valor)
+	if err != nil {
+		return nil, services.ErrPeriodoInvalido
+	}
+	return &data, nil
+}
+
+// GetRelatorioCategorias aceita '?moeda=USD' para converter os totais para outra moeda que não a base.
+func (h *RelatorioHandler) GetRelatorioCategorias(c *gin.Context) {
+	periodo, err := parsePeriodo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	relatorio, err := h.categoriasService.Execute(c.Request.Context(), periodo, c.Query("moeda"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
+
+func (h *RelatorioHandler) GetRelatorioTags(c *gin.Context) {
+	periodo, err := parsePeriodo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	relatorio, err := h.tagsService.Execute(c.Request.Context(), periodo, c.Query("moeda"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
+
+// GetRelatorioMensal aceita '?moeda=', como os demais relatórios.
+func (h *RelatorioHandler) GetRelatorioMensal(c *gin.Context) {
+	periodo, err := parsePeriodo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	relatorio, err := h.mensalService.Execute(c.Request.Context(), periodo, c.Query("moeda"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/services"
+)
+
+const (
+	intervaloPingStream = 15 * time.Second
+	// esperaReconexaoClienteMs é sugerido ao navegador no campo 'retry' do SSE.
+	esperaReconexaoClienteMs = 3000
+)
+
+type StreamHandler struct {
+	service *services.StreamEventosService
+}
+
+func NewStreamHandler(svc *services.StreamEventosService) *StreamHandler {
+	return &StreamHandler{service: svc}
+}
+
+// parseFiltroStream lê '?ativo_id=' e '?tipo=', ambos aceitando valores separados por vírgula.
+// A aplicação não tem usuários; cada cliente filtra os ativos que acompanha.
+func parseFiltroStream(c *gin.Context) (services.FiltroStream, error) {
+	var filtro services.FiltroStream
+	if ativos := c.Query("ativo_id"); ativos != "" {
+		for _, id := range strings.Split(ativos, ",") {
+			filtro.AtivoIDs = append(filtro.AtivoIDs, strings.TrimSpace(id))
+		}
+	}
+	if tipos := c.Query("tipo"); tipos != "" {
+		for _, t := range strings.Split(tipos, ",") {
+			tipo, err := models.ParseTipoEventoStream(strings.TrimSpace(t))
+			if err != nil {
+				return filtro, err
+			}
+			filtro.Tipos = append(filtro.Tipos, tipo)
+		}
+	}
+	return filtro, nil
+}
+
+// parseUltimoEvento lê o cabeçalho Last-Event-ID, enviado pelo navegador ao reconectar, ou
+// '?ultimo_evento_id=' para a primeira conexão. Retorna -1 se nenhum foi informado.
+func parseUltimoEvento(c *gin.Context) (int64, error) {
+	valor := c.GetHeader("Last-Event-ID")
+	if valor == "" {
+		valor = c.Query("ultimo_evento_id")
+	}
+	if valor == "" {
+		return -1, nil
+	}
+	id, err := strconv.ParseInt(valor, 10, 64)
+	if err != nil || id < 0 {
+		return 0, erros.Invalido("ultimo_evento_invalido", fmt.Sprintf("ID do último evento inválido: %s", valor))
+	}
+	return id, nil
+}
+
+// GetStream envia por Server-Sent Events as alterações de saldo dos ativos e as transações
+// criadas, assim que confirmadas. Informando o último evento recebido, os eventos posteriores
+// a ele são reenviados antes dos novos.
+func (h *StreamHandler) GetStream(c *gin.Context) {
+	filtro, err := parseFiltroStream(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ultimoEvento, err := parseUltimoEvento(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// A assinatura é feita antes da leitura do histórico para que nenhum evento confirmado
+	// entre as duas coisas se perca; os repetidos são descartados abaixo.
+	assinatura := h.service.Assinar(filtro)
+	defer h.service.Cancelar(assinatura)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", esperaReconexaoClienteMs)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	enviados := make(map[int64]struct{})
+	if ultimoEvento >= 0 {
+		err := h.service.Historico(ctx, ultimoEvento, filtro, func(e models.EventoStream) error {
+			enviados[e.ID] = struct{}{}
+			return escreverEventoStream(c, e)
+		})
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Error().Err(err).Msg("Erro ao reenviar eventos do stream")
+			}
+			return
+		}
+	}
+
+	ping := time.NewTicker(intervaloPingStream)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ping.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		case e, ok := <-assinatura.Eventos:
+			if !ok {
+				// Encerrada pelo servidor; o cliente reconecta e retoma pelo Last-Event-ID.
+				return
+			}
+			if _, repetido := enviados[e.ID]; repetido {
+				continue
+			}
+			if err := escreverEventoStream(c, e); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func escreverEventoStream(c *gin.Context, e models.EventoStream) error {
+	dados, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Tipo, dados); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
+
+// LimparEventosStream remove os eventos fora do prazo de retenção; pensado para ser acionado periodicamente.
+func (h *StreamHandler) LimparEventosStream(c *gin.Context) {
+	removidos, err := h.service.LimparEventosAntigos(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"removidos": removidos})
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type TagHandler struct {
+	listService *services.ListTagsService
+}
+
+func NewTagHandler(listSvc *services.ListTagsService) *TagHandler {
+	return &TagHandler{
+		listService: listSvc,
+	}
+}
+
+// GetTags lista as tags; com o parâmetro 'q', funciona como autocompletar.
+func (h *TagHandler) GetTags(c *gin.Context) {
+	tags, err := h.listService.Execute(c.Request.Context(), c.Query("q"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, tags)
+}
@@ -8,7 +8,6 @@ import (
 
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/services"
-
 )
 
 type TransacaoRecorrenteHandler struct {
@@ -28,15 +27,13 @@ func NewTransacaoRecorrenteHandler(createSvc *services.CreateTransacaoRecorrente
 func (h *TransacaoRecorrenteHandler) CreateTransacaoRecorrente(c *gin.Context) {
 	var input models.TransacaoRecorrente
 	if err := c.ShouldBindJSON(&input); err != nil {
-		log.Error().Err(err).Msg("Erro no bind do JSON para criar transação recorrente")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "corpo da requisição inválido"})
+		c.Error(dadosInvalidos(err))
 		return
 	}
 
 	novaRecorrencia, err := h.createService.Execute(c.Request.Context(), input)
 	if err != nil {
-		log.Error().Err(err).Msg("Erro no serviço de criação de transação recorrente")
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -44,16 +41,9 @@ func (h *TransacaoRecorrenteHandler) CreateTransacaoRecorrente(c *gin.Context) {
 }
 
 func (h *TransacaoRecorrenteHandler) ListTransacoesRecorrentesPorAtivo(c *gin.Context) {
-	ativoID := c.Param("id")
-	if ativoID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID do ativo é obrigatório"})
-		return
-	}
-
-	recorrencias, err := h.listService.Execute(c.Request.Context(), ativoID)
+	recorrencias, err := h.listService.Execute(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		log.Error().Err(err).Msg("Erro ao listar transações recorrentes")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao listar transações recorrentes"})
+		c.Error(err)
 		return
 	}
 
@@ -64,8 +54,7 @@ func (h *TransacaoRecorrenteHandler) ProcessarRecorrencias(c *gin.Context) {
 	log.Info().Msg("Requisição para acionar o worker de processamento de recorrências recebida.")
 	relatorio, err := h.processarService.Execute(c.Request.Context())
 	if err != nil {
-		log.Error().Err(err).Msg("Erro na execução do worker de processamento de recorrências")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "erro ao processar recorrências"})
+		c.Error(err)
 		return
 	}
 
@@ -6,39 +6,115 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"io"
 	"net/http"
+	"strings"
 )
 
 type TransacaoHandler struct {
-	createService  *services.CreateTransacaoService
-	listService    *services.ListTransacoesService
-	reverseService *services.ReverseTransacaoService
+	createService     *services.CreateTransacaoService
+	listService       *services.ListTransacoesService
+	reverseService    *services.ReverseTransacaoService
+	updateService     *services.UpdateTransacaoService
+	deleteService     *services.DeleteTransacaoService
+	historicoService  *services.ListTransacaoHistoricoService
+	duplicatasService *services.ListDuplicatasService
+	mesclarService    *services.MesclarTransacoesService
+	efetivarService   *services.EfetivarTransacaoService
+	cancelarService   *services.CancelarTransacaoService
+	agendadasService  *services.ProcessarAgendadasService
 }
 
-func NewTransacaoHandler(createSvc *services.CreateTransacaoService, listSvc *services.ListTransacoesService, reverseSvc *services.ReverseTransacaoService) *TransacaoHandler {
+func NewTransacaoHandler(createSvc *services.CreateTransacaoService, listSvc *services.ListTransacoesService, reverseSvc *services.ReverseTransacaoService, updateSvc *services.UpdateTransacaoService, deleteSvc *services.DeleteTransacaoService, historicoSvc *services.ListTransacaoHistoricoService, duplicatasSvc *services.ListDuplicatasService, mesclarSvc *services.MesclarTransacoesService, efetivarSvc *services.EfetivarTransacaoService, cancelarSvc *services.CancelarTransacaoService, agendadasSvc *services.ProcessarAgendadasService) *TransacaoHandler {
 	return &TransacaoHandler{
-		createService:  createSvc,
-		listService:    listSvc,
-		reverseService: reverseSvc,
+		createService:     createSvc,
+		listService:       listSvc,
+		reverseService:    reverseSvc,
+		updateService:     updateSvc,
+		deleteService:     deleteSvc,
+		historicoService:  historicoSvc,
+		duplicatasService: duplicatasSvc,
+		mesclarService:    mesclarSvc,
+		efetivarService:   efetivarSvc,
+		cancelarService:   cancelarSvc,
+		agendadasService:  agendadasSvc,
 	}
 }
 
+func (h *TransacaoHandler) UpdateTransacao(c *gin.Context) {
+	var input services.UpdateTransacaoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	transacao, err := h.updateService.Execute(c.Request.Context(), c.Param("id"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, transacao)
+}
+
+func (h *TransacaoHandler) DeleteTransacao(c *gin.Context) {
+	if err := h.deleteService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// EfetivarTransacao efetiva uma transação agendada ou pendente, aplicando-a ao saldo.
+func (h *TransacaoHandler) EfetivarTransacao(c *gin.Context) {
+	transacao, err := h.efetivarService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, transacao)
+}
+
+// CancelarTransacao cancela uma transação agendada ou pendente.
+func (h *TransacaoHandler) CancelarTransacao(c *gin.Context) {
+	transacao, err := h.cancelarService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, transacao)
+}
+
+func (h *TransacaoHandler) ProcessarAgendadas(c *gin.Context) {
+	log.Info().Msg("Requisição para acionar o worker de efetivação de transações agendadas recebida.")
+	relatorio, err := h.agendadasService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
+
+func (h *TransacaoHandler) GetTransacaoHistorico(c *gin.Context) {
+	historico, err := h.historicoService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, historico)
+}
+
 // ALTERAÇÃO: Este método foi adicionado para lidar com a rota de estorno.
 func (h *TransacaoHandler) ReverseTransacao(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID da transação é obrigatório"})
+	// O corpo é opcional: sem ele, o estorno é total e sem motivo registrado.
+	var input services.ReverseTransacaoInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(dadosInvalidos(err))
 		return
 	}
 
-	estorno, err := h.reverseService.Execute(c.Request.Context(), id)
+	estorno, err := h.reverseService.Execute(c.Request.Context(), c.Param("id"), input)
 	if err != nil {
-		if errors.Is(err, services.ErrTransacaoJaEstornada) {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		log.Error().Err(err).Msg("Erro ao estornar transação")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao estornar transação"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusCreated, estorno)
@@ -47,31 +123,90 @@ func (h *TransacaoHandler) ReverseTransacao(c *gin.Context) {
 func (h *TransacaoHandler) CreateTransacao(c *gin.Context) {
 	var input models.Transacao
 	if err := c.ShouldBindJSON(&input); err != nil {
-		log.Error().Err(err).Msg("Erro no bind do JSON para criar transação")
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(dadosInvalidos(err))
 		return
 	}
 
 	novaTransacao, err := h.createService.Execute(c.Request.Context(), input)
 	if err != nil {
-		log.Error().Err(err).Msg("Erro no serviço de criação de transação")
-		if errors.Is(err, services.ErrSaldoInsuficiente) || errors.Is(err, services.ErrAtivoNaoEncontrado) || errors.Is(err, services.ErrTipoTransacaoInvalido) {
-			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao processar a transação"})
+		c.Error(err)
 		return
 	}
 
+	if len(novaTransacao.PossiveisDuplicatas) > 0 {
+		c.Header("Warning", `199 - "possivel transacao duplicada"`)
+	}
 	c.JSON(http.StatusCreated, novaTransacao)
 }
 
+func (h *TransacaoHandler) GetDuplicatas(c *gin.Context) {
+	periodo, err := parsePeriodo(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pares, err := h.duplicatasService.Execute(c.Request.Context(), periodo)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, pares)
+}
+
+// MesclarTransacoes mantém a transação da URL e estorna a duplicata informada no corpo.
+func (h *TransacaoHandler) MesclarTransacoes(c *gin.Context) {
+	var input services.MesclarTransacoesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	mantida, estorno, err := h.mesclarService.Execute(c.Request.Context(), c.Param("id"), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"transacao": mantida, "estorno": estorno})
+}
+
+// parseFiltroTransacoes lê os filtros de listagem da query string:
+// 'ativo_id', 'categoria_id', 'tag', 'status' (um ou mais, separados por vírgula),
+// 'inicio' e 'fim' (AAAA-MM-DD, inclusivos).
+func parseFiltroTransacoes(c *gin.Context) (models.FiltroTransacoes, error) {
+	filtro := models.FiltroTransacoes{
+		AtivoFinanceiroID: c.Query("ativo_id"),
+		CategoriaID:       c.Query("categoria_id"),
+		Tag:               c.Query("tag"),
+	}
+	if status := c.Query("status"); status != "" {
+		for _, st := range strings.Split(status, ",") {
+			s, err := models.ParseStatusTransacao(strings.ToUpper(strings.TrimSpace(st)))
+			if err != nil {
+				return filtro, err
+			}
+			filtro.Status = append(filtro.Status, s)
+		}
+	}
+	periodo, err := parsePeriodo(c)
+	if err != nil {
+		return filtro, err
+	}
+	filtro.Inicio, filtro.Fim, err = periodo.Limites()
+	return filtro, err
+}
+
 func (h *TransacaoHandler) GetTransacoes(c *gin.Context) {
-	transacoes, err := h.listService.Execute(c.Request.Context())
+	filtro, err := parseFiltroTransacoes(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	transacoes, err := h.listService.Execute(c.Request.Context(), filtro)
 	if err != nil {
-		log.Error().Err(err).Msg("Erro ao buscar transações")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao buscar transações"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, transacoes)
-}
\ No newline at end of file
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/services"
+)
+
+type TransferenciaHandler struct {
+	createService   *services.CreateTransferenciaService
+	efetivarService *services.EfetivarTransferenciaService
+}
+
+func NewTransferenciaHandler(createSvc *services.CreateTransferenciaService, efetivarSvc *services.EfetivarTransferenciaService) *TransferenciaHandler {
+	return &TransferenciaHandler{createService: createSvc, efetivarService: efetivarSvc}
+}
+
+func (h *TransferenciaHandler) CreateTransferencia(c *gin.Context) {
+	var input services.CreateTransferenciaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	transferencia, err := h.createService.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, transferencia)
+}
+
+// EfetivarTransferencia efetiva juntas as duas pontas de uma transferência agendada.
+func (h *TransferenciaHandler) EfetivarTransferencia(c *gin.Context) {
+	transferencia, err := h.efetivarService.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, transferencia)
+}
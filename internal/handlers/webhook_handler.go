@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/services"
+)
+
+type WebhookHandler struct {
+	createService    *services.CreateWebhookService
+	listService      *services.ListWebhooksService
+	deleteService    *services.DeleteWebhookService
+	entregasService  *services.EntregasWebhookService
+	despacharService *services.DespacharWebhooksService
+}
+
+func NewWebhookHandler(createSvc *services.CreateWebhookService, listSvc *services.ListWebhooksService, deleteSvc *services.DeleteWebhookService, entregasSvc *services.EntregasWebhookService, despacharSvc *services.DespacharWebhooksService) *WebhookHandler {
+	return &WebhookHandler{
+		createService:    createSvc,
+		listService:      listSvc,
+		deleteService:    deleteSvc,
+		entregasService:  entregasSvc,
+		despacharService: despacharSvc,
+	}
+}
+
+// CreateWebhook responde com o segredo da assinatura; ele não é exibido novamente.
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var input services.CreateWebhookInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+
+	assinatura, err := h.createService.Execute(c.Request.Context(), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, assinatura)
+}
+
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	assinaturas, err := h.listService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, assinaturas)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.deleteService.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) GetEntregas(c *gin.Context) {
+	entregas, err := h.entregasService.Listar(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, entregas)
+}
+
+func (h *WebhookHandler) ReenviarEntrega(c *gin.Context) {
+	entrega, err := h.entregasService.Reenviar(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, entrega)
+}
+
+func (h *WebhookHandler) DespacharWebhooks(c *gin.Context) {
+	log.Info().Msg("Requisição para acionar o worker de webhooks recebida.")
+	relatorio, err := h.despacharService.Execute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, relatorio)
+}
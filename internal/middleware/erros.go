@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/erros"
+)
+
+// TipoConteudoProblema é o tipo de conteúdo das respostas de erro (RFC 7807).
+const TipoConteudoProblema = "application/problem+json"
+
+// Problema é o corpo das respostas de erro, no formato problem+json. 'code' é o código estável
+// do erro de domínio e 'errors' detalha os campos de uma requisição inválida.
+type Problema struct {
+	Type     string                `json:"type"`
+	Title    string                `json:"title"`
+	Status   int                   `json:"status"`
+	Detail   string                `json:"detail,omitempty"`
+	Instance string                `json:"instance,omitempty"`
+	Code     string                `json:"code"`
+	Errors   []erros.CampoInvalido `json:"errors,omitempty"`
+}
+
+var statusPorTipo = map[erros.Tipo]int{
+	erros.TipoInvalido:      http.StatusBadRequest,
+	erros.TipoNaoEncontrado: http.StatusNotFound,
+	erros.TipoConflito:      http.StatusConflict,
+	erros.TipoRegraNegocio:  http.StatusUnprocessableEntity,
+	erros.TipoMuitoGrande:   http.StatusRequestEntityTooLarge,
+	erros.TipoNaoSuportado:  http.StatusUnsupportedMediaType,
+}
+
+const codigoErroInterno = "erro_interno"
+
+var errRotaNaoEncontrada = erros.NaoEncontrado("rota_nao_encontrada", "rota não encontrada")
+
+// Erros responde o último erro registrado pelo handler com c.Error. Erros de domínio recebem o
+// status correspondente ao seu tipo; qualquer outro erro vira 500, sem expor a mensagem original.
+// Deve ficar depois do middleware de idempotência, para que a resposta de erro também seja guardada.
+func Erros() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		responderProblema(c, c.Errors.Last().Err)
+	}
+}
+
+// Recuperacao responde com um Problema 500 as requisições cujo handler entrou em panic.
+func Recuperacao() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recuperado any) {
+		responderProblema(c, fmt.Errorf("panic: %v", recuperado))
+		c.Abort()
+	})
+}
+
+// RotaNaoEncontrada responde as requisições para caminhos que não existem.
+func RotaNaoEncontrada(c *gin.Context) {
+	c.Error(errRotaNaoEncontrada)
+}
+
+func responderProblema(c *gin.Context, err error) {
+	problema := Problema{
+		Status:   http.StatusInternalServerError,
+		Code:     codigoErroInterno,
+		Detail:   "Erro interno ao processar a requisição",
+		Instance: c.Request.URL.Path,
+	}
+	if e, ok := erros.Como(err); ok {
+		if status, ok := statusPorTipo[e.Tipo]; ok {
+			problema.Status = status
+			problema.Code = e.Codigo
+			problema.Detail = err.Error()
+			problema.Errors = e.Campos
+		}
+	}
+	problema.Type = "urn:controlador:erro:" + problema.Code
+	problema.Title = http.StatusText(problema.Status)
+
+	corpo, err := json.Marshal(problema)
+	if err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Header("Content-Type", TipoConteudoProblema)
+	c.Data(problema.Status, TipoConteudoProblema, corpo)
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/services"
+)
+
+var errCorpoIlegivel = erros.Invalido("corpo_ilegivel", "não foi possível ler o corpo da requisição")
+
+const (
+	cabecalhoChaveIdempotencia = "Idempotency-Key"
+	cabecalhoRespostaRepetida  = "Idempotent-Replayed"
+)
+
+// respostaGravada repassa a resposta ao cliente e guarda uma cópia do corpo.
+type respostaGravada struct {
+	gin.ResponseWriter
+	corpo bytes.Buffer
+}
+
+func (w *respostaGravada) Write(b []byte) (int, error) {
+	w.corpo.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *respostaGravada) WriteString(s string) (int, error) {
+	w.corpo.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotencia atende o cabeçalho Idempotency-Key nas rotas que alteram dados. A primeira
+// requisição com a chave é executada e sua resposta é guardada; as seguintes, com o mesmo
+// conteúdo, recebem a resposta guardada sem executar nada. A mesma chave com outro conteúdo,
+// ou enquanto a primeira ainda está em processamento, resulta em 409.
+// Requisições sem o cabeçalho seguem normalmente.
+func Idempotencia(svc *services.IdempotenciaService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		chave := c.GetHeader(cabecalhoChaveIdempotencia)
+		if chave == "" || !alteraDados(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		corpo, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			responderProblema(c, errCorpoIlegivel)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(corpo))
+
+		ctx := c.Request.Context()
+		fingerprint := services.Fingerprint(c.Request.Method, c.Request.URL.RequestURI(), corpo)
+		anterior, err := svc.Iniciar(ctx, chave, fingerprint)
+		if err != nil {
+			if _, ok := erros.Como(err); !ok {
+				log.Error().Err(err).Msg("Erro ao verificar chave de idempotência")
+			}
+			responderProblema(c, err)
+			c.Abort()
+			return
+		}
+		if anterior != nil {
+			c.Header(cabecalhoRespostaRepetida, "true")
+			c.Data(*anterior.StatusCode, anterior.ContentType, anterior.Corpo)
+			c.Abort()
+			return
+		}
+
+		w := &respostaGravada{ResponseWriter: c.Writer}
+		c.Writer = w
+		concluida := false
+		defer func() {
+			// Um panic no handler não pode deixar a chave presa como "em processamento".
+			if !concluida {
+				if err := svc.Liberar(context.WithoutCancel(ctx), chave); err != nil {
+					log.Error().Err(err).Str("chave", chave).Msg("Erro ao liberar chave de idempotência")
+				}
+			}
+		}()
+
+		c.Next()
+
+		// O contexto da requisição pode já ter sido cancelado se o cliente desistiu,
+		// mas a resposta precisa ser guardada para a próxima tentativa.
+		if err := svc.Concluir(context.WithoutCancel(ctx), chave, w.Status(), w.Header().Get("Content-Type"), w.corpo.Bytes()); err != nil {
+			log.Error().Err(err).Str("chave", chave).Msg("Erro ao guardar resposta da chave de idempotência")
+			return
+		}
+		concluida = true
+	}
+}
+
+func alteraDados(metodo string) bool {
+	switch metodo {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
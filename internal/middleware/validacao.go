@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/openapi"
+)
+
+const mensagemForaDaEspecificacao = "a requisição tem campos inválidos"
+
+// ValidarRequisicao confere o corpo JSON de cada requisição com o esquema publicado para a rota
+// em /api/docs/openapi.json e responde 400, com os campos rejeitados, sem chamar o handler.
+// Deve ficar depois de Erros, que é quem escreve a resposta.
+func ValidarRequisicao(esp *openapi.Especificacao) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !esp.RecebeJSON(c.Request.Method, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		corpo, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(errCorpoIlegivel)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(corpo))
+
+		if campos := esp.ValidarRequisicao(c.Request.Method, c.FullPath(), corpo); len(campos) > 0 {
+			c.Error(erros.Validacao(mensagemForaDaEspecificacao, campos...))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -3,7 +3,11 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
 	"time"
+
+	"controlador/backend/internal/erros"
 )
 
 type TipoAtivo string
@@ -11,6 +15,12 @@ type TipoAtivo string
 const (
 	AtivoContaCorrente TipoAtivo = "CONTA_CORRENTE"
 	AtivoCartaoCredito TipoAtivo = "CARTÃO_CREDITO"
+	AtivoPoupanca      TipoAtivo = "POUPANCA"
+	AtivoDinheiro      TipoAtivo = "DINHEIRO"
+	AtivoInvestimento  TipoAtivo = "INVESTIMENTO"
+	AtivoValeRefeicao  TipoAtivo = "VALE_REFEICAO"
+	// AtivoEmprestimo guarda o saldo devedor como valor negativo.
+	AtivoEmprestimo TipoAtivo = "EMPRESTIMO"
 )
 
 type TipoTransacao string
@@ -20,6 +30,31 @@ const (
 	TransacaoDebito      TipoTransacao = "DEBITO"
 	TransacaoCredito     TipoTransacao = "CREDITO"
 	TransacaoEstorno     TipoTransacao = "ESTORNO"
+	// TransacaoSaldoInicial é o lançamento de abertura gerado na criação do ativo. Não é aceito
+	// como entrada da API e, ao contrário dos demais tipos, seu valor pode ser negativo.
+	TransacaoSaldoInicial TipoTransacao = "SALDO_INICIAL"
+)
+
+// OrigemTransacao identifica o subsistema responsável por uma transação gerada automaticamente.
+type OrigemTransacao string
+
+const (
+	OrigemInvestimento  OrigemTransacao = "INVESTIMENTO"
+	OrigemFinanciamento OrigemTransacao = "FINANCIAMENTO"
+	OrigemTransferencia OrigemTransacao = "TRANSFERENCIA"
+)
+
+// StatusTransacao indica em que ponto do ciclo de vida a transação está.
+// Somente transações efetivadas alteram o saldo e o limite do ativo.
+type StatusTransacao string
+
+const (
+	// StatusAgendada aguarda a data da transação para ser efetivada automaticamente.
+	StatusAgendada StatusTransacao = "AGENDADA"
+	// StatusPendente aguarda confirmação manual, independentemente da data.
+	StatusPendente  StatusTransacao = "PENDENTE"
+	StatusEfetivada StatusTransacao = "EFETIVADA"
+	StatusCancelada StatusTransacao = "CANCELADA"
 )
 
 type Categoria struct {
@@ -35,20 +70,791 @@ type AtivoFinanceiro struct {
 	Tipo             TipoAtivo `json:"tipo" db:"tipo"`
 	SaldoAtual       float64   `json:"saldo_atual" db:"saldo_atual"`
 	LimiteDisponivel float64   `json:"limite_disponivel" db:"limite_disponivel"`
-	IsActive         bool      `json:"is_active" db:"is_active"`
+	// LimiteTotal é o limite de crédito contratado do cartão; o utilizado é LimiteTotal - LimiteDisponivel.
+	LimiteTotal float64 `json:"limite_total" db:"limite_total"`
+	// Moeda é o código ISO 4217 em que o saldo e as transações do ativo são expressos.
+	Moeda string `json:"moeda" db:"moeda"`
+	// LimiteChequeEspecial é quanto uma conta corrente pode ficar negativa.
+	LimiteChequeEspecial float64 `json:"limite_cheque_especial" db:"limite_cheque_especial"`
+	// TaxaJurosChequeEspecial é a taxa mensal, em %, cobrada sobre o saldo negativo.
+	TaxaJurosChequeEspecial float64   `json:"taxa_juros_cheque_especial" db:"taxa_juros_cheque_especial"`
+	IsActive                bool      `json:"is_active" db:"is_active"`
+	CreatedAt               time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time `json:"updated_at" db:"updated_at"`
+	// DataSaldoInicial é a data do lançamento de saldo inicial, informada apenas na criação.
+	DataSaldoInicial *time.Time `json:"data_saldo_inicial,omitempty" db:"-"`
+}
+
+type Transacao struct {
+	ID                string          `json:"id" db:"id"`
+	AtivoFinanceiroID string          `json:"ativo_financeiro_id" db:"ativo_financeiro_id"`
+	CategoriaID       string          `json:"categoria_id" db:"categoria_id"`
+	Descricao         string          `json:"descricao" db:"descricao"`
+	Valor             float64         `json:"valor" db:"valor"`
+	Tipo              TipoTransacao   `json:"tipo" db:"tipo"`
+	Status            StatusTransacao `json:"status" db:"status"`
+	// Moeda é sempre a do ativo da transação; é gravada para que relatórios possam convertê-la.
+	Moeda          string    `json:"moeda" db:"moeda"`
+	Data           time.Time `json:"data" db:"data"`
+	Notas          string    `json:"notas,omitempty" db:"notas"`
+	ReversalOf     *string   `json:"reversal_of,omitempty" db:"reversal_of"`
+	MotivoEstorno  *string   `json:"motivo_estorno,omitempty" db:"motivo_estorno"`
+	EstornoParcial bool      `json:"estorno_parcial,omitempty" db:"estorno_parcial"`
+	ValorEstornado float64   `json:"valor_estornado" db:"valor_estornado"`
+	// Conciliada indica que a transação foi conferida com o extrato do banco.
+	Conciliada bool `json:"conciliada" db:"conciliada"`
+	// Bloqueada impede edição, exclusão e estorno de uma transação já conciliada.
+	Bloqueada     bool    `json:"bloqueada" db:"bloqueada"`
+	ConciliacaoID *string `json:"conciliacao_id,omitempty" db:"conciliacao_id"`
+	// Origem indica o subsistema que gerou a transação; vazia nas lançadas diretamente.
+	// Transações com origem só são alteradas pelo subsistema que as gerou.
+	Origem    OrigemTransacao `json:"origem,omitempty" db:"origem"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	// Divisoes rateia o valor da transação entre categorias; vazio quando não há rateio.
+	Divisoes []TransacaoDivisao `json:"divisoes,omitempty" db:"-"`
+	Tags     []string           `json:"tags,omitempty" db:"-"`
+	// PossiveisDuplicatas lista, na resposta de criação, transações parecidas já registradas.
+	PossiveisDuplicatas []string `json:"possiveis_duplicatas,omitempty" db:"-"`
+}
+
+// TransacaoDivisao é uma linha de rateio de uma transação, com categoria e valor próprios.
+type TransacaoDivisao struct {
+	ID          string  `json:"id" db:"id"`
+	TransacaoID string  `json:"transacao_id" db:"transacao_id"`
+	CategoriaID string  `json:"categoria_id" db:"categoria_id"`
+	Valor       float64 `json:"valor" db:"valor"`
+	Memo        string  `json:"memo,omitempty" db:"memo"`
+}
+
+// Anexo é um arquivo (comprovante, nota fiscal) vinculado a uma transação.
+// As chaves de armazenamento são internas e não são expostas na API.
+type Anexo struct {
+	ID              string    `json:"id" db:"id"`
+	TransacaoID     string    `json:"transacao_id" db:"transacao_id"`
+	NomeArquivo     string    `json:"nome_arquivo" db:"nome_arquivo"`
+	ContentType     string    `json:"content_type" db:"content_type"`
+	Tamanho         int64     `json:"tamanho" db:"tamanho"`
+	Chave           string    `json:"-" db:"chave"`
+	ChaveMiniatura  *string   `json:"-" db:"chave_miniatura"`
+	PossuiMiniatura bool      `json:"possui_miniatura" db:"-"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+type CampoCondicaoRegra string
+
+const (
+	CondicaoDescricao CampoCondicaoRegra = "descricao"
+	CondicaoAtivo     CampoCondicaoRegra = "ativo_financeiro_id"
+	CondicaoValor     CampoCondicaoRegra = "valor"
+	CondicaoTipo      CampoCondicaoRegra = "tipo"
+)
+
+type OperadorCondicaoRegra string
+
+const (
+	OperadorContem OperadorCondicaoRegra = "contem"
+	OperadorRegex  OperadorCondicaoRegra = "regex"
+	OperadorIgual  OperadorCondicaoRegra = "igual"
+	OperadorEntre  OperadorCondicaoRegra = "entre"
+)
+
+// CondicaoRegra compara um campo da transação. 'Valor' é usado por contem, regex e igual;
+// 'Minimo' e 'Maximo' (inclusivos, opcionais) pelo operador entre.
+type CondicaoRegra struct {
+	Campo    CampoCondicaoRegra    `json:"campo"`
+	Operador OperadorCondicaoRegra `json:"operador"`
+	Valor    string                `json:"valor,omitempty"`
+	Minimo   *float64              `json:"minimo,omitempty"`
+	Maximo   *float64              `json:"maximo,omitempty"`
+}
+
+type TipoAcaoRegra string
+
+const (
+	AcaoDefinirCategoria  TipoAcaoRegra = "definir_categoria"
+	AcaoAdicionarTag      TipoAcaoRegra = "adicionar_tag"
+	AcaoRenomearDescricao TipoAcaoRegra = "renomear_descricao"
+)
+
+type AcaoRegra struct {
+	Tipo  TipoAcaoRegra `json:"tipo"`
+	Valor string        `json:"valor"`
+}
+
+// Regra categoriza transações automaticamente. Todas as condições precisam ser atendidas;
+// regras com menor prioridade são avaliadas primeiro.
+type Regra struct {
+	ID         string          `json:"id" db:"id"`
+	Nome       string          `json:"nome" db:"nome"`
+	Prioridade int             `json:"prioridade" db:"prioridade"`
+	Ativa      bool            `json:"ativa" db:"ativa"`
+	Condicoes  []CondicaoRegra `json:"condicoes" db:"condicoes"`
+	Acoes      []AcaoRegra     `json:"acoes" db:"acoes"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// DiferencaRegra descreve o que a reaplicação das regras muda em uma transação.
+type DiferencaRegra struct {
+	TransacaoID       string   `json:"transacao_id"`
+	RegrasAplicadas   []string `json:"regras_aplicadas"`
+	DescricaoAnterior string   `json:"descricao_anterior"`
+	DescricaoNova     string   `json:"descricao_nova"`
+	CategoriaAnterior string   `json:"categoria_anterior"`
+	CategoriaNova     string   `json:"categoria_nova"`
+	TagsAdicionadas   []string `json:"tags_adicionadas,omitempty"`
+}
+
+// CriterioDuplicata define quão próximas duas transações do mesmo ativo precisam ser
+// para serem consideradas possíveis duplicatas.
+type CriterioDuplicata struct {
+	ToleranciaMinima     float64       // diferença de valor sempre aceita, em reais
+	ToleranciaPercentual float64       // diferença de valor aceita, proporcional ao maior valor
+	Janela               time.Duration // distância máxima entre as datas
+	SimilaridadeMinima   float64       // semelhança mínima das descrições, de 0 a 1
+}
+
+// ParDuplicata é um par de transações que provavelmente representam o mesmo lançamento.
+type ParDuplicata struct {
+	Transacao    Transacao `json:"transacao"`
+	Duplicata    Transacao `json:"duplicata"`
+	Similaridade float64   `json:"similaridade"`
+}
+
+// ChaveIdempotencia registra uma requisição feita com o cabeçalho Idempotency-Key e, depois de
+// concluída, a resposta que deve ser repetida nas novas tentativas. 'StatusCode' nulo indica
+// que a requisição original ainda está em processamento.
+type ChaveIdempotencia struct {
+	Chave       string
+	Fingerprint string
+	StatusCode  *int
+	ContentType string
+	Corpo       []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Tag é um rótulo livre, transversal às categorias, aplicado a transações e recorrências.
+type Tag struct {
+	ID   string `json:"id" db:"id"`
+	Nome string `json:"nome" db:"nome"`
+}
+
+// FiltroTransacoes restringe a listagem de transações; campos vazios não filtram.
+// 'Fim' é exclusivo.
+type FiltroTransacoes struct {
+	AtivoFinanceiroID string
+	CategoriaID       string
+	Tag               string
+	Status            []StatusTransacao
+	Conciliada        *bool
+	Inicio            *time.Time
+	Fim               *time.Time
+}
+
+// TransacaoExportada acompanha a transação com o que as exportações mostram além dela: os nomes
+// do ativo e da categoria e, nos estornos, o tipo da transação original.
+type TransacaoExportada struct {
+	Transacao
+	AtivoNome     string
+	AtivoTipo     TipoAtivo
+	AtivoSaldo    float64
+	CategoriaNome string
+	TipoOriginal  *TipoTransacao
+}
+
+type StatusConciliacao string
+
+const (
+	ConciliacaoAberta    StatusConciliacao = "ABERTA"
+	ConciliacaoConcluida StatusConciliacao = "CONCLUIDA"
+)
+
+// Conciliacao confronta os registros de um ativo com o saldo informado no extrato do banco
+// em uma data. Ao ser concluída, as transações conferidas ficam bloqueadas.
+type Conciliacao struct {
+	ID                string            `json:"id" db:"id"`
+	AtivoFinanceiroID string            `json:"ativo_financeiro_id" db:"ativo_financeiro_id"`
+	DataExtrato       time.Time         `json:"data_extrato" db:"data_extrato"`
+	SaldoExtrato      float64           `json:"saldo_extrato" db:"saldo_extrato"`
+	Status            StatusConciliacao `json:"status" db:"status"`
+	CreatedAt         time.Time         `json:"created_at" db:"created_at"`
+	ConcluidaEm       *time.Time        `json:"concluida_em,omitempty" db:"concluida_em"`
+}
+
+// ResumoConciliacao mostra o andamento de uma conciliação: o saldo considerando apenas as
+// transações conferidas, a diferença para o extrato e as transações que podem ser conferidas.
+type ResumoConciliacao struct {
+	Conciliacao
+	SaldoConciliado float64     `json:"saldo_conciliado"`
+	Diferenca       float64     `json:"diferenca"`
+	Transacoes      []Transacao `json:"transacoes"`
+}
+
+// SaldoProjetado mostra o saldo e o limite atuais de um ativo ao lado do efeito das
+// transações agendadas e pendentes, que ainda não foram aplicadas.
+type SaldoProjetado struct {
+	AtivoFinanceiroID   string     `json:"ativo_financeiro_id"`
+	SaldoAtual          float64    `json:"saldo_atual"`
+	LimiteDisponivel    float64    `json:"limite_disponivel"`
+	SaldoPendente       float64    `json:"saldo_pendente"`
+	LimitePendente      float64    `json:"limite_pendente"`
+	SaldoProjetado      float64    `json:"saldo_projetado"`
+	LimiteProjetado     float64    `json:"limite_projetado"`
+	TransacoesPendentes int        `json:"transacoes_pendentes"`
+	Ate                 *time.Time `json:"ate,omitempty"`
+}
+
+// RelatorioTag totaliza receitas e despesas das transações marcadas com uma tag, já descontados os estornos.
+type RelatorioTag struct {
+	TagID    string  `json:"tag_id"`
+	TagNome  string  `json:"tag_nome"`
+	Moeda    string  `json:"moeda"`
+	Receitas float64 `json:"receitas"`
+	Despesas float64 `json:"despesas"`
+}
+
+// RelatorioCategoria totaliza receitas e despesas de uma categoria, já descontados os estornos.
+// Os valores são expressos em 'Moeda', a moeda base escolhida para o relatório.
+type RelatorioCategoria struct {
+	CategoriaID   string  `json:"categoria_id"`
+	CategoriaNome string  `json:"categoria_nome"`
+	Moeda         string  `json:"moeda"`
+	Receitas      float64 `json:"receitas"`
+	Despesas      float64 `json:"despesas"`
+}
+
+// RelatorioMensal totaliza receitas e despesas de um mês (AAAA-MM), já descontados os estornos,
+// em 'Moeda'.
+type RelatorioMensal struct {
+	Mes      string  `json:"mes"`
+	Moeda    string  `json:"moeda"`
+	Receitas float64 `json:"receitas"`
+	Despesas float64 `json:"despesas"`
+}
+
+// LinhaRelatorio é um subtotal de receitas e despesas de um grupo (categoria ou tag) em uma moeda
+// e um dia, antes da conversão para a moeda base do relatório.
+type LinhaRelatorio struct {
+	GrupoID   string
+	GrupoNome string
+	Moeda     string
+	Dia       time.Time
+	Receitas  float64
+	Despesas  float64
+}
+
+// ClasseTitulo agrupa os títulos nos relatórios de alocação da carteira.
+type ClasseTitulo string
+
+const (
+	ClasseAcao          ClasseTitulo = "ACAO"
+	ClasseFII           ClasseTitulo = "FII"
+	ClasseETF           ClasseTitulo = "ETF"
+	ClasseTesouroDireto ClasseTitulo = "TESOURO_DIRETO"
+	ClasseRendaFixa     ClasseTitulo = "RENDA_FIXA"
+	ClasseFundo         ClasseTitulo = "FUNDO"
+	ClasseOutro         ClasseTitulo = "OUTRO"
+)
+
+// Titulo é um papel negociável da carteira de investimentos, como uma ação ou um título do Tesouro.
+type Titulo struct {
+	ID        string       `json:"id" db:"id"`
+	Codigo    string       `json:"codigo" db:"codigo"`
+	Nome      string       `json:"nome" db:"nome"`
+	Classe    ClasseTitulo `json:"classe" db:"classe"`
+	Moeda     string       `json:"moeda" db:"moeda"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+type TipoOperacaoInvestimento string
+
+const (
+	OperacaoCompra    TipoOperacaoInvestimento = "COMPRA"
+	OperacaoVenda     TipoOperacaoInvestimento = "VENDA"
+	OperacaoDividendo TipoOperacaoInvestimento = "DIVIDENDO"
+	OperacaoJCP       TipoOperacaoInvestimento = "JCP"
+)
+
+// OperacaoInvestimento é uma compra, venda ou provento de um título, liquidada no ativo financeiro
+// indicado por meio da transação 'TransacaoID'. Em proventos, 'Quantidade' e 'PrecoUnitario' são
+// opcionais e 'ValorTotal' é o valor recebido.
+type OperacaoInvestimento struct {
+	ID                string                   `json:"id" db:"id"`
+	TituloID          string                   `json:"titulo_id" db:"titulo_id"`
+	AtivoFinanceiroID string                   `json:"ativo_financeiro_id" db:"ativo_financeiro_id"`
+	Tipo              TipoOperacaoInvestimento `json:"tipo" db:"tipo"`
+	Data              time.Time                `json:"data" db:"data"`
+	Quantidade        float64                  `json:"quantidade" db:"quantidade"`
+	PrecoUnitario     float64                  `json:"preco_unitario" db:"preco_unitario"`
+	// Taxas são corretagem, emolumentos e impostos retidos; somam ao custo da compra e
+	// descontam do valor recebido em vendas e proventos.
+	Taxas       float64   `json:"taxas" db:"taxas"`
+	ValorTotal  float64   `json:"valor_total" db:"valor_total"`
+	TransacaoID string    `json:"transacao_id" db:"transacao_id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// CotacaoTitulo é o preço de mercado de um título em uma data.
+type CotacaoTitulo struct {
+	TituloID string    `json:"titulo_id" db:"titulo_id"`
+	Data     time.Time `json:"data" db:"data"`
+	Preco    float64   `json:"preco" db:"preco"`
+	// Fonte indica como a cotação foi obtida: "MANUAL" ou "ARQUIVO".
+	Fonte string `json:"fonte" db:"fonte"`
+}
+
+// PosicaoInvestimento é a situação atual de um título na carteira, com custo médio e resultados.
+type PosicaoInvestimento struct {
+	Titulo                Titulo     `json:"titulo"`
+	Quantidade            float64    `json:"quantidade"`
+	PrecoMedio            float64    `json:"preco_medio"`
+	CustoTotal            float64    `json:"custo_total"`
+	PrecoAtual            *float64   `json:"preco_atual,omitempty"`
+	DataCotacao           *time.Time `json:"data_cotacao,omitempty"`
+	ValorMercado          float64    `json:"valor_mercado"`
+	ResultadoNaoRealizado float64    `json:"resultado_nao_realizado"`
+	ResultadoRealizado    float64    `json:"resultado_realizado"`
+	Proventos             float64    `json:"proventos"`
+}
+
+// ItemAlocacao é a participação de um grupo (classe ou título) no valor de mercado da carteira.
+type ItemAlocacao struct {
+	Chave      string  `json:"chave"`
+	Valor      float64 `json:"valor"`
+	Percentual float64 `json:"percentual"`
+}
+
+// AlocacaoCarteira distribui o valor de mercado da carteira, convertido para 'Moeda',
+// por classe de título e por título.
+type AlocacaoCarteira struct {
+	Moeda      string         `json:"moeda"`
+	ValorTotal float64        `json:"valor_total"`
+	PorClasse  []ItemAlocacao `json:"por_classe"`
+	PorTitulo  []ItemAlocacao `json:"por_titulo"`
+}
+
+// SistemaAmortizacao define como as parcelas de um financiamento são calculadas.
+type SistemaAmortizacao string
+
+const (
+	// SistemaSAC amortiza o mesmo valor a cada mês; as parcelas diminuem com os juros.
+	SistemaSAC SistemaAmortizacao = "SAC"
+	// SistemaPrice mantém parcelas iguais; a parte de amortização cresce a cada mês.
+	SistemaPrice SistemaAmortizacao = "PRICE"
+)
+
+type StatusFinanciamento string
+
+const (
+	FinanciamentoAtivo   StatusFinanciamento = "ATIVO"
+	FinanciamentoQuitado StatusFinanciamento = "QUITADO"
+)
+
+// Financiamento é o contrato de um empréstimo ou financiamento. A dívida fica no ativo do tipo
+// EMPRESTIMO indicado por 'AtivoFinanceiroID' e as parcelas são pagas pelo ativo 'AtivoPagamentoID'.
+type Financiamento struct {
+	ID                string             `json:"id" db:"id"`
+	AtivoFinanceiroID string             `json:"ativo_financeiro_id" db:"ativo_financeiro_id"`
+	AtivoPagamentoID  string             `json:"ativo_pagamento_id" db:"ativo_pagamento_id"`
+	Sistema           SistemaAmortizacao `json:"sistema" db:"sistema"`
+	// TaxaJurosMensal é a taxa efetiva ao mês, em percentual.
+	TaxaJurosMensal     float64             `json:"taxa_juros_mensal" db:"taxa_juros_mensal"`
+	ValorPrincipal      float64             `json:"valor_principal" db:"valor_principal"`
+	SaldoDevedor        float64             `json:"saldo_devedor" db:"saldo_devedor"`
+	PrazoMeses          int                 `json:"prazo_meses" db:"prazo_meses"`
+	DataPrimeiraParcela time.Time           `json:"data_primeira_parcela" db:"data_primeira_parcela"`
+	Status              StatusFinanciamento `json:"status" db:"status"`
+	CreatedAt           time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at" db:"updated_at"`
+	// Parcelas é o cronograma, preenchido na consulta de um financiamento.
+	Parcelas []ParcelaFinanciamento `json:"parcelas,omitempty" db:"-"`
+}
+
+type StatusParcela string
+
+const (
+	ParcelaPendente StatusParcela = "PENDENTE"
+	ParcelaPaga     StatusParcela = "PAGA"
+)
+
+// ParcelaFinanciamento é uma linha do cronograma: 'Valor' = 'Juros' + 'Amortizacao', e
+// 'SaldoDevedor' é o saldo que resta depois do pagamento.
+type ParcelaFinanciamento struct {
+	ID              string        `json:"id" db:"id"`
+	FinanciamentoID string        `json:"financiamento_id" db:"financiamento_id"`
+	Numero          int           `json:"numero" db:"numero"`
+	DataVencimento  time.Time     `json:"data_vencimento" db:"data_vencimento"`
+	Valor           float64       `json:"valor" db:"valor"`
+	Juros           float64       `json:"juros" db:"juros"`
+	Amortizacao     float64       `json:"amortizacao" db:"amortizacao"`
+	SaldoDevedor    float64       `json:"saldo_devedor" db:"saldo_devedor"`
+	Status          StatusParcela `json:"status" db:"status"`
+	TransacaoID     *string       `json:"transacao_id,omitempty" db:"transacao_id"`
+	PagaEm          *time.Time    `json:"paga_em,omitempty" db:"paga_em"`
+}
+
+// ModoAmortizacaoExtra indica o que uma amortização extraordinária reduz nas parcelas restantes.
+type ModoAmortizacaoExtra string
+
+const (
+	ReduzirPrazo   ModoAmortizacaoExtra = "PRAZO"
+	ReduzirParcela ModoAmortizacaoExtra = "PARCELA"
+)
+
+// AmortizacaoExtra é um pagamento fora do cronograma que abate diretamente o saldo devedor.
+type AmortizacaoExtra struct {
+	ID              string               `json:"id" db:"id"`
+	FinanciamentoID string               `json:"financiamento_id" db:"financiamento_id"`
+	Data            time.Time            `json:"data" db:"data"`
+	Valor           float64              `json:"valor" db:"valor"`
+	Modo            ModoAmortizacaoExtra `json:"modo" db:"modo"`
+	TransacaoID     string               `json:"transacao_id" db:"transacao_id"`
+	CreatedAt       time.Time            `json:"created_at" db:"created_at"`
+}
+
+// Meta é um objetivo de poupança com valor e data alvo. O progresso vem do saldo dos ativos
+// vinculados ('AtivoIDs') ou, alternativamente, da soma das transações marcadas com 'Tag'.
+type Meta struct {
+	ID        string    `json:"id" db:"id"`
+	Nome      string    `json:"nome" db:"nome"`
+	ValorAlvo float64   `json:"valor_alvo" db:"valor_alvo"`
+	DataAlvo  time.Time `json:"data_alvo" db:"data_alvo"`
+	Moeda     string    `json:"moeda" db:"moeda"`
+	Tag       *string   `json:"tag,omitempty" db:"tag"`
+	AtivoIDs  []string  `json:"ativo_ids,omitempty" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	// Progresso é calculado na consulta, nunca gravado.
+	Progresso *ProgressoMeta `json:"progresso,omitempty" db:"-"`
+}
+
+// ProgressoMeta resume a situação de uma meta na data da consulta, em valores da moeda da meta.
+type ProgressoMeta struct {
+	ValorAtual    float64 `json:"valor_atual"`
+	Percentual    float64 `json:"percentual"`
+	ValorFaltante float64 `json:"valor_faltante"`
+	// MesesRestantes conta os meses até a data alvo; zero quando ela já passou.
+	MesesRestantes         int     `json:"meses_restantes"`
+	AporteMensalNecessario float64 `json:"aporte_mensal_necessario"`
+	// RitmoMensal é a média mensal dos aportes nos últimos meses analisados.
+	RitmoMensal float64 `json:"ritmo_mensal"`
+	// DataProjetada é quando a meta seria atingida mantido o ritmo; ausente sem ritmo positivo.
+	DataProjetada *time.Time `json:"data_projetada,omitempty"`
+	EmRisco       bool       `json:"em_risco"`
+}
+
+// TipoEventoNotificacao identifica o que gerou uma notificação; as preferências são por tipo.
+type TipoEventoNotificacao string
+
+const (
+	// EventoRecorrenciaProxima avisa com antecedência que uma transação recorrente vai vencer.
+	EventoRecorrenciaProxima TipoEventoNotificacao = "RECORRENCIA_PROXIMA"
+	// EventoRecorrenciaFalhou avisa que o processamento de recorrências teve falhas.
+	EventoRecorrenciaFalhou TipoEventoNotificacao = "RECORRENCIA_FALHOU"
+	// EventoSaldoBaixo avisa que o saldo de um ativo ficou abaixo do limite da preferência.
+	EventoSaldoBaixo TipoEventoNotificacao = "SALDO_BAIXO"
+	// EventoOrcamentoLimite avisa que as despesas do mês atingiram o alerta ou o total do orçamento.
+	EventoOrcamentoLimite TipoEventoNotificacao = "ORCAMENTO_LIMITE"
+)
+
+// EventosNotificacao lista todos os tipos de evento, na ordem em que as preferências são exibidas.
+var EventosNotificacao = []TipoEventoNotificacao{EventoRecorrenciaProxima, EventoRecorrenciaFalhou, EventoSaldoBaixo, EventoOrcamentoLimite}
+
+// CanalNotificacao é um meio de entrega. INAPP grava na caixa de entrada consultada pela API.
+type CanalNotificacao string
+
+const (
+	CanalInApp   CanalNotificacao = "INAPP"
+	CanalEmail   CanalNotificacao = "EMAIL"
+	CanalWebhook CanalNotificacao = "WEBHOOK"
+)
+
+// PreferenciaNotificacao configura um tipo de evento. Sem preferência gravada, o evento é
+// ativo, entregue só na caixa de entrada, com 3 dias de antecedência e limite zero.
+type PreferenciaNotificacao struct {
+	Evento TipoEventoNotificacao `json:"evento" db:"evento"`
+	Ativo  bool                  `json:"ativo" db:"ativo"`
+	Canais []CanalNotificacao    `json:"canais" db:"canais"`
+	// AntecedenciaDias vale para RECORRENCIA_PROXIMA.
+	AntecedenciaDias int `json:"antecedencia_dias" db:"antecedencia_dias"`
+	// Limite vale para SALDO_BAIXO e é expresso na moeda base.
+	Limite    float64   `json:"limite" db:"limite"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Notificacao é um evento registrado e entregue. 'Chave' identifica a ocorrência para que o
+// mesmo evento não seja notificado duas vezes.
+type Notificacao struct {
+	ID        string                `json:"id" db:"id"`
+	Evento    TipoEventoNotificacao `json:"evento" db:"evento"`
+	Chave     string                `json:"-" db:"chave"`
+	Titulo    string                `json:"titulo" db:"titulo"`
+	Mensagem  string                `json:"mensagem" db:"mensagem"`
+	Canais    []CanalNotificacao    `json:"canais" db:"canais"`
+	LidaEm    *time.Time            `json:"lida_em,omitempty" db:"lida_em"`
+	CreatedAt time.Time             `json:"created_at" db:"created_at"`
+}
+
+// Orcamento é o limite mensal de despesas de uma categoria, na moeda base. Ao atingir
+// 'PercentualAlerta' do valor, e novamente ao atingir o total, é gerado um ORCAMENTO_LIMITE.
+type Orcamento struct {
+	CategoriaID      string    `json:"categoria_id" db:"categoria_id"`
+	ValorMensal      float64   `json:"valor_mensal" db:"valor_mensal"`
+	PercentualAlerta float64   `json:"percentual_alerta" db:"percentual_alerta"`
 	CreatedAt        time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 }
 
-type Transacao struct {
-	ID                string        `json:"id" db:"id"`
-	AtivoFinanceiroID string        `json:"ativo_financeiro_id" db:"ativo_financeiro_id"`
-	CategoriaID       string        `json:"categoria_id" db:"categoria_id"`
-	Descricao         string        `json:"descricao" db:"descricao"`
-	Valor             float64       `json:"valor" db:"valor"`
-	Tipo              TipoTransacao `json:"tipo" db:"tipo"`
-	ReversalOf        *string       `json:"reversal_of,omitempty" db:"reversal_of"`
-	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
+// TipoEventoWebhook é um evento do livro-caixa publicado para as assinaturas de webhook.
+type TipoEventoWebhook string
+
+const (
+	WebhookTransacaoCriada       TipoEventoWebhook = "transacao.criada"
+	WebhookTransacaoEstornada    TipoEventoWebhook = "transacao.estornada"
+	WebhookAtivoDesativado       TipoEventoWebhook = "ativo.desativado"
+	WebhookRecorrenciaProcessada TipoEventoWebhook = "recorrencia.processada"
+	// WebhookTodosEventos, em uma assinatura, recebe todos os tipos de evento.
+	WebhookTodosEventos TipoEventoWebhook = "*"
+)
+
+// EventosWebhook lista os tipos de evento que podem ser assinados.
+var EventosWebhook = []TipoEventoWebhook{WebhookTransacaoCriada, WebhookTransacaoEstornada, WebhookAtivoDesativado, WebhookRecorrenciaProcessada}
+
+// EventoOutbox é um evento gravado na mesma transação de banco da alteração que o originou; só
+// eventos de alterações confirmadas chegam a ser distribuídos às assinaturas.
+type EventoOutbox struct {
+	ID            string            `json:"id" db:"id"`
+	Tipo          TipoEventoWebhook `json:"tipo" db:"tipo"`
+	Dados         json.RawMessage   `json:"dados" db:"dados"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+	DistribuidoEm *time.Time        `json:"-" db:"distribuido_em"`
+}
+
+// WebhookAssinatura recebe, por POST na URL, os eventos listados. 'Segredo' assina o corpo
+// em HMAC-SHA256 e só é exibido na criação.
+type WebhookAssinatura struct {
+	ID        string              `json:"id" db:"id"`
+	URL       string              `json:"url" db:"url"`
+	Segredo   string              `json:"segredo,omitempty" db:"segredo"`
+	Eventos   []TipoEventoWebhook `json:"eventos" db:"eventos"`
+	Ativo     bool                `json:"ativo" db:"ativo"`
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+type StatusEntregaWebhook string
+
+const (
+	EntregaPendente StatusEntregaWebhook = "PENDENTE"
+	EntregaEntregue StatusEntregaWebhook = "ENTREGUE"
+	// EntregaFalhou indica que as tentativas se esgotaram; a entrega pode ser reenviada manualmente.
+	EntregaFalhou StatusEntregaWebhook = "FALHOU"
+)
+
+// EntregaWebhook registra o envio de um evento a uma assinatura e suas tentativas.
+type EntregaWebhook struct {
+	ID                 string               `json:"id" db:"id"`
+	AssinaturaID       string               `json:"assinatura_id" db:"assinatura_id"`
+	EventoID           string               `json:"evento_id" db:"evento_id"`
+	Evento             TipoEventoWebhook    `json:"evento" db:"-"`
+	Status             StatusEntregaWebhook `json:"status" db:"status"`
+	Tentativas         int                  `json:"tentativas" db:"tentativas"`
+	ProximaTentativaEm *time.Time           `json:"proxima_tentativa_em,omitempty" db:"proxima_tentativa_em"`
+	UltimoStatusHTTP   *int                 `json:"ultimo_status_http,omitempty" db:"ultimo_status_http"`
+	UltimoErro         *string              `json:"ultimo_erro,omitempty" db:"ultimo_erro"`
+	EntregueEm         *time.Time           `json:"entregue_em,omitempty" db:"entregue_em"`
+	CreatedAt          time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// EnvioWebhook reúne o que o worker precisa para tentar uma entrega; não é exposto pela API.
+type EnvioWebhook struct {
+	Entrega EntregaWebhook
+	URL     string
+	Segredo string
+	Evento  EventoOutbox
+}
+
+// FormatoBackup identifica os arquivos de backup; VersaoBackup muda sempre que o conteúdo muda
+// de forma incompatível.
+const (
+	FormatoBackup = "controlador-backup"
+	VersaoBackup  = 1
+)
+
+// ArquivoBackup é o envelope do backup. 'Dados' traz, por tabela, as linhas como objetos JSON;
+// 'Checksum' é o SHA-256 dos bytes de 'Dados', no formato "sha256:<hex>".
+type ArquivoBackup struct {
+	Formato  string          `json:"formato"`
+	Versao   int             `json:"versao"`
+	GeradoEm time.Time       `json:"gerado_em"`
+	Dados    json.RawMessage `json:"dados"`
+	Checksum string          `json:"checksum"`
+}
+
+type ModoRestauracao string
+
+const (
+	// RestauracaoSubstituir apaga os dados atuais e carrega o backup com os IDs originais.
+	RestauracaoSubstituir ModoRestauracao = "substituir"
+	// RestauracaoMesclar mantém os dados atuais e carrega o backup com IDs novos; categorias,
+	// tags e títulos já existentes com o mesmo nome ou código são reaproveitados.
+	RestauracaoMesclar ModoRestauracao = "mesclar"
+)
+
+// ResumoRestauracao informa quantas linhas de cada tabela foram carregadas.
+type ResumoRestauracao struct {
+	Modo         ModoRestauracao `json:"modo"`
+	Tabelas      map[string]int  `json:"tabelas"`
+	Reutilizados int             `json:"reutilizados"`
+	Ignorados    int             `json:"ignorados"`
+}
+
+// TipoEventoStream é um evento enviado em tempo real aos clientes conectados ao stream.
+type TipoEventoStream string
+
+const (
+	StreamSaldoAlterado   TipoEventoStream = "ativo.saldo_alterado"
+	StreamTransacaoCriada TipoEventoStream = "transacao.criada"
+)
+
+// EventosStream lista os tipos de evento que podem ser filtrados no stream.
+var EventosStream = []TipoEventoStream{StreamSaldoAlterado, StreamTransacaoCriada}
+
+// EventoStream é gravado pelo próprio banco, por gatilhos, na transação que alterou o saldo ou
+// criou a transação. O ID cresce na ordem de confirmação e serve de Last-Event-ID para retomar o stream.
+type EventoStream struct {
+	ID                int64            `json:"id" db:"id"`
+	Tipo              TipoEventoStream `json:"tipo" db:"tipo"`
+	AtivoFinanceiroID string           `json:"ativo_financeiro_id" db:"ativo_financeiro_id"`
+	Dados             json.RawMessage  `json:"dados" db:"dados"`
+	CreatedAt         time.Time        `json:"created_at" db:"created_at"`
+}
+
+// TaxaCambio é a cotação de 1 unidade de MoedaOrigem em MoedaDestino em uma data.
+type TaxaCambio struct {
+	ID           string    `json:"id" db:"id"`
+	MoedaOrigem  string    `json:"moeda_origem" db:"moeda_origem"`
+	MoedaDestino string    `json:"moeda_destino" db:"moeda_destino"`
+	Data         time.Time `json:"data" db:"data"`
+	Taxa         float64   `json:"taxa" db:"taxa"`
+	// Fonte indica a origem da cotação: "MANUAL" para as cadastradas pela API.
+	Fonte     string    `json:"fonte" db:"fonte"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Transferencia liga o débito no ativo de origem ao recebimento no ativo de destino,
+// registrando a cotação usada quando as moedas são diferentes.
+type Transferencia struct {
+	ID                 string     `json:"id" db:"id"`
+	TransacaoOrigemID  string     `json:"transacao_origem_id" db:"transacao_origem_id"`
+	TransacaoDestinoID string     `json:"transacao_destino_id" db:"transacao_destino_id"`
+	MoedaOrigem        string     `json:"moeda_origem" db:"moeda_origem"`
+	MoedaDestino       string     `json:"moeda_destino" db:"moeda_destino"`
+	ValorOrigem        float64    `json:"valor_origem" db:"valor_origem"`
+	ValorDestino       float64    `json:"valor_destino" db:"valor_destino"`
+	Taxa               float64    `json:"taxa" db:"taxa"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	Origem             *Transacao `json:"origem,omitempty" db:"-"`
+	Destino            *Transacao `json:"destino,omitempty" db:"-"`
+}
+
+// ValorEstornavel retorna quanto da transação ainda pode ser estornado.
+func (t Transacao) ValorEstornavel() float64 {
+	if t.ReversalOf != nil || t.Tipo == TransacaoSaldoInicial {
+		return 0
+	}
+	return t.Valor - t.ValorEstornado
+}
+
+// ChequeEspecialUtilizado retorna quanto do cheque especial está em uso (o saldo negativo).
+func (a AtivoFinanceiro) ChequeEspecialUtilizado() float64 {
+	if a.SaldoAtual >= 0 {
+		return 0
+	}
+	return -a.SaldoAtual
+}
+
+// MarshalJSON inclui na representação do ativo o uso do cheque especial, quando houver limite.
+func (a AtivoFinanceiro) MarshalJSON() ([]byte, error) {
+	type alias AtivoFinanceiro
+	uso := struct {
+		alias
+		ChequeEspecialUtilizado  *float64 `json:"cheque_especial_utilizado,omitempty"`
+		ChequeEspecialDisponivel *float64 `json:"cheque_especial_disponivel,omitempty"`
+		PercentualChequeEspecial *float64 `json:"percentual_cheque_especial_utilizado,omitempty"`
+	}{alias: alias(a)}
+	if a.LimiteChequeEspecial > 0 {
+		utilizado := a.ChequeEspecialUtilizado()
+		disponivel := a.LimiteChequeEspecial - utilizado
+		percentual := math.Round(utilizado/a.LimiteChequeEspecial*10000) / 100
+		uso.ChequeEspecialUtilizado = &utilizado
+		uso.ChequeEspecialDisponivel = &disponivel
+		uso.PercentualChequeEspecial = &percentual
+	}
+	return json.Marshal(uso)
+}
+
+// MarshalJSON inclui o valor ainda estornável na representação da transação.
+func (t Transacao) MarshalJSON() ([]byte, error) {
+	type alias Transacao
+	return json.Marshal(struct {
+		alias
+		ValorEstornavel float64 `json:"valor_estornavel"`
+	}{alias(t), t.ValorEstornavel()})
+}
+
+// RecalculoSaldo compara o saldo e o limite gravados no ativo com os recalculados a partir
+// das transações efetivadas.
+type RecalculoSaldo struct {
+	Ativo             *AtivoFinanceiro `json:"ativo"`
+	SaldoAnterior     float64          `json:"saldo_anterior"`
+	SaldoRecalculado  float64          `json:"saldo_recalculado"`
+	LimiteAnterior    float64          `json:"limite_disponivel_anterior"`
+	LimiteRecalculado float64          `json:"limite_disponivel_recalculado"`
+	Divergente        bool             `json:"divergente"`
+}
+
+// EfeitoSaldo representa a variação aplicada ao saldo e ao limite de um ativo.
+type EfeitoSaldo struct {
+	Saldo  float64
+	Limite float64
+}
+
+// Aplicar soma o efeito ao saldo e ao limite do ativo em memória, sem persistir.
+func (a *AtivoFinanceiro) Aplicar(e EfeitoSaldo) {
+	a.SaldoAtual += e.Saldo
+	a.LimiteDisponivel += e.Limite
+}
+
+// Inverso retorna o efeito que desfaz o efeito atual.
+func (e EfeitoSaldo) Inverso() EfeitoSaldo {
+	return EfeitoSaldo{Saldo: -e.Saldo, Limite: -e.Limite}
+}
+
+type OperacaoHistorico string
+
+const (
+	HistoricoAtualizacao OperacaoHistorico = "ATUALIZACAO"
+	HistoricoExclusao    OperacaoHistorico = "EXCLUSAO"
+)
+
+// TransacaoHistorico guarda uma versão anterior de uma transação editada ou excluída.
+type TransacaoHistorico struct {
+	ID          string            `json:"id" db:"id"`
+	TransacaoID string            `json:"transacao_id" db:"transacao_id"`
+	Versao      int               `json:"versao" db:"versao"`
+	Operacao    OperacaoHistorico `json:"operacao" db:"operacao"`
+	Dados       Transacao         `json:"dados" db:"dados"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+}
+
+// AlteracaoStatusAtivo é o resultado da desativação ou reativação de um ativo, com as
+// recorrências pausadas ou retomadas junto com ele.
+type AlteracaoStatusAtivo struct {
+	Ativo                 *AtivoFinanceiro `json:"ativo"`
+	RecorrenciasPausadas  []string         `json:"recorrencias_pausadas,omitempty"`
+	RecorrenciasRetomadas []string         `json:"recorrencias_retomadas,omitempty"`
 }
 
 type TransacaoRecorrente struct {
@@ -60,8 +866,12 @@ type TransacaoRecorrente struct {
 	Tipo              TipoTransacao `json:"tipo" db:"tipo"`
 	DiaDoVencimento   int           `json:"dia_do_vencimento" db:"dia_do_vencimento"`
 	Ativa             bool          `json:"ativa" db:"ativa"`
-	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time     `json:"updated_at" db:"updated_at"`
+	// PausadaPorDesativacao indica que a recorrência foi pausada junto com o ativo e será
+	// retomada quando ele for reativado.
+	PausadaPorDesativacao bool      `json:"pausada_por_desativacao" db:"pausada_por_desativacao"`
+	Tags                  []string  `json:"tags,omitempty" db:"-"`
+	CreatedAt             time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at" db:"updated_at"`
 }
 
 func (t *TipoAtivo) UnmarshalJSON(b []byte) error {
@@ -70,11 +880,11 @@ func (t *TipoAtivo) UnmarshalJSON(b []byte) error {
 		return err
 	}
 	switch TipoAtivo(s) {
-	case AtivoContaCorrente, AtivoCartaoCredito:
+	case AtivoContaCorrente, AtivoCartaoCredito, AtivoPoupanca, AtivoDinheiro, AtivoInvestimento, AtivoValeRefeicao, AtivoEmprestimo:
 		*t = TipoAtivo(s)
 		return nil
 	default:
-		return fmt.Errorf("tipo de ativo inválido: %s", s)
+		return erros.Invalido("tipo_ativo_desconhecido", fmt.Sprintf("tipo de ativo inválido: %s", s))
 	}
 }
 
@@ -88,6 +898,145 @@ func (t *TipoTransacao) UnmarshalJSON(b []byte) error {
 		*t = TipoTransacao(s)
 		return nil
 	default:
-		return fmt.Errorf("tipo de transação inválido: %s", s)
+		return erros.Invalido("tipo_transacao_desconhecido", fmt.Sprintf("tipo de transação inválido: %s", s))
+	}
+}
+
+// MoedaPadrao é a moeda assumida quando um ativo é criado sem moeda.
+const MoedaPadrao = "BRL"
+
+// ParseMoeda normaliza um código de moeda ISO 4217 (três letras), rejeitando formatos inválidos.
+func ParseMoeda(v string) (string, error) {
+	moeda := strings.ToUpper(strings.TrimSpace(v))
+	if len(moeda) != 3 {
+		return "", erros.Invalido("moeda_invalida", fmt.Sprintf("moeda inválida: %q", v))
+	}
+	for _, r := range moeda {
+		if r < 'A' || r > 'Z' {
+			return "", erros.Invalido("moeda_invalida", fmt.Sprintf("moeda inválida: %q", v))
+		}
+	}
+	return moeda, nil
+}
+
+// ParseClasseTitulo converte um texto em ClasseTitulo, rejeitando valores desconhecidos.
+func ParseClasseTitulo(v string) (ClasseTitulo, error) {
+	switch ClasseTitulo(v) {
+	case ClasseAcao, ClasseFII, ClasseETF, ClasseTesouroDireto, ClasseRendaFixa, ClasseFundo, ClasseOutro:
+		return ClasseTitulo(v), nil
+	default:
+		return "", erros.Invalido("classe_titulo_desconhecida", fmt.Sprintf("classe de título inválida: %s", v))
+	}
+}
+
+func (c *ClasseTitulo) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	classe, err := ParseClasseTitulo(v)
+	if err != nil {
+		return err
+	}
+	*c = classe
+	return nil
+}
+
+func (t *TipoOperacaoInvestimento) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch TipoOperacaoInvestimento(v) {
+	case OperacaoCompra, OperacaoVenda, OperacaoDividendo, OperacaoJCP:
+		*t = TipoOperacaoInvestimento(v)
+		return nil
+	default:
+		return erros.Invalido("tipo_operacao_investimento_desconhecido", fmt.Sprintf("tipo de operação de investimento inválido: %s", v))
+	}
+}
+
+func (s *SistemaAmortizacao) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch SistemaAmortizacao(v) {
+	case SistemaSAC, SistemaPrice:
+		*s = SistemaAmortizacao(v)
+		return nil
+	default:
+		return erros.Invalido("sistema_amortizacao_desconhecido", fmt.Sprintf("sistema de amortização inválido: %s", v))
+	}
+}
+
+func (m *ModoAmortizacaoExtra) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch ModoAmortizacaoExtra(v) {
+	case ReduzirPrazo, ReduzirParcela:
+		*m = ModoAmortizacaoExtra(v)
+		return nil
+	default:
+		return erros.Invalido("modo_amortizacao_desconhecido", fmt.Sprintf("modo de amortização inválido: %s", v))
+	}
+}
+
+// ParseStatusTransacao converte um texto em StatusTransacao, rejeitando valores desconhecidos.
+func ParseStatusTransacao(v string) (StatusTransacao, error) {
+	switch StatusTransacao(v) {
+	case StatusAgendada, StatusPendente, StatusEfetivada, StatusCancelada:
+		return StatusTransacao(v), nil
+	default:
+		return "", erros.Invalido("status_transacao_desconhecido", fmt.Sprintf("status de transação inválido: %s", v))
+	}
+}
+
+func (s *StatusTransacao) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	status, err := ParseStatusTransacao(v)
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
+
+// ParseTipoEventoNotificacao converte um texto em TipoEventoNotificacao, rejeitando valores desconhecidos.
+func ParseTipoEventoNotificacao(v string) (TipoEventoNotificacao, error) {
+	for _, evento := range EventosNotificacao {
+		if TipoEventoNotificacao(v) == evento {
+			return evento, nil
+		}
+	}
+	return "", erros.Invalido("evento_notificacao_desconhecido", fmt.Sprintf("tipo de evento de notificação inválido: %s", v))
+}
+
+// ParseTipoEventoStream converte um texto em TipoEventoStream, rejeitando valores desconhecidos.
+func ParseTipoEventoStream(v string) (TipoEventoStream, error) {
+	for _, evento := range EventosStream {
+		if TipoEventoStream(v) == evento {
+			return evento, nil
+		}
+	}
+	return "", erros.Invalido("evento_stream_desconhecido", fmt.Sprintf("tipo de evento de stream inválido: %s", v))
+}
+
+func (c *CanalNotificacao) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch CanalNotificacao(v) {
+	case CanalInApp, CanalEmail, CanalWebhook:
+		*c = CanalNotificacao(v)
+		return nil
+	default:
+		return erros.Invalido("canal_notificacao_desconhecido", fmt.Sprintf("canal de notificação inválido: %s", v))
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,132 @@
+package notificacao
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"controlador/backend/internal/models"
+)
+
+// EmailConfig configura o envio por SMTP. Sem 'Usuario' o envio é feito sem autenticação,
+// como esperam capturadores de e-mail locais (MailHog, Mailpit).
+type EmailConfig struct {
+	Host         string
+	Porta        int
+	Usuario      string
+	Senha        string
+	Remetente    string
+	Destinatario string
+}
+
+// timeoutSMTP limita a conexão e o diálogo com o servidor SMTP, mesmo sem prazo no contexto.
+const timeoutSMTP = 30 * time.Second
+
+// CanalEmail envia cada notificação como um e-mail de texto simples.
+type CanalEmail struct {
+	cfg EmailConfig
+}
+
+func NewCanalEmail(cfg EmailConfig) (*CanalEmail, error) {
+	if cfg.Destinatario == "" {
+		return nil, errors.New("NOTIFICACAO_EMAIL_DESTINATARIO é obrigatório quando o e-mail está configurado")
+	}
+	return &CanalEmail{cfg: cfg}, nil
+}
+
+func (c *CanalEmail) Nome() models.CanalNotificacao { return models.CanalEmail }
+
+func (c *CanalEmail) Enviar(ctx context.Context, n models.Notificacao) error {
+	var auth smtp.Auth
+	if c.cfg.Usuario != "" {
+		auth = smtp.PlainAuth("", c.cfg.Usuario, c.cfg.Senha, c.cfg.Host)
+	}
+
+	var corpo strings.Builder
+	fmt.Fprintf(&corpo, "From: %s\r\n", c.cfg.Remetente)
+	fmt.Fprintf(&corpo, "To: %s\r\n", c.cfg.Destinatario)
+	fmt.Fprintf(&corpo, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Titulo))
+	fmt.Fprintf(&corpo, "Date: %s\r\n", n.CreatedAt.Format(time.RFC1123Z))
+	corpo.WriteString("MIME-Version: 1.0\r\n")
+	corpo.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	corpo.WriteString("\r\n")
+	corpo.WriteString(strings.ReplaceAll(n.Mensagem, "\n", "\r\n"))
+	corpo.WriteString("\r\n")
+
+	return c.enviarSMTP(ctx, auth, []byte(corpo.String()))
+}
+
+// enviarSMTP faz o diálogo SMTP de smtp.SendMail sobre uma conexão aberta com o contexto: o envio
+// é interrompido pelo cancelamento de 'ctx' e nunca dura mais que timeoutSMTP.
+func (c *CanalEmail) enviarSMTP(ctx context.Context, auth smtp.Auth, mensagem []byte) error {
+	endereco := net.JoinHostPort(c.cfg.Host, strconv.Itoa(c.cfg.Porta))
+	dialer := net.Dialer{Timeout: timeoutSMTP}
+	conn, err := dialer.DialContext(ctx, "tcp", endereco)
+	if err != nil {
+		return err
+	}
+	prazo := time.Now().Add(timeoutSMTP)
+	if limite, ok := ctx.Deadline(); ok && limite.Before(prazo) {
+		prazo = limite
+	}
+	if err := conn.SetDeadline(prazo); err != nil {
+		conn.Close()
+		return err
+	}
+	// Fechar a conexão destrava qualquer leitura ou escrita pendente quando o contexto é cancelado.
+	parar := context.AfterFunc(ctx, func() { conn.Close() })
+	defer parar()
+
+	err = c.dialogoSMTP(conn, auth, mensagem)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (c *CanalEmail) dialogoSMTP(conn net.Conn, auth smtp.Auth, mensagem []byte) error {
+	cliente, err := smtp.NewClient(conn, c.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer cliente.Close()
+
+	if ok, _ := cliente.Extension("STARTTLS"); ok {
+		if err := cliente.StartTLS(&tls.Config{ServerName: c.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := cliente.Extension("AUTH"); !ok {
+			return errors.New("smtp: o servidor não aceita AUTH")
+		}
+		if err := cliente.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := cliente.Mail(c.cfg.Remetente); err != nil {
+		return err
+	}
+	if err := cliente.Rcpt(c.cfg.Destinatario); err != nil {
+		return err
+	}
+	w, err := cliente.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(mensagem); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return cliente.Quit()
+}
@@ -0,0 +1,51 @@
+// Package notificacao entrega notificações por canais externos à aplicação.
+package notificacao
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"controlador/backend/internal/config"
+	"controlador/backend/internal/models"
+)
+
+// Canal abstrai um meio de entrega de notificações. A caixa de entrada (INAPP) não é um Canal:
+// ela é o próprio registro da notificação no banco.
+type Canal interface {
+	Nome() models.CanalNotificacao
+	Enviar(ctx context.Context, n models.Notificacao) error
+}
+
+// NewFromEnv cria os canais configurados. O e-mail é ativado por NOTIFICACAO_SMTP_HOST e o
+// webhook por NOTIFICACAO_WEBHOOK_URL; canais sem configuração ficam de fora do mapa.
+func NewFromEnv() (map[models.CanalNotificacao]Canal, error) {
+	canais := make(map[models.CanalNotificacao]Canal)
+
+	if host := os.Getenv("NOTIFICACAO_SMTP_HOST"); host != "" {
+		porta, err := strconv.Atoi(config.Getenv("NOTIFICACAO_SMTP_PORTA", "1025"))
+		if err != nil {
+			return nil, fmt.Errorf("NOTIFICACAO_SMTP_PORTA inválida: %w", err)
+		}
+		email, err := NewCanalEmail(EmailConfig{
+			Host:         host,
+			Porta:        porta,
+			Usuario:      os.Getenv("NOTIFICACAO_SMTP_USUARIO"),
+			Senha:        os.Getenv("NOTIFICACAO_SMTP_SENHA"),
+			Remetente:    config.Getenv("NOTIFICACAO_EMAIL_REMETENTE", "controlador@localhost"),
+			Destinatario: os.Getenv("NOTIFICACAO_EMAIL_DESTINATARIO"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		canais[email.Nome()] = email
+	}
+
+	if url := os.Getenv("NOTIFICACAO_WEBHOOK_URL"); url != "" {
+		webhook := NewCanalWebhook(url, os.Getenv("NOTIFICACAO_WEBHOOK_SEGREDO"))
+		canais[webhook.Nome()] = webhook
+	}
+
+	return canais, nil
+}
@@ -0,0 +1,34 @@
+package notificacao
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/webhook"
+)
+
+// CanalWebhook publica a notificação em JSON via POST. Com um segredo configurado, o timestamp
+// (X-Controlador-Timestamp) e o corpo são assinados em HMAC-SHA256 no cabeçalho
+// X-Controlador-Assinatura ("sha256=<hex>"), como em webhook.Assinar.
+type CanalWebhook struct {
+	url     string
+	segredo string
+	cliente *webhook.Cliente
+}
+
+func NewCanalWebhook(url, segredo string) *CanalWebhook {
+	return &CanalWebhook{url: url, segredo: segredo, cliente: webhook.NewCliente(10 * time.Second)}
+}
+
+func (c *CanalWebhook) Nome() models.CanalNotificacao { return models.CanalWebhook }
+
+func (c *CanalWebhook) Enviar(ctx context.Context, n models.Notificacao) error {
+	corpo, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	_, err = c.cliente.Enviar(ctx, c.url, c.segredo, map[string]string{"X-Controlador-Evento": string(n.Evento)}, corpo)
+	return err
+}
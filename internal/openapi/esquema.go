@@ -0,0 +1,191 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	tipoTempo = reflect.TypeOf(time.Time{})
+	tipoJSON  = reflect.TypeOf(json.RawMessage{})
+)
+
+// camposSomenteLeitura são gerados pelo servidor; aparecem nas respostas e são ignorados nos corpos.
+var camposSomenteLeitura = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// gerador converte tipos Go em esquemas JSON Schema seguindo as regras do encoding/json.
+// Structs nomeadas viram componentes em '#/components/schemas'.
+type gerador struct {
+	d        *Documento
+	esquemas map[string]any
+	nomes    map[reflect.Type]string
+}
+
+func (g *gerador) esquema(t reflect.Type) map[string]any {
+	switch t {
+	case tipoTempo:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case tipoJSON:
+		return map[string]any{}
+	}
+	if valores, ok := g.d.enums[t]; ok {
+		return map[string]any{"type": "string", "enum": valores}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return anulavel(g.esquema(t.Elem()))
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": g.esquema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.esquema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.objeto(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + g.componente(t)}
+	}
+	return map[string]any{}
+}
+
+// componente registra a struct entre os componentes e retorna o nome dela. Tipos de pacotes
+// diferentes com o mesmo nome recebem o pacote como prefixo.
+func (g *gerador) componente(t reflect.Type) string {
+	if nome, ok := g.nomes[t]; ok {
+		return nome
+	}
+	nome := exportado(t.Name())
+	if _, ocupado := g.esquemas[nome]; ocupado {
+		pacote := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		nome = exportado(pacote) + nome
+	}
+	g.nomes[t] = nome
+	// Reserva o nome antes de descer nos campos, para tipos que se referenciam.
+	g.esquemas[nome] = nil
+	g.esquemas[nome] = g.objeto(t)
+	return nome
+}
+
+func (g *gerador) objeto(t reflect.Type) map[string]any {
+	propriedades := make(map[string]any)
+	var obrigatorios []string
+	g.campos(t, propriedades, &obrigatorios)
+	for nome, tipo := range g.d.extras[t] {
+		propriedades[nome] = g.esquema(tipo)
+	}
+
+	esquema := map[string]any{"type": "object", "properties": propriedades}
+	if len(obrigatorios) > 0 {
+		sort.Strings(obrigatorios)
+		esquema["required"] = obrigatorios
+	}
+	return esquema
+}
+
+// campos segue a regra do encoding/json: campos declarados na própria struct prevalecem sobre
+// os de mesmo nome vindos de structs embutidas.
+func (g *gerador) campos(t reflect.Type, propriedades map[string]any, obrigatorios *[]string) {
+	var embutidos []reflect.Type
+	for i := 0; i < t.NumField(); i++ {
+		campo := t.Field(i)
+		tagJSON := campo.Tag.Get("json")
+		if tagJSON == "-" {
+			continue
+		}
+		nome, opcoes, _ := strings.Cut(tagJSON, ",")
+		if campo.Anonymous && nome == "" {
+			tipo := campo.Type
+			if tipo.Kind() == reflect.Pointer {
+				tipo = tipo.Elem()
+			}
+			if tipo.Kind() == reflect.Struct {
+				embutidos = append(embutidos, tipo)
+				continue
+			}
+		}
+		if !campo.IsExported() {
+			continue
+		}
+		if nome == "" {
+			nome = campo.Name
+		}
+
+		esquema := g.esquema(campo.Type)
+		if opcoes == "string" {
+			esquema = map[string]any{"type": "string"}
+		}
+		if camposSomenteLeitura[nome] {
+			esquema["readOnly"] = true
+		}
+		propriedades[nome] = esquema
+		for _, regra := range strings.Split(campo.Tag.Get("binding"), ",") {
+			if regra == "required" {
+				*obrigatorios = append(*obrigatorios, nome)
+			}
+		}
+	}
+
+	for _, tipo := range embutidos {
+		internas := make(map[string]any)
+		var obrigatoriosInternos []string
+		g.campos(tipo, internas, &obrigatoriosInternos)
+		for nome, esquema := range internas {
+			if _, ok := propriedades[nome]; !ok {
+				propriedades[nome] = esquema
+			}
+		}
+		for _, nome := range obrigatoriosInternos {
+			if !contem(*obrigatorios, nome) {
+				*obrigatorios = append(*obrigatorios, nome)
+			}
+		}
+	}
+}
+
+// anulavel aceita também null no lugar do valor.
+func anulavel(esquema map[string]any) map[string]any {
+	if tipo, ok := esquema["type"].(string); ok {
+		copia := make(map[string]any, len(esquema))
+		for k, v := range esquema {
+			copia[k] = v
+		}
+		copia["type"] = []string{tipo, "null"}
+		return copia
+	}
+	return map[string]any{"anyOf": []any{esquema, map[string]any{"type": "null"}}}
+}
+
+func exportado(nome string) string {
+	if nome == "" {
+		return nome
+	}
+	r := []rune(nome)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func contem(lista []string, valor string) bool {
+	for _, v := range lista {
+		if v == valor {
+			return true
+		}
+	}
+	return false
+}
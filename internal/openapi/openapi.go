@@ -0,0 +1,302 @@
+// Package openapi monta a especificação OpenAPI 3.1 da API a partir das operações declaradas
+// junto aos handlers. Os esquemas de corpo e de resposta são gerados por reflexão dos próprios
+// tipos Go que os handlers recebem e devolvem, então acompanham qualquer mudança nesses tipos.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrDocumentacaoDesatualizada = errors.New("a documentação OpenAPI não confere com as rotas registradas")
+
+// Parametro é um parâmetro de query ou de cabeçalho. Os parâmetros de caminho saem do próprio
+// caminho da operação.
+type Parametro struct {
+	Nome      string
+	Em        string // "query" ou "header"
+	Descricao string
+	// Tipo é "string" (padrão), "integer" ou "boolean".
+	Tipo        string
+	Formato     string
+	Enum        []string
+	Obrigatorio bool
+}
+
+// Operacao descreve uma rota da API.
+type Operacao struct {
+	Metodo string
+	// Caminho usa a sintaxe do gin, com os parâmetros como ':id'.
+	Caminho string
+	// Handler é o nome do método que atende a rota; Verificar confere que é ele que está
+	// registrado no roteador para esse método e caminho.
+	Handler   string
+	Tag       string
+	Resumo    string
+	Descricao string
+
+	Parametros []Parametro
+	// Corpo é um valor do tipo do corpo JSON; nil quando a operação não recebe corpo.
+	Corpo         any
+	CorpoOpcional bool
+	// Obrigatorios lista campos que a operação exige no corpo além dos marcados com
+	// binding:"required" no tipo.
+	Obrigatorios []string
+	// Arquivo é o campo do formulário multipart que recebe um arquivo.
+	Arquivo string
+
+	Status int
+	// Resposta é um valor do tipo devolvido com Status; nil quando a resposta não tem corpo JSON.
+	Resposta any
+	// TiposConteudo substituem application/json nas respostas que são arquivos ou streams.
+	TiposConteudo []string
+	// Erros são os status de erro possíveis, todos com o corpo declarado em RespostaErro.
+	Erros []int
+}
+
+type tag struct {
+	nome      string
+	descricao string
+}
+
+// Documento reúne as operações e as informações usadas para gerar a especificação.
+type Documento struct {
+	titulo    string
+	versao    string
+	descricao string
+	tags      []tag
+	operacoes []Operacao
+	enums     map[reflect.Type][]string
+	extras    map[reflect.Type]map[string]reflect.Type
+
+	erro             reflect.Type
+	tipoConteudoErro string
+}
+
+func Novo(titulo, versao, descricao string) *Documento {
+	return &Documento{
+		titulo:    titulo,
+		versao:    versao,
+		descricao: descricao,
+		enums:     make(map[reflect.Type][]string),
+		extras:    make(map[reflect.Type]map[string]reflect.Type),
+	}
+}
+
+// Tag declara um grupo de operações; os grupos aparecem na ordem em que são declarados.
+func (d *Documento) Tag(nome, descricao string) {
+	d.tags = append(d.tags, tag{nome: nome, descricao: descricao})
+}
+
+func (d *Documento) Adicionar(operacoes ...Operacao) {
+	d.operacoes = append(d.operacoes, operacoes...)
+}
+
+// RespostaErro declara o corpo comum a todas as respostas de erro: um valor do seu tipo e o
+// tipo de conteúdo com que é enviado.
+func (d *Documento) RespostaErro(tipo any, tipoConteudo string) {
+	d.erro = reflect.TypeOf(tipo)
+	d.tipoConteudoErro = tipoConteudo
+}
+
+// Valores registra os valores aceitos por um tipo texto enumerado, como models.TipoAtivo.
+func Valores[T ~string](d *Documento, valores ...T) {
+	var zero T
+	lista := make([]string, len(valores))
+	for i, v := range valores {
+		lista[i] = string(v)
+	}
+	d.enums[reflect.TypeOf(zero)] = lista
+}
+
+// CamposExtras registra campos que o tipo inclui no JSON por um MarshalJSON próprio e que a
+// reflexão, portanto, não enxerga. 'campos' liga o nome de cada campo a um valor do seu tipo.
+func (d *Documento) CamposExtras(tipo any, campos map[string]any) {
+	t := reflect.TypeOf(tipo)
+	if d.extras[t] == nil {
+		d.extras[t] = make(map[string]reflect.Type)
+	}
+	for nome, valor := range campos {
+		d.extras[t][nome] = reflect.TypeOf(valor)
+	}
+}
+
+// Verificar compara as operações com as rotas registradas no roteador: toda rota precisa estar
+// documentada, pelo handler que de fato a atende, e toda operação documentada precisa existir.
+func (d *Documento) Verificar(rotas gin.RoutesInfo) error {
+	documentadas := make(map[string]Operacao, len(d.operacoes))
+	for _, op := range d.operacoes {
+		documentadas[op.Metodo+" "+op.Caminho] = op
+	}
+
+	var problemas []string
+	registradas := make(map[string]bool, len(rotas))
+	for _, rota := range rotas {
+		chave := rota.Method + " " + rota.Path
+		registradas[chave] = true
+		op, ok := documentadas[chave]
+		if !ok {
+			problemas = append(problemas, "rota sem documentação: "+chave)
+			continue
+		}
+		if op.Handler != "" && !strings.HasSuffix(rota.Handler, "."+op.Handler+"-fm") {
+			problemas = append(problemas, fmt.Sprintf("%s é atendida por %s, não por %s", chave, rota.Handler, op.Handler))
+		}
+	}
+	for chave := range documentadas {
+		if !registradas[chave] {
+			problemas = append(problemas, "operação documentada sem rota: "+chave)
+		}
+	}
+
+	if len(problemas) > 0 {
+		sort.Strings(problemas)
+		return fmt.Errorf("%w:\n%s", ErrDocumentacaoDesatualizada, strings.Join(problemas, "\n"))
+	}
+	return nil
+}
+
+var parametroCaminho = regexp.MustCompile(`:([A-Za-z_]+)`)
+
+// JSON gera a especificação.
+func (d *Documento) JSON() ([]byte, error) {
+	g := &gerador{d: d, esquemas: make(map[string]any), nomes: make(map[reflect.Type]string)}
+
+	caminhos := make(map[string]map[string]any)
+	for _, op := range d.operacoes {
+		caminho := parametroCaminho.ReplaceAllString(op.Caminho, "{$1}")
+		if caminhos[caminho] == nil {
+			caminhos[caminho] = make(map[string]any)
+		}
+		caminhos[caminho][strings.ToLower(op.Metodo)] = g.operacao(op)
+	}
+
+	tags := make([]map[string]any, len(d.tags))
+	for i, t := range d.tags {
+		tags[i] = map[string]any{"name": t.nome, "description": t.descricao}
+	}
+
+	return json.MarshalIndent(map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       d.titulo,
+			"version":     d.versao,
+			"description": d.descricao,
+		},
+		"tags":  tags,
+		"paths": caminhos,
+		"components": map[string]any{
+			"schemas": g.esquemas,
+			"parameters": map[string]any{
+				"IdempotencyKey": map[string]any{
+					"name":        "Idempotency-Key",
+					"in":          "header",
+					"description": "Chave que torna o reenvio da mesma requisição seguro: a resposta original é devolvida sem repetir a operação.",
+					"schema":      map[string]any{"type": "string"},
+				},
+			},
+		},
+	}, "", "  ")
+}
+
+func (g *gerador) operacao(op Operacao) map[string]any {
+	saida := map[string]any{
+		"summary": op.Resumo,
+		"tags":    []string{op.Tag},
+	}
+	if op.Handler != "" {
+		saida["operationId"] = op.Handler
+	}
+	if op.Descricao != "" {
+		saida["description"] = op.Descricao
+	}
+
+	var parametros []any
+	for _, nome := range parametroCaminho.FindAllStringSubmatch(op.Caminho, -1) {
+		parametros = append(parametros, map[string]any{
+			"name": nome[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+		})
+	}
+	for _, p := range op.Parametros {
+		esquema := map[string]any{"type": "string"}
+		if p.Tipo != "" {
+			esquema["type"] = p.Tipo
+		}
+		if p.Formato != "" {
+			esquema["format"] = p.Formato
+		}
+		if len(p.Enum) > 0 {
+			esquema["enum"] = p.Enum
+		}
+		parametro := map[string]any{"name": p.Nome, "in": p.Em, "schema": esquema}
+		if p.Descricao != "" {
+			parametro["description"] = p.Descricao
+		}
+		if p.Obrigatorio {
+			parametro["required"] = true
+		}
+		parametros = append(parametros, parametro)
+	}
+	if op.Metodo != http.MethodGet {
+		parametros = append(parametros, map[string]any{"$ref": "#/components/parameters/IdempotencyKey"})
+	}
+	if len(parametros) > 0 {
+		saida["parameters"] = parametros
+	}
+
+	switch {
+	case op.Corpo != nil:
+		esquema := g.esquema(reflect.TypeOf(op.Corpo))
+		if len(op.Obrigatorios) > 0 {
+			esquema = map[string]any{"allOf": []any{esquema, map[string]any{"required": op.Obrigatorios}}}
+		}
+		saida["requestBody"] = map[string]any{
+			"required": !op.CorpoOpcional,
+			"content":  map[string]any{"application/json": map[string]any{"schema": esquema}},
+		}
+	case op.Arquivo != "":
+		saida["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{"multipart/form-data": map[string]any{"schema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{op.Arquivo: map[string]any{"type": "string", "format": "binary"}},
+				"required":   []string{op.Arquivo},
+			}}},
+		}
+	}
+
+	respostas := make(map[string]any, len(op.Erros)+1)
+	sucesso := map[string]any{"description": http.StatusText(op.Status)}
+	switch {
+	case len(op.TiposConteudo) > 0:
+		conteudo := make(map[string]any, len(op.TiposConteudo))
+		for _, tipo := range op.TiposConteudo {
+			esquema := map[string]any{"type": "string", "format": "binary"}
+			if strings.HasPrefix(tipo, "text/") {
+				esquema = map[string]any{"type": "string"}
+			}
+			conteudo[tipo] = map[string]any{"schema": esquema}
+		}
+		sucesso["content"] = conteudo
+	case op.Resposta != nil:
+		sucesso["content"] = map[string]any{"application/json": map[string]any{"schema": g.esquema(reflect.TypeOf(op.Resposta))}}
+	}
+	respostas[fmt.Sprint(op.Status)] = sucesso
+	for _, status := range op.Erros {
+		resposta := map[string]any{"description": http.StatusText(status)}
+		if g.d.erro != nil {
+			resposta["content"] = map[string]any{g.d.tipoConteudoErro: map[string]any{"schema": g.esquema(g.d.erro)}}
+		}
+		respostas[fmt.Sprint(status)] = resposta
+	}
+	saida["responses"] = respostas
+	return saida
+}
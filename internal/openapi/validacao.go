@@ -0,0 +1,284 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"controlador/backend/internal/erros"
+)
+
+// Especificacao é a especificação gerada lida de volta, para conferir requisições e respostas
+// com os esquemas publicados em vez de com os tipos Go que os originaram.
+type Especificacao struct {
+	caminhos map[string]map[string]any
+	esquemas map[string]any
+}
+
+// Ler interpreta a especificação gerada por Documento.JSON.
+func Ler(dados []byte) (*Especificacao, error) {
+	var doc struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(dados, &doc); err != nil {
+		return nil, err
+	}
+	return &Especificacao{caminhos: doc.Paths, esquemas: doc.Components.Schemas}, nil
+}
+
+// operacao encontra a operação pelo método e pelo caminho na sintaxe do gin, como c.FullPath().
+func (e *Especificacao) operacao(metodo, caminho string) map[string]any {
+	op, _ := e.caminhos[parametroCaminho.ReplaceAllString(caminho, "{$1}")][strings.ToLower(metodo)].(map[string]any)
+	return op
+}
+
+// esquemaCorpo devolve o esquema do corpo JSON da operação, ou nil se ela não recebe JSON.
+func (e *Especificacao) esquemaCorpo(metodo, caminho string) map[string]any {
+	corpo, _ := e.operacao(metodo, caminho)["requestBody"].(map[string]any)
+	conteudo, _ := corpo["content"].(map[string]any)
+	midia, _ := conteudo["application/json"].(map[string]any)
+	esquema, _ := midia["schema"].(map[string]any)
+	return esquema
+}
+
+// RecebeJSON informa se a operação documenta um corpo JSON.
+func (e *Especificacao) RecebeJSON(metodo, caminho string) bool {
+	return e.esquemaCorpo(metodo, caminho) != nil
+}
+
+// ValidarRequisicao confere um corpo JSON com o esquema da operação e devolve os campos
+// rejeitados. Corpos vazios ou que não são JSON ficam para o handler, que já responde a eles
+// com erros próprios; os valores enumerados também, porque os tipos do pacote models os
+// rejeitam com um código específico para cada campo.
+func (e *Especificacao) ValidarRequisicao(metodo, caminho string, corpo []byte) []erros.CampoInvalido {
+	esquema := e.esquemaCorpo(metodo, caminho)
+	valor, ok := lerJSON(corpo)
+	if esquema == nil || !ok {
+		return nil
+	}
+	v := &validacao{esquemas: e.esquemas, requisicao: true}
+	v.conferir(esquema, valor, "")
+	sort.Slice(v.campos, func(i, j int) bool { return v.campos[i].Campo < v.campos[j].Campo })
+	return v.campos
+}
+
+// ValidarResposta confere uma resposta com a operação: o status precisa estar documentado, o
+// tipo de conteúdo também e, nas respostas JSON, o corpo precisa seguir o esquema, sem campos
+// que ele não declare.
+func (e *Especificacao) ValidarResposta(metodo, caminho string, status int, tipoConteudo string, corpo []byte) error {
+	op := e.operacao(metodo, caminho)
+	if op == nil {
+		return fmt.Errorf("%s %s não está documentada", metodo, caminho)
+	}
+	respostas, _ := op["responses"].(map[string]any)
+	resposta, ok := respostas[strconv.Itoa(status)].(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s %s: status %d não documentado", metodo, caminho, status)
+	}
+
+	conteudo, _ := resposta["content"].(map[string]any)
+	if len(conteudo) == 0 {
+		if len(corpo) > 0 {
+			return fmt.Errorf("%s %s: status %d é documentado sem corpo, mas a resposta tem %d bytes", metodo, caminho, status, len(corpo))
+		}
+		return nil
+	}
+	tipo, _, err := mime.ParseMediaType(tipoConteudo)
+	if err != nil {
+		return fmt.Errorf("%s %s: tipo de conteúdo inválido %q", metodo, caminho, tipoConteudo)
+	}
+	documentado, ok := conteudo[tipo].(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s %s: tipo de conteúdo %s não documentado para o status %d", metodo, caminho, tipo, status)
+	}
+	if tipo != "application/json" && !strings.HasSuffix(tipo, "+json") {
+		return nil
+	}
+
+	valor, ok := lerJSON(corpo)
+	if !ok {
+		return fmt.Errorf("%s %s: o corpo não é um JSON válido", metodo, caminho)
+	}
+	esquema, _ := documentado["schema"].(map[string]any)
+	v := &validacao{esquemas: e.esquemas}
+	v.conferir(esquema, valor, "")
+	if len(v.campos) > 0 {
+		problemas := make([]string, len(v.campos))
+		for i, c := range v.campos {
+			problemas[i] = c.Campo + ": " + c.Mensagem
+		}
+		return fmt.Errorf("%s %s: o corpo do status %d não confere com o esquema:\n%s", metodo, caminho, status, strings.Join(problemas, "\n"))
+	}
+	return nil
+}
+
+func lerJSON(dados []byte) (any, bool) {
+	if len(bytes.TrimSpace(dados)) == 0 {
+		return nil, false
+	}
+	d := json.NewDecoder(bytes.NewReader(dados))
+	d.UseNumber()
+	var valor any
+	if err := d.Decode(&valor); err != nil {
+		return nil, false
+	}
+	return valor, true
+}
+
+// validacao percorre um valor JSON junto com o esquema gerado, que usa só um subconjunto do
+// JSON Schema: $ref, allOf, anyOf, type, enum, properties, required, additionalProperties e
+// items. Os formatos são apenas anotações e não são conferidos.
+type validacao struct {
+	esquemas map[string]any
+	// requisicao ignora os campos somente leitura, os valores null, que o encoding/json aceita
+	// em qualquer campo, e os valores enumerados. Fora dela, nas respostas, campos não declarados
+	// são rejeitados.
+	requisicao bool
+	campos     []erros.CampoInvalido
+}
+
+func (v *validacao) rejeitar(campo, mensagem string) {
+	if campo == "" {
+		campo = "corpo"
+	}
+	v.campos = append(v.campos, erros.CampoInvalido{Campo: campo, Mensagem: mensagem})
+}
+
+func (v *validacao) conferir(esquema map[string]any, valor any, campo string) {
+	if ref, ok := esquema["$ref"].(string); ok {
+		nome := strings.TrimPrefix(ref, "#/components/schemas/")
+		componente, ok := v.esquemas[nome].(map[string]any)
+		if !ok {
+			v.rejeitar(campo, "esquema não encontrado: "+ref)
+			return
+		}
+		esquema = componente
+	}
+	if todos, ok := esquema["allOf"].([]any); ok {
+		for _, parte := range todos {
+			p, _ := parte.(map[string]any)
+			v.conferir(p, valor, campo)
+		}
+	}
+	if alternativas, ok := esquema["anyOf"].([]any); ok {
+		aceito := false
+		for _, alternativa := range alternativas {
+			a, _ := alternativa.(map[string]any)
+			tentativa := &validacao{esquemas: v.esquemas, requisicao: v.requisicao}
+			tentativa.conferir(a, valor, campo)
+			if len(tentativa.campos) == 0 {
+				aceito = true
+				break
+			}
+		}
+		if !aceito {
+			v.rejeitar(campo, "valor em formato não aceito")
+			return
+		}
+	}
+
+	if tipos := tiposAceitos(esquema["type"]); len(tipos) > 0 && !contem(tipos, tipoJSONDe(valor)) &&
+		!(tipoJSONDe(valor) == "integer" && contem(tipos, "number")) {
+		v.rejeitar(campo, fmt.Sprintf("tipo inválido: esperado %s, recebido %s", strings.Join(tipos, " ou "), tipoJSONDe(valor)))
+		return
+	}
+	if valores, ok := esquema["enum"].([]any); ok && !v.requisicao && valor != nil {
+		if !contemValor(valores, valor) {
+			v.rejeitar(campo, fmt.Sprintf("valor %v fora dos aceitos", valor))
+		}
+	}
+
+	switch x := valor.(type) {
+	case map[string]any:
+		propriedades, _ := esquema["properties"].(map[string]any)
+		obrigatorios, _ := esquema["required"].([]any)
+		for _, o := range obrigatorios {
+			nome, _ := o.(string)
+			if _, ok := x[nome]; !ok {
+				v.rejeitar(juntarCampo(campo, nome), "campo obrigatório")
+			}
+		}
+		for nome, item := range x {
+			if p, ok := propriedades[nome].(map[string]any); ok {
+				if v.requisicao && (item == nil || p["readOnly"] == true) {
+					continue
+				}
+				v.conferir(p, item, juntarCampo(campo, nome))
+				continue
+			}
+			if extra, ok := esquema["additionalProperties"].(map[string]any); ok {
+				v.conferir(extra, item, juntarCampo(campo, nome))
+				continue
+			}
+			if !v.requisicao && propriedades != nil {
+				v.rejeitar(juntarCampo(campo, nome), "campo não documentado")
+			}
+		}
+	case []any:
+		if itens, ok := esquema["items"].(map[string]any); ok {
+			for i, item := range x {
+				v.conferir(itens, item, fmt.Sprintf("%s[%d]", campo, i))
+			}
+		}
+	}
+}
+
+func tiposAceitos(tipo any) []string {
+	switch t := tipo.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		tipos := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				tipos = append(tipos, s)
+			}
+		}
+		return tipos
+	}
+	return nil
+}
+
+func tipoJSONDe(valor any) string {
+	switch x := valor.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := x.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", valor)
+}
+
+func contemValor(valores []any, valor any) bool {
+	for _, v := range valores {
+		if v == valor {
+			return true
+		}
+	}
+	return false
+}
+
+func juntarCampo(pai, nome string) string {
+	if pai == "" {
+		return nome
+	}
+	return pai + "." + nome
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+// AnexoRepository persiste os metadados dos anexos; o conteúdo fica no storage.Storage.
+type AnexoRepository interface {
+	Create(ctx context.Context, anexo *models.Anexo) error
+	FindByID(ctx context.Context, id string) (*models.Anexo, error)
+	FindAllByTransacaoID(ctx context.Context, transacaoID string) ([]models.Anexo, error)
+	Delete(ctx context.Context, id string) error
+	// DeleteAllByTransacaoID remove os anexos da transação e os retorna, para que os arquivos
+	// possam ser apagados depois que a transação de banco for confirmada.
+	DeleteAllByTransacaoID(ctx context.Context, tx pgx.Tx, transacaoID string) ([]models.Anexo, error)
+}
+
+type pgAnexoRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgAnexoRepository(db *pgxpool.Pool) AnexoRepository {
+	return &pgAnexoRepository{db: db}
+}
+
+func scanAnexo(row pgx.Row) (*models.Anexo, error) {
+	var a models.Anexo
+	if err := row.Scan(&a.ID, &a.TransacaoID, &a.NomeArquivo, &a.ContentType, &a.Tamanho, &a.Chave, &a.ChaveMiniatura, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	a.PossuiMiniatura = a.ChaveMiniatura != nil
+	return &a, nil
+}
+
+func (r *pgAnexoRepository) Create(ctx context.Context, anexo *models.Anexo) error {
+	sql := `INSERT INTO anexos (id, transacao_id, nome_arquivo, content_type, tamanho, chave, chave_miniatura, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.Exec(ctx, sql, anexo.ID, anexo.TransacaoID, anexo.NomeArquivo, anexo.ContentType, anexo.Tamanho, anexo.Chave, anexo.ChaveMiniatura, anexo.CreatedAt)
+	return err
+}
+
+func (r *pgAnexoRepository) FindByID(ctx context.Context, id string) (*models.Anexo, error) {
+	sql := `SELECT id, transacao_id, nome_arquivo, content_type, tamanho, chave, chave_miniatura, created_at FROM anexos WHERE id = $1`
+	a, err := scanAnexo(r.db.QueryRow(ctx, sql, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *pgAnexoRepository) FindAllByTransacaoID(ctx context.Context, transacaoID string) ([]models.Anexo, error) {
+	var anexos []models.Anexo
+	sql := `SELECT id, transacao_id, nome_arquivo, content_type, tamanho, chave, chave_miniatura, created_at FROM anexos WHERE transacao_id = $1 ORDER BY created_at ASC`
+	rows, err := r.db.Query(ctx, sql, transacaoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a, err := scanAnexo(rows)
+		if err != nil {
+			return nil, err
+		}
+		anexos = append(anexos, *a)
+	}
+	return anexos, nil
+}
+
+func (r *pgAnexoRepository) Delete(ctx context.Context, id string) error {
+	sql := `DELETE FROM anexos WHERE id = $1`
+	_, err := r.db.Exec(ctx, sql, id)
+	return err
+}
+
+func (r *pgAnexoRepository) DeleteAllByTransacaoID(ctx context.Context, tx pgx.Tx, transacaoID string) ([]models.Anexo, error) {
+	var anexos []models.Anexo
+	sql := `DELETE FROM anexos WHERE transacao_id = $1 RETURNING id, transacao_id, nome_arquivo, content_type, tamanho, chave, chave_miniatura, created_at`
+	rows, err := tx.Query(ctx, sql, transacaoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a, err := scanAnexo(rows)
+		if err != nil {
+			return nil, err
+		}
+		anexos = append(anexos, *a)
+	}
+	return anexos, rows.Err()
+}
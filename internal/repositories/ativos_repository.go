@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"controlador/backend/internal/models"
 	"github.com/jackc/pgx/v5"
@@ -9,11 +10,28 @@ import (
 )
 
 type AtivoRepository interface {
-	Save(ctx context.Context, ativo *models.AtivoFinanceiro) error
+	Save(ctx context.Context, tx pgx.Tx, ativo *models.AtivoFinanceiro) error
 	FindAll(ctx context.Context) ([]models.AtivoFinanceiro, error)
 	FindByID(ctx context.Context, id string) (*models.AtivoFinanceiro, error)
-	UpdateBalance(ctx context.Context, tx pgx.Tx, ativoID string, valor float64, tipo models.TipoTransacao) error
-	Deactivate(ctx context.Context, id string) error
+	UpdateBalance(ctx context.Context, tx pgx.Tx, ativoID string, efeito models.EfeitoSaldo) error
+	FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.AtivoFinanceiro, error)
+	Update(ctx context.Context, tx pgx.Tx, ativo *models.AtivoFinanceiro) error
+	Deactivate(ctx context.Context, tx pgx.Tx, id string) error
+	Reactivate(ctx context.Context, tx pgx.Tx, id string) error
+	DefinirSaldo(ctx context.Context, tx pgx.Tx, id string, saldo, limiteDisponivel float64) error
+	FindEmChequeEspecial(ctx context.Context) ([]models.AtivoFinanceiro, error)
+	RegistrarJurosChequeEspecial(ctx context.Context, tx pgx.Tx, ativoID string, data time.Time, transacaoID string, saldoBase, valor float64) (bool, error)
+}
+
+// ativoColumns lista as colunas lidas em todas as consultas de ativos, na ordem esperada por scanAtivo.
+const ativoColumns = `id, instituicao, nome, tipo, saldo_atual, limite_disponivel, limite_total, moeda, limite_cheque_especial, taxa_juros_cheque_especial, is_active, created_at, updated_at`
+
+func scanAtivo(row pgx.Row) (*models.AtivoFinanceiro, error) {
+	var a models.AtivoFinanceiro
+	if err := row.Scan(&a.ID, &a.Instituicao, &a.Nome, &a.Tipo, &a.SaldoAtual, &a.LimiteDisponivel, &a.LimiteTotal, &a.Moeda, &a.LimiteChequeEspecial, &a.TaxaJurosChequeEspecial, &a.IsActive, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &a, nil
 }
 
 type pgAtivoRepository struct {
@@ -24,68 +42,113 @@ func NewPgAtivoRepository(db *pgxpool.Pool) AtivoRepository {
 	return &pgAtivoRepository{db: db}
 }
 
-func (r *pgAtivoRepository) Deactivate(ctx context.Context, id string) error {
+func (r *pgAtivoRepository) Deactivate(ctx context.Context, tx pgx.Tx, id string) error {
 	sql := `UPDATE ativos_financeiros SET is_active = FALSE, updated_at = NOW() WHERE id = $1`
-	_, err := r.db.Exec(ctx, sql, id)
+	_, err := tx.Exec(ctx, sql, id)
 	return err
 }
 
-func (r *pgAtivoRepository) UpdateBalance(ctx context.Context, tx pgx.Tx, ativoID string, valor float64, tipo models.TipoTransacao) error {
-	var sqlUpdate string
-	if tipo == models.TransacaoRecebimento {
-		sqlUpdate = `UPDATE ativos_financeiros SET saldo_atual = saldo_atual + $1, updated_at = NOW() WHERE id = $2`
-	} else if tipo == models.TransacaoDebito {
-		sqlUpdate = `UPDATE ativos_financeiros SET saldo_atual = saldo_atual - $1, updated_at = NOW() WHERE id = $2`
-	} else if tipo == models.TransacaoCredito {
-		sqlUpdate = `UPDATE ativos_financeiros SET limite_disponivel = limite_disponivel - $1, updated_at = NOW() WHERE id = $2`
-	} else if tipo == models.TransacaoEstorno {
-		ativo, err := r.FindByID(ctx, ativoID)
-		if err != nil {
-			return err
-		}
-		if ativo.Tipo == models.AtivoContaCorrente {
-			sqlUpdate = `UPDATE ativos_financeiros SET saldo_atual = saldo_atual + $1, updated_at = NOW() WHERE id = $2`
-		} else {
-			sqlUpdate = `UPDATE ativos_financeiros SET limite_disponivel = limite_disponivel + $1, updated_at = NOW() WHERE id = $2`
-		}
-	}
-	_, err := tx.Exec(ctx, sqlUpdate, valor, ativoID)
+func (r *pgAtivoRepository) Reactivate(ctx context.Context, tx pgx.Tx, id string) error {
+	sql := `UPDATE ativos_financeiros SET is_active = TRUE, updated_at = NOW() WHERE id = $1`
+	_, err := tx.Exec(ctx, sql, id)
+	return err
+}
+
+// Update grava os dados cadastrais e os limites do ativo. O saldo só é alterado por UpdateBalance.
+func (r *pgAtivoRepository) Update(ctx context.Context, tx pgx.Tx, ativo *models.AtivoFinanceiro) error {
+	sql := `
+		UPDATE ativos_financeiros SET instituicao = $1, nome = $2, limite_disponivel = $3, limite_total = $4,
+			limite_cheque_especial = $5, taxa_juros_cheque_especial = $6, updated_at = $7
+		WHERE id = $8`
+	_, err := tx.Exec(ctx, sql, ativo.Instituicao, ativo.Nome, ativo.LimiteDisponivel, ativo.LimiteTotal, ativo.LimiteChequeEspecial, ativo.TaxaJurosChequeEspecial, ativo.UpdatedAt, ativo.ID)
 	return err
 }
 
-func (r *pgAtivoRepository) Save(ctx context.Context, ativo *models.AtivoFinanceiro) error {
-	sql := `INSERT INTO ativos_financeiros (id, instituicao, nome, tipo, saldo_atual, limite_disponivel, created_at, updated_at, is_active) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, TRUE)`
-	_, err := r.db.Exec(ctx, sql, ativo.ID, ativo.Instituicao, ativo.Nome, ativo.Tipo, ativo.SaldoAtual, ativo.LimiteDisponivel, ativo.CreatedAt, ativo.UpdatedAt)
+// DefinirSaldo sobrescreve o saldo e o limite disponível, usado ao recalculá-los a partir das transações.
+func (r *pgAtivoRepository) DefinirSaldo(ctx context.Context, tx pgx.Tx, id string, saldo, limiteDisponivel float64) error {
+	sql := `UPDATE ativos_financeiros SET saldo_atual = $1, limite_disponivel = $2, updated_at = NOW() WHERE id = $3`
+	_, err := tx.Exec(ctx, sql, saldo, limiteDisponivel, id)
+	return err
+}
+
+// UpdateBalance aplica, dentro da transação de banco, a variação de saldo e de limite calculada pelo serviço.
+func (r *pgAtivoRepository) UpdateBalance(ctx context.Context, tx pgx.Tx, ativoID string, efeito models.EfeitoSaldo) error {
+	sqlUpdate := `UPDATE ativos_financeiros SET saldo_atual = saldo_atual + $1, limite_disponivel = limite_disponivel + $2, updated_at = NOW() WHERE id = $3`
+	_, err := tx.Exec(ctx, sqlUpdate, efeito.Saldo, efeito.Limite, ativoID)
+	return err
+}
+
+func (r *pgAtivoRepository) Save(ctx context.Context, tx pgx.Tx, ativo *models.AtivoFinanceiro) error {
+	sql := `INSERT INTO ativos_financeiros (id, instituicao, nome, tipo, saldo_atual, limite_disponivel, limite_total, moeda, limite_cheque_especial, taxa_juros_cheque_especial, created_at, updated_at, is_active) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, TRUE)`
+	_, err := tx.Exec(ctx, sql, ativo.ID, ativo.Instituicao, ativo.Nome, ativo.Tipo, ativo.SaldoAtual, ativo.LimiteDisponivel, ativo.LimiteTotal, ativo.Moeda, ativo.LimiteChequeEspecial, ativo.TaxaJurosChequeEspecial, ativo.CreatedAt, ativo.UpdatedAt)
 	return err
 }
 
 func (r *pgAtivoRepository) FindAll(ctx context.Context) ([]models.AtivoFinanceiro, error) {
-	var ativos []models.AtivoFinanceiro
-	sql := `SELECT id, instituicao, nome, tipo, saldo_atual, limite_disponivel, is_active, created_at, updated_at FROM ativos_financeiros ORDER BY created_at DESC`
-	rows, err := r.db.Query(ctx, sql)
+	return r.query(ctx, `SELECT `+ativoColumns+` FROM ativos_financeiros ORDER BY created_at DESC`)
+}
+
+func (r *pgAtivoRepository) FindByID(ctx context.Context, id string) (*models.AtivoFinanceiro, error) {
+	sql := `SELECT ` + ativoColumns + ` FROM ativos_financeiros WHERE id = $1`
+	ativo, err := scanAtivo(r.db.QueryRow(ctx, sql, id))
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var a models.AtivoFinanceiro
-		if err := rows.Scan(&a.ID, &a.Instituicao, &a.Nome, &a.Tipo, &a.SaldoAtual, &a.LimiteDisponivel, &a.IsActive, &a.CreatedAt, &a.UpdatedAt); err != nil {
-			return nil, err
+		if err == pgx.ErrNoRows {
+			return nil, nil
 		}
-		ativos = append(ativos, a)
+		return nil, err
 	}
-	return ativos, nil
+	return ativo, nil
 }
 
-func (r *pgAtivoRepository) FindByID(ctx context.Context, id string) (*models.AtivoFinanceiro, error) {
-	var ativo models.AtivoFinanceiro
-	sql := `SELECT id, instituicao, nome, tipo, saldo_atual, limite_disponivel, is_active, created_at, updated_at FROM ativos_financeiros WHERE id = $1`
-	err := r.db.QueryRow(ctx, sql, id).Scan(&ativo.ID, &ativo.Instituicao, &ativo.Nome, &ativo.Tipo, &ativo.SaldoAtual, &ativo.LimiteDisponivel, &ativo.IsActive, &ativo.CreatedAt, &ativo.UpdatedAt)
+// FindByIDForUpdate busca o ativo bloqueando sua linha até o fim da transação de banco.
+func (r *pgAtivoRepository) FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.AtivoFinanceiro, error) {
+	sql := `SELECT ` + ativoColumns + ` FROM ativos_financeiros WHERE id = $1 FOR UPDATE`
+	ativo, err := scanAtivo(tx.QueryRow(ctx, sql, id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &ativo, nil
-}
\ No newline at end of file
+	return ativo, nil
+}
+
+// FindEmChequeEspecial busca as contas correntes ativas com saldo negativo e taxa de juros configurada.
+func (r *pgAtivoRepository) FindEmChequeEspecial(ctx context.Context) ([]models.AtivoFinanceiro, error) {
+	sql := `
+		SELECT ` + ativoColumns + ` FROM ativos_financeiros
+		WHERE tipo = 'CONTA_CORRENTE' AND is_active AND saldo_atual < 0 AND taxa_juros_cheque_especial > 0
+		ORDER BY created_at ASC`
+	return r.query(ctx, sql)
+}
+
+// RegistrarJurosChequeEspecial reserva o lançamento de juros do dia para a conta.
+// Retorna false, sem gravar nada, se os juros desse dia já foram lançados.
+func (r *pgAtivoRepository) RegistrarJurosChequeEspecial(ctx context.Context, tx pgx.Tx, ativoID string, data time.Time, transacaoID string, saldoBase, valor float64) (bool, error) {
+	sql := `
+		INSERT INTO juros_cheque_especial (ativo_financeiro_id, data, transacao_id, saldo_base, valor)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (ativo_financeiro_id, data) DO NOTHING`
+	tag, err := tx.Exec(ctx, sql, ativoID, data, transacaoID, saldoBase, valor)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *pgAtivoRepository) query(ctx context.Context, sql string, args ...any) ([]models.AtivoFinanceiro, error) {
+	var ativos []models.AtivoFinanceiro
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		a, err := scanAtivo(rows)
+		if err != nil {
+			return nil, err
+		}
+		ativos = append(ativos, *a)
+	}
+	return ativos, rows.Err()
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/database"
+	"controlador/backend/internal/models"
+)
+
+// bancoDeTeste conecta ao PostgreSQL de TEST_DATABASE_URL e recria o esquema com database.Migrate,
+// que apaga todas as tabelas: nunca aponte a variável para um banco com dados. Sem ela, o teste é pulado.
+func bancoDeTeste(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL não definida")
+	}
+	db, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		t.Fatalf("conectar: %v", err)
+	}
+	t.Cleanup(db.Close)
+	database.DB = db
+	database.Migrate()
+	return db
+}
+
+// transacaoDeTeste abre uma transação de banco desfeita ao fim do teste.
+func transacaoDeTeste(t *testing.T, db *pgxpool.Pool) pgx.Tx {
+	t.Helper()
+	tx, err := db.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	t.Cleanup(func() { tx.Rollback(context.Background()) })
+	return tx
+}
+
+func TestAtivoSaveGravaMoedaELimiteTotal(t *testing.T) {
+	db := bancoDeTeste(t)
+	ctx := context.Background()
+	tx := transacaoDeTeste(t, db)
+	repo := NewPgAtivoRepository(db)
+
+	now := time.Now()
+	ativo := &models.AtivoFinanceiro{
+		ID:               uuid.New().String(),
+		Instituicao:      "Chase",
+		Nome:             "Cartão em dólar",
+		Tipo:             models.AtivoCartaoCredito,
+		LimiteDisponivel: 1500,
+		LimiteTotal:      2000,
+		Moeda:            "USD",
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := repo.Save(ctx, tx, ativo); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	lido, err := repo.FindByIDForUpdate(ctx, tx, ativo.ID)
+	if err != nil {
+		t.Fatalf("FindByIDForUpdate: %v", err)
+	}
+	if lido == nil {
+		t.Fatal("ativo não encontrado na mesma transação")
+	}
+	if lido.Moeda != "USD" {
+		t.Errorf("Moeda = %q, esperado USD", lido.Moeda)
+	}
+	if lido.LimiteTotal != 2000 {
+		t.Errorf("LimiteTotal = %v, esperado 2000", lido.LimiteTotal)
+	}
+
+	// A transação herda a moeda gravada no ativo.
+	categoriaID := uuid.New().String()
+	if _, err := tx.Exec(ctx, `INSERT INTO categorias (id, nome, icone) VALUES ($1, $2, 'tag')`, categoriaID, "Teste "+categoriaID); err != nil {
+		t.Fatalf("criar categoria: %v", err)
+	}
+	transacao := &models.Transacao{
+		ID:                uuid.New().String(),
+		AtivoFinanceiroID: ativo.ID,
+		CategoriaID:       categoriaID,
+		Descricao:         "Assinatura",
+		Valor:             20,
+		Tipo:              models.TransacaoDebito,
+		Status:            models.StatusEfetivada,
+		Data:              now,
+		CreatedAt:         now,
+	}
+	if err := NewPgTransacaoRepository(db).Create(ctx, tx, transacao); err != nil {
+		t.Fatalf("Create transação: %v", err)
+	}
+	if transacao.Moeda != "USD" {
+		t.Errorf("Moeda da transação = %q, esperado USD", transacao.Moeda)
+	}
+}
+
+func TestAtivoSaveDesfeitoComATransacao(t *testing.T) {
+	db := bancoDeTeste(t)
+	ctx := context.Background()
+	repo := NewPgAtivoRepository(db)
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	now := time.Now()
+	ativo := &models.AtivoFinanceiro{
+		ID: uuid.New().String(), Instituicao: "Banco", Nome: "Conta", Tipo: models.AtivoContaCorrente,
+		Moeda: models.MoedaPadrao, CreatedAt: now, UpdatedAt: now,
+	}
+	if err := repo.Save(ctx, tx, ativo); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+
+	lido, err := repo.FindByID(ctx, ativo.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if lido != nil {
+		t.Error("ativo gravado apesar do rollback")
+	}
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BackupRepository lê e grava tabelas inteiras como objetos JSON, uma linha por objeto, com as
+// colunas da própria tabela. Os nomes de tabela e coluna vêm sempre da lista fixa do serviço de
+// backup, nunca do arquivo recebido.
+type BackupRepository interface {
+	// Linhas chama 'visitar' com cada linha da tabela, na ordem das colunas de 'ordem'.
+	Linhas(ctx context.Context, tx pgx.Tx, tabela, ordem string, visitar func(linha []byte) error) error
+	// ChavesNaturais retorna o ID de cada linha da tabela indexado pelo valor da coluna informada.
+	ChavesNaturais(ctx context.Context, tx pgx.Tx, tabela, coluna string) (map[string]string, error)
+	Limpar(ctx context.Context, tx pgx.Tx, tabelas []string) error
+	// MarcarRestauracao sinaliza aos gatilhos do banco que a transação está restaurando um
+	// backup, para que não registrem as linhas carregadas como eventos novos.
+	MarcarRestauracao(ctx context.Context, tx pgx.Tx) error
+	// Inserir grava as linhas e retorna quantas entraram; com 'ignorarConflitos', linhas que
+	// violariam uma chave única são descartadas.
+	Inserir(ctx context.Context, tx pgx.Tx, tabela string, linhas json.RawMessage, ignorarConflitos bool) (int64, error)
+}
+
+type pgBackupRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgBackupRepository(db *pgxpool.Pool) BackupRepository {
+	return &pgBackupRepository{db: db}
+}
+
+func (r *pgBackupRepository) Linhas(ctx context.Context, tx pgx.Tx, tabela, ordem string, visitar func(linha []byte) error) error {
+	rows, err := tx.Query(ctx, `SELECT to_jsonb(t)::text FROM `+tabela+` t ORDER BY `+ordem)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var linha []byte
+		if err := rows.Scan(&linha); err != nil {
+			return err
+		}
+		if err := visitar(linha); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *pgBackupRepository) ChavesNaturais(ctx context.Context, tx pgx.Tx, tabela, coluna string) (map[string]string, error) {
+	rows, err := tx.Query(ctx, `SELECT `+coluna+`, id::text FROM `+tabela)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chaves := make(map[string]string)
+	for rows.Next() {
+		var chave, id string
+		if err := rows.Scan(&chave, &id); err != nil {
+			return nil, err
+		}
+		chaves[chave] = id
+	}
+	return chaves, rows.Err()
+}
+
+func (r *pgBackupRepository) Limpar(ctx context.Context, tx pgx.Tx, tabelas []string) error {
+	_, err := tx.Exec(ctx, `TRUNCATE `+strings.Join(tabelas, ", ")+` CASCADE`)
+	return err
+}
+
+func (r *pgBackupRepository) MarcarRestauracao(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, `SET LOCAL controlador.restaurando_backup = 'on'`)
+	return err
+}
+
+func (r *pgBackupRepository) Inserir(ctx context.Context, tx pgx.Tx, tabela string, linhas json.RawMessage, ignorarConflitos bool) (int64, error) {
+	sql := `INSERT INTO ` + tabela + ` SELECT * FROM jsonb_populate_recordset(NULL::` + tabela + `, $1::jsonb)`
+	if ignorarConflitos {
+		sql += ` ON CONFLICT DO NOTHING`
+	}
+	tag, err := tx.Exec(ctx, sql, string(linhas))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type ConciliacaoRepository interface {
+	Create(ctx context.Context, tx pgx.Tx, conciliacao *models.Conciliacao) error
+	FindByID(ctx context.Context, id string) (*models.Conciliacao, error)
+	FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Conciliacao, error)
+	FindAllByAtivoID(ctx context.Context, ativoID string) ([]models.Conciliacao, error)
+	FindAbertaByAtivoID(ctx context.Context, tx pgx.Tx, ativoID string) (*models.Conciliacao, error)
+	Concluir(ctx context.Context, tx pgx.Tx, conciliacao *models.Conciliacao) error
+	Delete(ctx context.Context, tx pgx.Tx, id string) error
+}
+
+const conciliacaoColumns = `id, ativo_financeiro_id, data_extrato, saldo_extrato, status, created_at, concluida_em`
+
+type pgConciliacaoRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgConciliacaoRepository(db *pgxpool.Pool) ConciliacaoRepository {
+	return &pgConciliacaoRepository{db: db}
+}
+
+func scanConciliacao(row pgx.Row) (*models.Conciliacao, error) {
+	var c models.Conciliacao
+	if err := row.Scan(&c.ID, &c.AtivoFinanceiroID, &c.DataExtrato, &c.SaldoExtrato, &c.Status, &c.CreatedAt, &c.ConcluidaEm); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *pgConciliacaoRepository) Create(ctx context.Context, tx pgx.Tx, conciliacao *models.Conciliacao) error {
+	sql := `INSERT INTO conciliacoes (id, ativo_financeiro_id, data_extrato, saldo_extrato, status, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := tx.Exec(ctx, sql, conciliacao.ID, conciliacao.AtivoFinanceiroID, conciliacao.DataExtrato, conciliacao.SaldoExtrato, conciliacao.Status, conciliacao.CreatedAt)
+	return err
+}
+
+func (r *pgConciliacaoRepository) FindByID(ctx context.Context, id string) (*models.Conciliacao, error) {
+	return scanConciliacao(r.db.QueryRow(ctx, `SELECT `+conciliacaoColumns+` FROM conciliacoes WHERE id = $1`, id))
+}
+
+// FindByIDForUpdate busca a conciliação bloqueando a linha até o fim da transação de banco.
+func (r *pgConciliacaoRepository) FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Conciliacao, error) {
+	return scanConciliacao(tx.QueryRow(ctx, `SELECT `+conciliacaoColumns+` FROM conciliacoes WHERE id = $1 FOR UPDATE`, id))
+}
+
+func (r *pgConciliacaoRepository) FindAbertaByAtivoID(ctx context.Context, tx pgx.Tx, ativoID string) (*models.Conciliacao, error) {
+	return scanConciliacao(tx.QueryRow(ctx, `SELECT `+conciliacaoColumns+` FROM conciliacoes WHERE ativo_financeiro_id = $1 AND status = 'ABERTA'`, ativoID))
+}
+
+func (r *pgConciliacaoRepository) FindAllByAtivoID(ctx context.Context, ativoID string) ([]models.Conciliacao, error) {
+	var conciliacoes []models.Conciliacao
+	rows, err := r.db.Query(ctx, `SELECT `+conciliacaoColumns+` FROM conciliacoes WHERE ativo_financeiro_id = $1 ORDER BY data_extrato DESC, created_at DESC`, ativoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c, err := scanConciliacao(rows)
+		if err != nil {
+			return nil, err
+		}
+		conciliacoes = append(conciliacoes, *c)
+	}
+	return conciliacoes, rows.Err()
+}
+
+func (r *pgConciliacaoRepository) Concluir(ctx context.Context, tx pgx.Tx, conciliacao *models.Conciliacao) error {
+	sql := `UPDATE conciliacoes SET status = $1, concluida_em = $2 WHERE id = $3`
+	_, err := tx.Exec(ctx, sql, conciliacao.Status, conciliacao.ConcluidaEm, conciliacao.ID)
+	return err
+}
+
+func (r *pgConciliacaoRepository) Delete(ctx context.Context, tx pgx.Tx, id string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM conciliacoes WHERE id = $1`, id)
+	return err
+}
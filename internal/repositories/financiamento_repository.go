@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type FinanciamentoRepository interface {
+	Create(ctx context.Context, tx pgx.Tx, financiamento *models.Financiamento) error
+	FindAll(ctx context.Context) ([]models.Financiamento, error)
+	FindByID(ctx context.Context, id string) (*models.Financiamento, error)
+	FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Financiamento, error)
+	FindByAtivoID(ctx context.Context, ativoID string) (*models.Financiamento, error)
+	AtualizarSaldo(ctx context.Context, tx pgx.Tx, financiamento *models.Financiamento) error
+	FindParcelas(ctx context.Context, financiamentoID string) ([]models.ParcelaFinanciamento, error)
+	FindParcelasTx(ctx context.Context, tx pgx.Tx, financiamentoID string) ([]models.ParcelaFinanciamento, error)
+	SaveParcelas(ctx context.Context, tx pgx.Tx, parcelas []models.ParcelaFinanciamento) error
+	DeleteParcelasPendentes(ctx context.Context, tx pgx.Tx, financiamentoID string) error
+	MarcarParcelaPaga(ctx context.Context, tx pgx.Tx, parcelaID, transacaoID string, pagaEm time.Time) error
+	CreateAmortizacaoExtra(ctx context.Context, tx pgx.Tx, amortizacao *models.AmortizacaoExtra) error
+	FindComParcelasVencidas(ctx context.Context, ate time.Time) ([]string, error)
+}
+
+const (
+	financiamentoColumns = `id, ativo_financeiro_id, ativo_pagamento_id, sistema, taxa_juros_mensal, valor_principal, saldo_devedor, prazo_meses, data_primeira_parcela, status, created_at, updated_at`
+	parcelaColumns       = `id, financiamento_id, numero, data_vencimento, valor, juros, amortizacao, saldo_devedor, status, transacao_id, paga_em`
+)
+
+type pgFinanciamentoRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgFinanciamentoRepository(db *pgxpool.Pool) FinanciamentoRepository {
+	return &pgFinanciamentoRepository{db: db}
+}
+
+func scanFinanciamento(row pgx.Row) (*models.Financiamento, error) {
+	var f models.Financiamento
+	err := row.Scan(&f.ID, &f.AtivoFinanceiroID, &f.AtivoPagamentoID, &f.Sistema, &f.TaxaJurosMensal, &f.ValorPrincipal, &f.SaldoDevedor, &f.PrazoMeses, &f.DataPrimeiraParcela, &f.Status, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *pgFinanciamentoRepository) Create(ctx context.Context, tx pgx.Tx, f *models.Financiamento) error {
+	sql := `
+		INSERT INTO financiamentos (id, ativo_financeiro_id, ativo_pagamento_id, sistema, taxa_juros_mensal, valor_principal, saldo_devedor, prazo_meses, data_primeira_parcela, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	_, err := tx.Exec(ctx, sql, f.ID, f.AtivoFinanceiroID, f.AtivoPagamentoID, f.Sistema, f.TaxaJurosMensal, f.ValorPrincipal, f.SaldoDevedor, f.PrazoMeses, f.DataPrimeiraParcela, f.Status, f.CreatedAt, f.UpdatedAt)
+	return err
+}
+
+func (r *pgFinanciamentoRepository) FindAll(ctx context.Context) ([]models.Financiamento, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+financiamentoColumns+` FROM financiamentos ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var financiamentos []models.Financiamento
+	for rows.Next() {
+		f, err := scanFinanciamento(rows)
+		if err != nil {
+			return nil, err
+		}
+		financiamentos = append(financiamentos, *f)
+	}
+	return financiamentos, rows.Err()
+}
+
+func (r *pgFinanciamentoRepository) FindByID(ctx context.Context, id string) (*models.Financiamento, error) {
+	return scanFinanciamento(r.db.QueryRow(ctx, `SELECT `+financiamentoColumns+` FROM financiamentos WHERE id = $1`, id))
+}
+
+func (r *pgFinanciamentoRepository) FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Financiamento, error) {
+	return scanFinanciamento(tx.QueryRow(ctx, `SELECT `+financiamentoColumns+` FROM financiamentos WHERE id = $1 FOR UPDATE`, id))
+}
+
+func (r *pgFinanciamentoRepository) FindByAtivoID(ctx context.Context, ativoID string) (*models.Financiamento, error) {
+	return scanFinanciamento(r.db.QueryRow(ctx, `SELECT `+financiamentoColumns+` FROM financiamentos WHERE ativo_financeiro_id = $1`, ativoID))
+}
+
+func (r *pgFinanciamentoRepository) AtualizarSaldo(ctx context.Context, tx pgx.Tx, f *models.Financiamento) error {
+	sql := `UPDATE financiamentos SET saldo_devedor = $2, status = $3, updated_at = $4 WHERE id = $1`
+	_, err := tx.Exec(ctx, sql, f.ID, f.SaldoDevedor, f.Status, f.UpdatedAt)
+	return err
+}
+
+// FindParcelas lista o cronograma do financiamento em ordem de número.
+func (r *pgFinanciamentoRepository) FindParcelas(ctx context.Context, financiamentoID string) ([]models.ParcelaFinanciamento, error) {
+	return queryParcelas(ctx, r.db, financiamentoID)
+}
+
+// FindParcelasTx é como FindParcelas, mas lê dentro da transação de banco informada.
+func (r *pgFinanciamentoRepository) FindParcelasTx(ctx context.Context, tx pgx.Tx, financiamentoID string) ([]models.ParcelaFinanciamento, error) {
+	return queryParcelas(ctx, tx, financiamentoID)
+}
+
+func queryParcelas(ctx context.Context, q querier, financiamentoID string) ([]models.ParcelaFinanciamento, error) {
+	rows, err := q.Query(ctx, `SELECT `+parcelaColumns+` FROM parcelas_financiamento WHERE financiamento_id = $1 ORDER BY numero`, financiamentoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var parcelas []models.ParcelaFinanciamento
+	for rows.Next() {
+		var p models.ParcelaFinanciamento
+		if err := rows.Scan(&p.ID, &p.FinanciamentoID, &p.Numero, &p.DataVencimento, &p.Valor, &p.Juros, &p.Amortizacao, &p.SaldoDevedor, &p.Status, &p.TransacaoID, &p.PagaEm); err != nil {
+			return nil, err
+		}
+		parcelas = append(parcelas, p)
+	}
+	return parcelas, rows.Err()
+}
+
+func (r *pgFinanciamentoRepository) SaveParcelas(ctx context.Context, tx pgx.Tx, parcelas []models.ParcelaFinanciamento) error {
+	sql := `
+		INSERT INTO parcelas_financiamento (id, financiamento_id, numero, data_vencimento, valor, juros, amortizacao, saldo_devedor, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	for _, p := range parcelas {
+		if _, err := tx.Exec(ctx, sql, p.ID, p.FinanciamentoID, p.Numero, p.DataVencimento, p.Valor, p.Juros, p.Amortizacao, p.SaldoDevedor, p.Status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *pgFinanciamentoRepository) DeleteParcelasPendentes(ctx context.Context, tx pgx.Tx, financiamentoID string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM parcelas_financiamento WHERE financiamento_id = $1 AND status = 'PENDENTE'`, financiamentoID)
+	return err
+}
+
+func (r *pgFinanciamentoRepository) MarcarParcelaPaga(ctx context.Context, tx pgx.Tx, parcelaID, transacaoID string, pagaEm time.Time) error {
+	sql := `UPDATE parcelas_financiamento SET status = 'PAGA', transacao_id = $2, paga_em = $3 WHERE id = $1`
+	_, err := tx.Exec(ctx, sql, parcelaID, transacaoID, pagaEm)
+	return err
+}
+
+func (r *pgFinanciamentoRepository) CreateAmortizacaoExtra(ctx context.Context, tx pgx.Tx, a *models.AmortizacaoExtra) error {
+	sql := `
+		INSERT INTO amortizacoes_extras (id, financiamento_id, data, valor, modo, transacao_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := tx.Exec(ctx, sql, a.ID, a.FinanciamentoID, a.Data, a.Valor, a.Modo, a.TransacaoID, a.CreatedAt)
+	return err
+}
+
+// FindComParcelasVencidas retorna os financiamentos ativos com parcelas pendentes vencidas até a data.
+func (r *pgFinanciamentoRepository) FindComParcelasVencidas(ctx context.Context, ate time.Time) ([]string, error) {
+	sql := `
+		SELECT DISTINCT f.id FROM financiamentos f
+		JOIN parcelas_financiamento p ON p.financiamento_id = f.id
+		WHERE f.status = 'ATIVO' AND p.status = 'PENDENTE' AND p.data_vencimento <= $1::date`
+	return coletarIDs(ctx, r.db, sql, ate)
+}
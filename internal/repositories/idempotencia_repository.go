@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+// IdempotenciaRepository guarda as chaves de idempotência e as respostas associadas.
+type IdempotenciaRepository interface {
+	// Reservar registra a chave como em processamento. Se ela já existir e não tiver expirado,
+	// nada é gravado e o registro existente é retornado.
+	Reservar(ctx context.Context, chave, fingerprint string, expiraEm time.Time) (*models.ChaveIdempotencia, error)
+	Concluir(ctx context.Context, chave string, statusCode int, contentType string, corpo []byte) error
+	Liberar(ctx context.Context, chave string) error
+	RemoverExpiradas(ctx context.Context) (int64, error)
+}
+
+type pgIdempotenciaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgIdempotenciaRepository(db *pgxpool.Pool) IdempotenciaRepository {
+	return &pgIdempotenciaRepository{db: db}
+}
+
+func (r *pgIdempotenciaRepository) Reservar(ctx context.Context, chave, fingerprint string, expiraEm time.Time) (*models.ChaveIdempotencia, error) {
+	// Uma chave expirada é tratada como inexistente e pode ser reservada de novo.
+	sql := `
+		INSERT INTO chaves_idempotencia (chave, fingerprint, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (chave) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, content_type = '', corpo = NULL,
+			created_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE chaves_idempotencia.expires_at <= NOW()
+		RETURNING chave`
+	var reservada string
+	err := r.db.QueryRow(ctx, sql, chave, fingerprint, expiraEm).Scan(&reservada)
+	if err == nil {
+		return nil, nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, err
+	}
+
+	var existente models.ChaveIdempotencia
+	sql = `SELECT chave, fingerprint, status_code, content_type, corpo, created_at, expires_at FROM chaves_idempotencia WHERE chave = $1`
+	err = r.db.QueryRow(ctx, sql, chave).Scan(&existente.Chave, &existente.Fingerprint, &existente.StatusCode, &existente.ContentType, &existente.Corpo, &existente.CreatedAt, &existente.ExpiresAt)
+	if err == pgx.ErrNoRows {
+		// A chave foi liberada entre as duas consultas; quem chama pode tentar de novo.
+		return r.Reservar(ctx, chave, fingerprint, expiraEm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &existente, nil
+}
+
+func (r *pgIdempotenciaRepository) Concluir(ctx context.Context, chave string, statusCode int, contentType string, corpo []byte) error {
+	sql := `UPDATE chaves_idempotencia SET status_code = $2, content_type = $3, corpo = $4 WHERE chave = $1`
+	_, err := r.db.Exec(ctx, sql, chave, statusCode, contentType, corpo)
+	return err
+}
+
+func (r *pgIdempotenciaRepository) Liberar(ctx context.Context, chave string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM chaves_idempotencia WHERE chave = $1`, chave)
+	return err
+}
+
+func (r *pgIdempotenciaRepository) RemoverExpiradas(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM chaves_idempotencia WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
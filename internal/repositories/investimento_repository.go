@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type InvestimentoRepository interface {
+	CreateTitulo(ctx context.Context, titulo *models.Titulo) error
+	FindTitulos(ctx context.Context) ([]models.Titulo, error)
+	FindTituloByID(ctx context.Context, id string) (*models.Titulo, error)
+	FindTituloByCodigo(ctx context.Context, codigo string) (*models.Titulo, error)
+	LockTitulo(ctx context.Context, tx pgx.Tx, id string) error
+	CreateOperacao(ctx context.Context, tx pgx.Tx, operacao *models.OperacaoInvestimento) error
+	FindOperacoes(ctx context.Context, tituloID string) ([]models.OperacaoInvestimento, error)
+	FindOperacoesTx(ctx context.Context, tx pgx.Tx, tituloID string) ([]models.OperacaoInvestimento, error)
+	FindOperacaoByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.OperacaoInvestimento, error)
+	DeleteOperacao(ctx context.Context, tx pgx.Tx, id string) error
+	SaveCotacao(ctx context.Context, tx pgx.Tx, cotacao *models.CotacaoTitulo) error
+	FindCotacoesVigentes(ctx context.Context, data time.Time) (map[string]models.CotacaoTitulo, error)
+}
+
+const (
+	tituloColumns   = `id, codigo, nome, classe, moeda, created_at`
+	operacaoColumns = `id, titulo_id, ativo_financeiro_id, tipo, data, quantidade, preco_unitario, taxas, valor_total, transacao_id, created_at`
+)
+
+type pgInvestimentoRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgInvestimentoRepository(db *pgxpool.Pool) InvestimentoRepository {
+	return &pgInvestimentoRepository{db: db}
+}
+
+func scanTitulo(row pgx.Row) (*models.Titulo, error) {
+	var t models.Titulo
+	if err := row.Scan(&t.ID, &t.Codigo, &t.Nome, &t.Classe, &t.Moeda, &t.CreatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func scanOperacao(row pgx.Row) (*models.OperacaoInvestimento, error) {
+	var o models.OperacaoInvestimento
+	if err := row.Scan(&o.ID, &o.TituloID, &o.AtivoFinanceiroID, &o.Tipo, &o.Data, &o.Quantidade, &o.PrecoUnitario, &o.Taxas, &o.ValorTotal, &o.TransacaoID, &o.CreatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *pgInvestimentoRepository) CreateTitulo(ctx context.Context, titulo *models.Titulo) error {
+	sql := `INSERT INTO titulos (id, codigo, nome, classe, moeda, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.Exec(ctx, sql, titulo.ID, titulo.Codigo, titulo.Nome, titulo.Classe, titulo.Moeda, titulo.CreatedAt)
+	return err
+}
+
+func (r *pgInvestimentoRepository) FindTitulos(ctx context.Context) ([]models.Titulo, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+tituloColumns+` FROM titulos ORDER BY codigo`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var titulos []models.Titulo
+	for rows.Next() {
+		t, err := scanTitulo(rows)
+		if err != nil {
+			return nil, err
+		}
+		titulos = append(titulos, *t)
+	}
+	return titulos, rows.Err()
+}
+
+func (r *pgInvestimentoRepository) FindTituloByID(ctx context.Context, id string) (*models.Titulo, error) {
+	return scanTitulo(r.db.QueryRow(ctx, `SELECT `+tituloColumns+` FROM titulos WHERE id = $1`, id))
+}
+
+func (r *pgInvestimentoRepository) FindTituloByCodigo(ctx context.Context, codigo string) (*models.Titulo, error) {
+	return scanTitulo(r.db.QueryRow(ctx, `SELECT `+tituloColumns+` FROM titulos WHERE codigo = $1`, codigo))
+}
+
+// LockTitulo bloqueia o título até o fim da transação, serializando as operações sobre ele
+// para que a validação da quantidade em carteira não sofra condição de corrida.
+func (r *pgInvestimentoRepository) LockTitulo(ctx context.Context, tx pgx.Tx, id string) error {
+	var bloqueado string
+	return tx.QueryRow(ctx, `SELECT id FROM titulos WHERE id = $1 FOR UPDATE`, id).Scan(&bloqueado)
+}
+
+func (r *pgInvestimentoRepository) CreateOperacao(ctx context.Context, tx pgx.Tx, operacao *models.OperacaoInvestimento) error {
+	sql := `
+		INSERT INTO operacoes_investimento (id, titulo_id, ativo_financeiro_id, tipo, data, quantidade, preco_unitario, taxas, valor_total, transacao_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := tx.Exec(ctx, sql, operacao.ID, operacao.TituloID, operacao.AtivoFinanceiroID, operacao.Tipo, operacao.Data, operacao.Quantidade, operacao.PrecoUnitario, operacao.Taxas, operacao.ValorTotal, operacao.TransacaoID, operacao.CreatedAt)
+	return err
+}
+
+// FindOperacoes lista as operações em ordem cronológica, a ordem em que as posições são apuradas;
+// um título vazio lista as de todos os títulos.
+func (r *pgInvestimentoRepository) FindOperacoes(ctx context.Context, tituloID string) ([]models.OperacaoInvestimento, error) {
+	return queryOperacoes(ctx, r.db, tituloID)
+}
+
+// FindOperacoesTx é como FindOperacoes, mas lê dentro da transação de banco informada.
+func (r *pgInvestimentoRepository) FindOperacoesTx(ctx context.Context, tx pgx.Tx, tituloID string) ([]models.OperacaoInvestimento, error) {
+	return queryOperacoes(ctx, tx, tituloID)
+}
+
+func queryOperacoes(ctx context.Context, q querier, tituloID string) ([]models.OperacaoInvestimento, error) {
+	sql := `
+		SELECT ` + operacaoColumns + ` FROM operacoes_investimento
+		WHERE ($1 = '' OR titulo_id::text = $1)
+		ORDER BY data, created_at`
+	rows, err := q.Query(ctx, sql, tituloID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var operacoes []models.OperacaoInvestimento
+	for rows.Next() {
+		o, err := scanOperacao(rows)
+		if err != nil {
+			return nil, err
+		}
+		operacoes = append(operacoes, *o)
+	}
+	return operacoes, rows.Err()
+}
+
+func (r *pgInvestimentoRepository) FindOperacaoByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.OperacaoInvestimento, error) {
+	return scanOperacao(tx.QueryRow(ctx, `SELECT `+operacaoColumns+` FROM operacoes_investimento WHERE id = $1 FOR UPDATE`, id))
+}
+
+func (r *pgInvestimentoRepository) DeleteOperacao(ctx context.Context, tx pgx.Tx, id string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM operacoes_investimento WHERE id = $1`, id)
+	return err
+}
+
+// SaveCotacao grava o preço do título na data, substituindo o que já existir.
+func (r *pgInvestimentoRepository) SaveCotacao(ctx context.Context, tx pgx.Tx, cotacao *models.CotacaoTitulo) error {
+	sql := `
+		INSERT INTO cotacoes_titulos (titulo_id, data, preco, fonte) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (titulo_id, data) DO UPDATE SET preco = EXCLUDED.preco, fonte = EXCLUDED.fonte`
+	_, err := tx.Exec(ctx, sql, cotacao.TituloID, cotacao.Data, cotacao.Preco, cotacao.Fonte)
+	return err
+}
+
+// FindCotacoesVigentes retorna, por título, a cotação mais recente até a data informada.
+func (r *pgInvestimentoRepository) FindCotacoesVigentes(ctx context.Context, data time.Time) (map[string]models.CotacaoTitulo, error) {
+	sql := `
+		SELECT DISTINCT ON (titulo_id) titulo_id, data, preco, fonte FROM cotacoes_titulos
+		WHERE data <= $1::date
+		ORDER BY titulo_id, data DESC`
+	rows, err := r.db.Query(ctx, sql, data)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cotacoes := make(map[string]models.CotacaoTitulo)
+	for rows.Next() {
+		var c models.CotacaoTitulo
+		if err := rows.Scan(&c.TituloID, &c.Data, &c.Preco, &c.Fonte); err != nil {
+			return nil, err
+		}
+		cotacoes[c.TituloID] = c
+	}
+	return cotacoes, rows.Err()
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type MetaRepository interface {
+	Create(ctx context.Context, tx pgx.Tx, meta *models.Meta) error
+	FindAll(ctx context.Context) ([]models.Meta, error)
+	FindByID(ctx context.Context, id string) (*models.Meta, error)
+	Delete(ctx context.Context, id string) error
+}
+
+const metaColumns = `id, nome, valor_alvo, data_alvo, moeda, tag, created_at, updated_at`
+
+type pgMetaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgMetaRepository(db *pgxpool.Pool) MetaRepository {
+	return &pgMetaRepository{db: db}
+}
+
+func (r *pgMetaRepository) Create(ctx context.Context, tx pgx.Tx, meta *models.Meta) error {
+	sql := `
+		INSERT INTO metas (id, nome, valor_alvo, data_alvo, moeda, tag, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	if _, err := tx.Exec(ctx, sql, meta.ID, meta.Nome, meta.ValorAlvo, meta.DataAlvo, meta.Moeda, meta.Tag, meta.CreatedAt, meta.UpdatedAt); err != nil {
+		return err
+	}
+	for _, ativoID := range meta.AtivoIDs {
+		if _, err := tx.Exec(ctx, `INSERT INTO meta_ativos (meta_id, ativo_financeiro_id) VALUES ($1, $2)`, meta.ID, ativoID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *pgMetaRepository) FindAll(ctx context.Context) ([]models.Meta, error) {
+	return r.query(ctx, `SELECT `+metaColumns+` FROM metas ORDER BY data_alvo, nome`)
+}
+
+func (r *pgMetaRepository) FindByID(ctx context.Context, id string) (*models.Meta, error) {
+	metas, err := r.query(ctx, `SELECT `+metaColumns+` FROM metas WHERE id = $1`, id)
+	if err != nil || len(metas) == 0 {
+		return nil, err
+	}
+	return &metas[0], nil
+}
+
+func (r *pgMetaRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM metas WHERE id = $1`, id)
+	return err
+}
+
+// query lê as metas e, com uma consulta adicional, os ativos vinculados a elas.
+func (r *pgMetaRepository) query(ctx context.Context, sql string, args ...any) ([]models.Meta, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	var metas []models.Meta
+	indice := make(map[string]int)
+	for rows.Next() {
+		var m models.Meta
+		if err := rows.Scan(&m.ID, &m.Nome, &m.ValorAlvo, &m.DataAlvo, &m.Moeda, &m.Tag, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		indice[m.ID] = len(metas)
+		metas = append(metas, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || len(metas) == 0 {
+		return metas, err
+	}
+
+	ids := make([]string, len(metas))
+	for i, m := range metas {
+		ids[i] = m.ID
+	}
+	rows, err = r.db.Query(ctx, `SELECT meta_id, ativo_financeiro_id FROM meta_ativos WHERE meta_id = ANY($1) ORDER BY ativo_financeiro_id`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var metaID, ativoID string
+		if err := rows.Scan(&metaID, &ativoID); err != nil {
+			return nil, err
+		}
+		i := indice[metaID]
+		metas[i].AtivoIDs = append(metas[i].AtivoIDs, ativoID)
+	}
+	return metas, rows.Err()
+}
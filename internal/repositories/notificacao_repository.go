@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type NotificacaoRepository interface {
+	FindPreferencias(ctx context.Context) ([]models.PreferenciaNotificacao, error)
+	FindPreferencia(ctx context.Context, evento models.TipoEventoNotificacao) (*models.PreferenciaNotificacao, error)
+	SavePreferencia(ctx context.Context, p *models.PreferenciaNotificacao) error
+	// Create grava a notificação; retorna false, sem erro, se já existir uma com a mesma chave.
+	Create(ctx context.Context, n *models.Notificacao) (bool, error)
+	FindCaixaEntrada(ctx context.Context, apenasNaoLidas bool) ([]models.Notificacao, error)
+	MarcarLida(ctx context.Context, id string, em time.Time) (bool, error)
+	MarcarTodasLidas(ctx context.Context, em time.Time) (int64, error)
+}
+
+type pgNotificacaoRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgNotificacaoRepository(db *pgxpool.Pool) NotificacaoRepository {
+	return &pgNotificacaoRepository{db: db}
+}
+
+func canaisParaTexto(canais []models.CanalNotificacao) []string {
+	textos := make([]string, len(canais))
+	for i, c := range canais {
+		textos[i] = string(c)
+	}
+	return textos
+}
+
+func canaisDeTexto(textos []string) []models.CanalNotificacao {
+	canais := make([]models.CanalNotificacao, len(textos))
+	for i, t := range textos {
+		canais[i] = models.CanalNotificacao(t)
+	}
+	return canais
+}
+
+func (r *pgNotificacaoRepository) FindPreferencias(ctx context.Context) ([]models.PreferenciaNotificacao, error) {
+	rows, err := r.db.Query(ctx, `SELECT evento, ativo, canais, antecedencia_dias, limite, updated_at FROM preferencias_notificacao`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var preferencias []models.PreferenciaNotificacao
+	for rows.Next() {
+		p, err := scanPreferencia(rows)
+		if err != nil {
+			return nil, err
+		}
+		preferencias = append(preferencias, *p)
+	}
+	return preferencias, rows.Err()
+}
+
+func (r *pgNotificacaoRepository) FindPreferencia(ctx context.Context, evento models.TipoEventoNotificacao) (*models.PreferenciaNotificacao, error) {
+	row := r.db.QueryRow(ctx, `SELECT evento, ativo, canais, antecedencia_dias, limite, updated_at FROM preferencias_notificacao WHERE evento = $1`, evento)
+	p, err := scanPreferencia(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return p, err
+}
+
+func scanPreferencia(row pgx.Row) (*models.PreferenciaNotificacao, error) {
+	var p models.PreferenciaNotificacao
+	var canais []string
+	if err := row.Scan(&p.Evento, &p.Ativo, &canais, &p.AntecedenciaDias, &p.Limite, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	p.Canais = canaisDeTexto(canais)
+	return &p, nil
+}
+
+func (r *pgNotificacaoRepository) SavePreferencia(ctx context.Context, p *models.PreferenciaNotificacao) error {
+	sql := `
+		INSERT INTO preferencias_notificacao (evento, ativo, canais, antecedencia_dias, limite, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (evento) DO UPDATE
+		SET ativo = EXCLUDED.ativo, canais = EXCLUDED.canais, antecedencia_dias = EXCLUDED.antecedencia_dias,
+		    limite = EXCLUDED.limite, updated_at = EXCLUDED.updated_at`
+	_, err := r.db.Exec(ctx, sql, p.Evento, p.Ativo, canaisParaTexto(p.Canais), p.AntecedenciaDias, p.Limite, p.UpdatedAt)
+	return err
+}
+
+func (r *pgNotificacaoRepository) Create(ctx context.Context, n *models.Notificacao) (bool, error) {
+	sql := `
+		INSERT INTO notificacoes (id, evento, chave, titulo, mensagem, canais, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chave) DO NOTHING`
+	tag, err := r.db.Exec(ctx, sql, n.ID, n.Evento, n.Chave, n.Titulo, n.Mensagem, canaisParaTexto(n.Canais), n.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FindCaixaEntrada lista as notificações entregues na caixa de entrada, das mais recentes para as mais antigas.
+func (r *pgNotificacaoRepository) FindCaixaEntrada(ctx context.Context, apenasNaoLidas bool) ([]models.Notificacao, error) {
+	sql := `
+		SELECT id, evento, chave, titulo, mensagem, canais, lida_em, created_at
+		FROM notificacoes
+		WHERE 'INAPP' = ANY(canais) AND (NOT $1 OR lida_em IS NULL)
+		ORDER BY created_at DESC`
+	rows, err := r.db.Query(ctx, sql, apenasNaoLidas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notificacoes []models.Notificacao
+	for rows.Next() {
+		var n models.Notificacao
+		var canais []string
+		if err := rows.Scan(&n.ID, &n.Evento, &n.Chave, &n.Titulo, &n.Mensagem, &canais, &n.LidaEm, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		n.Canais = canaisDeTexto(canais)
+		notificacoes = append(notificacoes, n)
+	}
+	return notificacoes, rows.Err()
+}
+
+// MarcarLida retorna false se a notificação não existir na caixa de entrada. Marcar de novo uma
+// notificação já lida mantém a data da primeira leitura.
+func (r *pgNotificacaoRepository) MarcarLida(ctx context.Context, id string, em time.Time) (bool, error) {
+	sql := `UPDATE notificacoes SET lida_em = COALESCE(lida_em, $2) WHERE id = $1 AND 'INAPP' = ANY(canais)`
+	tag, err := r.db.Exec(ctx, sql, id, em)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *pgNotificacaoRepository) MarcarTodasLidas(ctx context.Context, em time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE notificacoes SET lida_em = $1 WHERE lida_em IS NULL AND 'INAPP' = ANY(canais)`, em)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
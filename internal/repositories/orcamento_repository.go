@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type OrcamentoRepository interface {
+	Save(ctx context.Context, o *models.Orcamento) error
+	FindAll(ctx context.Context) ([]models.Orcamento, error)
+	FindByCategoriaID(ctx context.Context, categoriaID string) (*models.Orcamento, error)
+	Delete(ctx context.Context, categoriaID string) error
+}
+
+type pgOrcamentoRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgOrcamentoRepository(db *pgxpool.Pool) OrcamentoRepository {
+	return &pgOrcamentoRepository{db: db}
+}
+
+// Save cria o orçamento da categoria ou substitui o existente, preservando a data de criação.
+func (r *pgOrcamentoRepository) Save(ctx context.Context, o *models.Orcamento) error {
+	sql := `
+		INSERT INTO orcamentos (categoria_id, valor_mensal, percentual_alerta, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (categoria_id) DO UPDATE
+		SET valor_mensal = EXCLUDED.valor_mensal, percentual_alerta = EXCLUDED.percentual_alerta, updated_at = EXCLUDED.updated_at
+		RETURNING created_at`
+	return r.db.QueryRow(ctx, sql, o.CategoriaID, o.ValorMensal, o.PercentualAlerta, o.CreatedAt, o.UpdatedAt).Scan(&o.CreatedAt)
+}
+
+func (r *pgOrcamentoRepository) FindAll(ctx context.Context) ([]models.Orcamento, error) {
+	rows, err := r.db.Query(ctx, `SELECT categoria_id, valor_mensal, percentual_alerta, created_at, updated_at FROM orcamentos`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orcamentos []models.Orcamento
+	for rows.Next() {
+		var o models.Orcamento
+		if err := rows.Scan(&o.CategoriaID, &o.ValorMensal, &o.PercentualAlerta, &o.CreatedAt, &o.UpdatedAt); err != nil {
+			return nil, err
+		}
+		orcamentos = append(orcamentos, o)
+	}
+	return orcamentos, rows.Err()
+}
+
+func (r *pgOrcamentoRepository) FindByCategoriaID(ctx context.Context, categoriaID string) (*models.Orcamento, error) {
+	var o models.Orcamento
+	sql := `SELECT categoria_id, valor_mensal, percentual_alerta, created_at, updated_at FROM orcamentos WHERE categoria_id = $1`
+	err := r.db.QueryRow(ctx, sql, categoriaID).Scan(&o.CategoriaID, &o.ValorMensal, &o.PercentualAlerta, &o.CreatedAt, &o.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *pgOrcamentoRepository) Delete(ctx context.Context, categoriaID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM orcamentos WHERE categoria_id = $1`, categoriaID)
+	return err
+}
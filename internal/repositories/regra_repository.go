@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+// RegraRepository persiste as regras de categorização automática.
+type RegraRepository interface {
+	Create(ctx context.Context, regra *models.Regra) error
+	FindAll(ctx context.Context) ([]models.Regra, error)
+	FindAtivas(ctx context.Context) ([]models.Regra, error)
+	FindByID(ctx context.Context, id string) (*models.Regra, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type pgRegraRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRegraRepository(db *pgxpool.Pool) RegraRepository {
+	return &pgRegraRepository{db: db}
+}
+
+func (r *pgRegraRepository) Create(ctx context.Context, regra *models.Regra) error {
+	condicoes, err := json.Marshal(regra.Condicoes)
+	if err != nil {
+		return err
+	}
+	acoes, err := json.Marshal(regra.Acoes)
+	if err != nil {
+		return err
+	}
+	sql := `INSERT INTO regras (id, nome, prioridade, ativa, condicoes, acoes, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err = r.db.Exec(ctx, sql, regra.ID, regra.Nome, regra.Prioridade, regra.Ativa, condicoes, acoes, regra.CreatedAt)
+	return err
+}
+
+func (r *pgRegraRepository) FindAll(ctx context.Context) ([]models.Regra, error) {
+	return r.query(ctx, `SELECT id, nome, prioridade, ativa, condicoes, acoes, created_at FROM regras ORDER BY prioridade ASC, created_at ASC`)
+}
+
+// FindAtivas retorna as regras ativas na ordem em que devem ser avaliadas.
+func (r *pgRegraRepository) FindAtivas(ctx context.Context) ([]models.Regra, error) {
+	return r.query(ctx, `SELECT id, nome, prioridade, ativa, condicoes, acoes, created_at FROM regras WHERE ativa = TRUE ORDER BY prioridade ASC, created_at ASC`)
+}
+
+func (r *pgRegraRepository) FindByID(ctx context.Context, id string) (*models.Regra, error) {
+	regras, err := r.query(ctx, `SELECT id, nome, prioridade, ativa, condicoes, acoes, created_at FROM regras WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(regras) == 0 {
+		return nil, nil
+	}
+	return &regras[0], nil
+}
+
+func (r *pgRegraRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM regras WHERE id = $1`, id)
+	return err
+}
+
+func (r *pgRegraRepository) query(ctx context.Context, sql string, args ...any) ([]models.Regra, error) {
+	var regras []models.Regra
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		regra, err := scanRegra(rows)
+		if err != nil {
+			return nil, err
+		}
+		regras = append(regras, *regra)
+	}
+	return regras, nil
+}
+
+func scanRegra(row pgx.Row) (*models.Regra, error) {
+	var regra models.Regra
+	var condicoes, acoes []byte
+	if err := row.Scan(&regra.ID, &regra.Nome, &regra.Prioridade, &regra.Ativa, &condicoes, &acoes, &regra.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(condicoes, &regra.Condicoes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(acoes, &regra.Acoes); err != nil {
+		return nil, err
+	}
+	return &regra, nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+// RelatorioRepository executa as consultas agregadas usadas pelos relatórios.
+type RelatorioRepository interface {
+	PorCategoria(ctx context.Context, inicio, fim *time.Time) ([]models.LinhaRelatorio, error)
+	PorTag(ctx context.Context, inicio, fim *time.Time) ([]models.LinhaRelatorio, error)
+	PorMes(ctx context.Context, inicio, fim *time.Time) ([]models.LinhaRelatorio, error)
+}
+
+type pgRelatorioRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRelatorioRepository(db *pgxpool.Pool) RelatorioRepository {
+	return &pgRelatorioRepository{db: db}
+}
+
+// linhasPorCategoriaSQL expande as transações efetivadas em linhas por categoria: transações com
+// rateio contribuem com cada divisão, as demais com o valor inteiro. Estornos entram com valor negativo
+// e o tipo da transação original, distribuídos proporcionalmente pelo rateio da original.
+// Estornos só existem para transações efetivadas e são sempre efetivados.
+const linhasPorCategoriaSQL = `
+	SELECT t.categoria_id, t.tipo, t.valor, t.data, t.moeda
+	FROM transacoes t
+	WHERE t.reversal_of IS NULL AND t.status = 'EFETIVADA'
+	  AND NOT EXISTS (SELECT 1 FROM transacao_divisoes d WHERE d.transacao_id = t.id)
+	UNION ALL
+	SELECT d.categoria_id, t.tipo, d.valor, t.data, t.moeda
+	FROM transacao_divisoes d
+	JOIN transacoes t ON t.id = d.transacao_id
+	WHERE t.status = 'EFETIVADA'
+	UNION ALL
+	SELECT COALESCE(d.categoria_id, e.categoria_id), o.tipo,
+	       -CASE WHEN d.id IS NULL THEN e.valor ELSE ROUND(e.valor * d.valor / o.valor, 2) END, e.data, e.moeda
+	FROM transacoes e
+	JOIN transacoes o ON o.id = e.reversal_of
+	LEFT JOIN transacao_divisoes d ON d.transacao_id = o.id`
+
+// PorCategoria totaliza as transações efetivadas por categoria, moeda e dia. A conversão para
+// a moeda do relatório é feita pelo serviço, com a taxa de câmbio de cada dia.
+func (r *pgRelatorioRepository) PorCategoria(ctx context.Context, inicio, fim *time.Time) ([]models.LinhaRelatorio, error) {
+	sql := `
+		SELECT c.id, c.nome, l.moeda, l.data::date,
+		       COALESCE(SUM(l.valor) FILTER (WHERE l.tipo = 'RECEBIMENTO'), 0),
+		       COALESCE(SUM(l.valor) FILTER (WHERE l.tipo IN ('DEBITO', 'CREDITO')), 0)
+		FROM (` + linhasPorCategoriaSQL + `) l
+		JOIN categorias c ON c.id = l.categoria_id
+		WHERE ($1::timestamptz IS NULL OR l.data >= $1)
+		  AND ($2::timestamptz IS NULL OR l.data < $2)
+		GROUP BY c.id, c.nome, l.moeda, l.data::date
+		ORDER BY c.nome ASC`
+	return r.linhas(ctx, sql, inicio, fim)
+}
+
+// PorTag totaliza as transações efetivadas por tag, moeda e dia. Estornos não têm tags próprias:
+// contam com as tags e o tipo da transação original, com valor negativo.
+func (r *pgRelatorioRepository) PorTag(ctx context.Context, inicio, fim *time.Time) ([]models.LinhaRelatorio, error) {
+	sql := `
+		SELECT g.id, g.nome, t.moeda, t.data::date,
+		       COALESCE(SUM(CASE WHEN o.id IS NULL THEN t.valor ELSE -t.valor END) FILTER (WHERE COALESCE(o.tipo, t.tipo) = 'RECEBIMENTO'), 0),
+		       COALESCE(SUM(CASE WHEN o.id IS NULL THEN t.valor ELSE -t.valor END) FILTER (WHERE COALESCE(o.tipo, t.tipo) IN ('DEBITO', 'CREDITO')), 0)
+		FROM transacoes t
+		LEFT JOIN transacoes o ON o.id = t.reversal_of
+		JOIN transacao_tags tt ON tt.transacao_id = COALESCE(t.reversal_of, t.id)
+		JOIN tags g ON g.id = tt.tag_id
+		WHERE t.status = 'EFETIVADA'
+		  AND ($1::timestamptz IS NULL OR t.data >= $1)
+		  AND ($2::timestamptz IS NULL OR t.data < $2)
+		GROUP BY g.id, g.nome, t.moeda, t.data::date
+		ORDER BY g.nome ASC`
+	return r.linhas(ctx, sql, inicio, fim)
+}
+
+// PorMes totaliza as transações efetivadas por mês, moeda e dia; o grupo é o mês no formato
+// AAAA-MM. Usa as mesmas linhas do relatório por categoria, cuja soma é o total de cada transação.
+func (r *pgRelatorioRepository) PorMes(ctx context.Context, inicio, fim *time.Time) ([]models.LinhaRelatorio, error) {
+	sql := `
+		SELECT to_char(l.data::date, 'YYYY-MM') AS mes, to_char(l.data::date, 'YYYY-MM'), l.moeda, l.data::date,
+		       COALESCE(SUM(l.valor) FILTER (WHERE l.tipo = 'RECEBIMENTO'), 0),
+		       COALESCE(SUM(l.valor) FILTER (WHERE l.tipo IN ('DEBITO', 'CREDITO')), 0)
+		FROM (` + linhasPorCategoriaSQL + `) l
+		WHERE ($1::timestamptz IS NULL OR l.data >= $1)
+		  AND ($2::timestamptz IS NULL OR l.data < $2)
+		GROUP BY mes, l.moeda, l.data::date
+		ORDER BY mes ASC`
+	return r.linhas(ctx, sql, inicio, fim)
+}
+
+func (r *pgRelatorioRepository) linhas(ctx context.Context, sql string, args ...any) ([]models.LinhaRelatorio, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var linhas []models.LinhaRelatorio
+	for rows.Next() {
+		var l models.LinhaRelatorio
+		if err := rows.Scan(&l.GrupoID, &l.GrupoNome, &l.Moeda, &l.Dia, &l.Receitas, &l.Despesas); err != nil {
+			return nil, err
+		}
+		linhas = append(linhas, l)
+	}
+	return linhas, rows.Err()
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+// StreamRepository lê os eventos gravados pelos gatilhos do stream; a gravação é feita pelo banco.
+type StreamRepository interface {
+	FindByID(ctx context.Context, id int64) (*models.EventoStream, error)
+	// FindDesde retorna até 'limite' eventos com ID maior que 'desde', em ordem de ID.
+	FindDesde(ctx context.Context, desde int64, limite int) ([]models.EventoStream, error)
+	DeleteAntesDe(ctx context.Context, antes time.Time) (int64, error)
+}
+
+type pgStreamRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgStreamRepository(db *pgxpool.Pool) StreamRepository {
+	return &pgStreamRepository{db: db}
+}
+
+func (r *pgStreamRepository) FindByID(ctx context.Context, id int64) (*models.EventoStream, error) {
+	eventos, err := r.query(ctx, `SELECT id, tipo, ativo_financeiro_id, dados, created_at FROM eventos_stream WHERE id = $1`, id)
+	if err != nil || len(eventos) == 0 {
+		return nil, err
+	}
+	return &eventos[0], nil
+}
+
+func (r *pgStreamRepository) FindDesde(ctx context.Context, desde int64, limite int) ([]models.EventoStream, error) {
+	sql := `
+		SELECT id, tipo, ativo_financeiro_id, dados, created_at
+		FROM eventos_stream
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2`
+	return r.query(ctx, sql, desde, limite)
+}
+
+func (r *pgStreamRepository) DeleteAntesDe(ctx context.Context, antes time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM eventos_stream WHERE created_at < $1`, antes)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *pgStreamRepository) query(ctx context.Context, sql string, args ...any) ([]models.EventoStream, error) {
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var eventos []models.EventoStream
+	for rows.Next() {
+		var e models.EventoStream
+		if err := rows.Scan(&e.ID, &e.Tipo, &e.AtivoFinanceiroID, &e.Dados, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		eventos = append(eventos, e)
+	}
+	return eventos, rows.Err()
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+// TagRepository define as operações de persistência de tags.
+// O vínculo entre tags e transações ou recorrências é gravado pelos repositórios dessas entidades.
+type TagRepository interface {
+	FindAll(ctx context.Context) ([]models.Tag, error)
+	Search(ctx context.Context, termo string, limite int) ([]models.Tag, error)
+}
+
+type pgTagRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgTagRepository(db *pgxpool.Pool) TagRepository {
+	return &pgTagRepository{db: db}
+}
+
+func (r *pgTagRepository) FindAll(ctx context.Context) ([]models.Tag, error) {
+	sql := `SELECT id, nome FROM tags ORDER BY nome ASC`
+	return r.query(ctx, sql)
+}
+
+// Search devolve as tags que começam com o termo, priorizando as mais usadas, para autocompletar.
+func (r *pgTagRepository) Search(ctx context.Context, termo string, limite int) ([]models.Tag, error) {
+	sql := `
+		SELECT g.id, g.nome
+		FROM tags g
+		LEFT JOIN transacao_tags tt ON tt.tag_id = g.id
+		WHERE g.nome ILIKE $1 || '%' ESCAPE '\'
+		GROUP BY g.id, g.nome
+		ORDER BY COUNT(tt.transacao_id) DESC, g.nome ASC
+		LIMIT $2`
+	return r.query(ctx, sql, escaparLike(termo), limite)
+}
+
+// escaparLike faz o termo ser comparado literalmente num LIKE com ESCAPE '\': sem isso, '_'
+// e '%' digitados pelo usuário funcionariam como curingas.
+func escaparLike(termo string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(termo)
+}
+
+func (r *pgTagRepository) query(ctx context.Context, sql string, args ...any) ([]models.Tag, error) {
+	var tags []models.Tag
+	rows, err := r.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t models.Tag
+		if err := rows.Scan(&t.ID, &t.Nome); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+// vincularTags substitui as tags associadas a uma entidade pela lista informada,
+// criando as tags que ainda não existem. 'tabela' e 'coluna' identificam a tabela de ligação.
+func vincularTags(ctx context.Context, tx pgx.Tx, tabela, coluna, id string, nomes []string) error {
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = $1`, tabela, coluna), id); err != nil {
+		return err
+	}
+	if len(nomes) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO tags (id, nome) SELECT gen_random_uuid(), nome FROM unnest($1::text[]) AS nome ON CONFLICT (nome) DO NOTHING`, nomes); err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(`INSERT INTO %s (%s, tag_id) SELECT $1, id FROM tags WHERE nome = ANY($2)`, tabela, coluna)
+	_, err := tx.Exec(ctx, sql, id, nomes)
+	return err
+}
+
+// carregarTags consulta os nomes das tags de cada entidade da lista de IDs, indexados pelo ID.
+func carregarTags(ctx context.Context, q querier, tabela, coluna string, ids []string) (map[string][]string, error) {
+	tags := make(map[string][]string, len(ids))
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	sql := fmt.Sprintf(`SELECT l.%s, g.nome FROM %s l JOIN tags g ON g.id = l.tag_id WHERE l.%s = ANY($1) ORDER BY g.nome`, coluna, tabela, coluna)
+	rows, err := q.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, nome string
+		if err := rows.Scan(&id, &nome); err != nil {
+			return nil, err
+		}
+		tags[id] = append(tags[id], nome)
+	}
+	return tags, rows.Err()
+}
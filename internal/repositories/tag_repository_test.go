@@ -0,0 +1,18 @@
+package repositories
+
+import "testing"
+
+func TestEscaparLike(t *testing.T) {
+	casos := map[string]string{
+		"mercado": "mercado",
+		"_":       `\_`,
+		"50%":     `50\%`,
+		`a\b`:     `a\\b`,
+		`%_\`:     `\%\_\\`,
+	}
+	for termo, esperado := range casos {
+		if got := escaparLike(termo); got != esperado {
+			t.Errorf("escaparLike(%q) = %q, esperado %q", termo, got, esperado)
+		}
+	}
+}
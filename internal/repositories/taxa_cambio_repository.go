@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type TaxaCambioRepository interface {
+	Save(ctx context.Context, taxa *models.TaxaCambio) error
+	FindAll(ctx context.Context, moedaOrigem, moedaDestino string) ([]models.TaxaCambio, error)
+	FindVigente(ctx context.Context, moedaOrigem, moedaDestino string, data time.Time) (*models.TaxaCambio, error)
+}
+
+const taxaCambioColumns = `id, moeda_origem, moeda_destino, data, taxa, fonte, created_at`
+
+type pgTaxaCambioRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgTaxaCambioRepository(db *pgxpool.Pool) TaxaCambioRepository {
+	return &pgTaxaCambioRepository{db: db}
+}
+
+func scanTaxaCambio(row pgx.Row) (*models.TaxaCambio, error) {
+	var t models.TaxaCambio
+	if err := row.Scan(&t.ID, &t.MoedaOrigem, &t.MoedaDestino, &t.Data, &t.Taxa, &t.Fonte, &t.CreatedAt); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Save grava a cotação do dia para o par, substituindo a que já existir.
+// O ID e a data de criação da cotação gravada são devolvidos em 'taxa'.
+func (r *pgTaxaCambioRepository) Save(ctx context.Context, taxa *models.TaxaCambio) error {
+	sql := `
+		INSERT INTO taxas_cambio (id, moeda_origem, moeda_destino, data, taxa, fonte, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (moeda_origem, moeda_destino, data)
+		DO UPDATE SET taxa = EXCLUDED.taxa, fonte = EXCLUDED.fonte, created_at = EXCLUDED.created_at
+		RETURNING id`
+	return r.db.QueryRow(ctx, sql, taxa.ID, taxa.MoedaOrigem, taxa.MoedaDestino, taxa.Data, taxa.Taxa, taxa.Fonte, taxa.CreatedAt).Scan(&taxa.ID)
+}
+
+// FindAll lista as cotações, mais recentes primeiro; moedas vazias não filtram.
+func (r *pgTaxaCambioRepository) FindAll(ctx context.Context, moedaOrigem, moedaDestino string) ([]models.TaxaCambio, error) {
+	sql := `
+		SELECT ` + taxaCambioColumns + ` FROM taxas_cambio
+		WHERE ($1 = '' OR moeda_origem = $1) AND ($2 = '' OR moeda_destino = $2)
+		ORDER BY data DESC, moeda_origem, moeda_destino`
+	rows, err := r.db.Query(ctx, sql, moedaOrigem, moedaDestino)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var taxas []models.TaxaCambio
+	for rows.Next() {
+		t, err := scanTaxaCambio(rows)
+		if err != nil {
+			return nil, err
+		}
+		taxas = append(taxas, *t)
+	}
+	return taxas, rows.Err()
+}
+
+// FindVigente retorna a cotação do par na data ou, na falta dela, a mais recente anterior.
+func (r *pgTaxaCambioRepository) FindVigente(ctx context.Context, moedaOrigem, moedaDestino string, data time.Time) (*models.TaxaCambio, error) {
+	sql := `
+		SELECT ` + taxaCambioColumns + ` FROM taxas_cambio
+		WHERE moeda_origem = $1 AND moeda_destino = $2 AND data <= $3::date
+		ORDER BY data DESC LIMIT 1`
+	return scanTaxaCambio(r.db.QueryRow(ctx, sql, moedaOrigem, moedaDestino, data))
+}
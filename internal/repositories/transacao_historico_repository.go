@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+// TransacaoHistoricoRepository persiste as versões anteriores de transações editadas ou excluídas.
+type TransacaoHistoricoRepository interface {
+	Create(ctx context.Context, tx pgx.Tx, historico *models.TransacaoHistorico) error
+	FindAllByTransacaoID(ctx context.Context, transacaoID string) ([]models.TransacaoHistorico, error)
+}
+
+type pgTransacaoHistoricoRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgTransacaoHistoricoRepository(db *pgxpool.Pool) TransacaoHistoricoRepository {
+	return &pgTransacaoHistoricoRepository{db: db}
+}
+
+// Create grava o snapshot com o próximo número de versão da transação.
+// O número é calculado dentro da mesma transação de banco que altera a transação original.
+func (r *pgTransacaoHistoricoRepository) Create(ctx context.Context, tx pgx.Tx, historico *models.TransacaoHistorico) error {
+	dados, err := json.Marshal(historico.Dados)
+	if err != nil {
+		return err
+	}
+	sql := `
+		INSERT INTO transacoes_historico (id, transacao_id, versao, operacao, dados, created_at)
+		VALUES ($1, $2, (SELECT COALESCE(MAX(versao), 0) + 1 FROM transacoes_historico WHERE transacao_id = $2), $3, $4, $5)
+		RETURNING versao`
+	return tx.QueryRow(ctx, sql, historico.ID, historico.TransacaoID, historico.Operacao, dados, historico.CreatedAt).Scan(&historico.Versao)
+}
+
+func (r *pgTransacaoHistoricoRepository) FindAllByTransacaoID(ctx context.Context, transacaoID string) ([]models.TransacaoHistorico, error) {
+	var historico []models.TransacaoHistorico
+	sql := `SELECT id, transacao_id, versao, operacao, dados, created_at FROM transacoes_historico WHERE transacao_id = $1 ORDER BY versao ASC`
+	rows, err := r.db.Query(ctx, sql, transacaoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var h models.TransacaoHistorico
+		var dados []byte
+		if err := rows.Scan(&h.ID, &h.TransacaoID, &h.Versao, &h.Operacao, &dados, &h.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(dados, &h.Dados); err != nil {
+			return nil, err
+		}
+		historico = append(historico, h)
+	}
+	return historico, nil
+}
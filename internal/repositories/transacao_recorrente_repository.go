@@ -15,6 +15,8 @@ type TransacaoRecorrenteRepository interface {
 	FindActiveByDay(ctx context.Context, dia int) ([]models.TransacaoRecorrente, error)
 	Update(ctx context.Context, tr *models.TransacaoRecorrente) error
 	Delete(ctx context.Context, id string) error
+	PausarPorAtivo(ctx context.Context, tx pgx.Tx, ativoID string) ([]string, error)
+	RetomarPorAtivo(ctx context.Context, tx pgx.Tx, ativoID string) ([]string, error)
 }
 
 type pgTransacaoRecorrenteRepository struct {
@@ -26,21 +28,48 @@ func NewPgTransacaoRecorrenteRepository(db *pgxpool.Pool) TransacaoRecorrenteRep
 }
 
 func (r *pgTransacaoRecorrenteRepository) Create(ctx context.Context, tr *models.TransacaoRecorrente) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	sql := `
 		INSERT INTO transacoes_recorrentes 
 		(id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, dia_do_vencimento, ativa, created_at, updated_at) 
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
-	_, err := r.db.Exec(ctx, sql, tr.ID, tr.AtivoFinanceiroID, tr.CategoriaID, tr.Descricao, tr.Valor, tr.Tipo, tr.DiaDoVencimento, tr.Ativa, tr.CreatedAt, tr.UpdatedAt)
-	return err
+	if _, err := tx.Exec(ctx, sql, tr.ID, tr.AtivoFinanceiroID, tr.CategoriaID, tr.Descricao, tr.Valor, tr.Tipo, tr.DiaDoVencimento, tr.Ativa, tr.CreatedAt, tr.UpdatedAt); err != nil {
+		return err
+	}
+	if err := vincularTags(ctx, tx, "transacao_recorrente_tags", "transacao_recorrente_id", tr.ID, tr.Tags); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// carregarTagsRecorrencias preenche as tags de todas as recorrências com uma única consulta.
+func (r *pgTransacaoRecorrenteRepository) carregarTagsRecorrencias(ctx context.Context, recorrentes []models.TransacaoRecorrente) error {
+	ids := make([]string, len(recorrentes))
+	for i, tr := range recorrentes {
+		ids[i] = tr.ID
+	}
+	tags, err := carregarTags(ctx, r.db, "transacao_recorrente_tags", "transacao_recorrente_id", ids)
+	if err != nil {
+		return err
+	}
+	for i := range recorrentes {
+		recorrentes[i].Tags = tags[recorrentes[i].ID]
+	}
+	return nil
 }
 
 func (r *pgTransacaoRecorrenteRepository) FindByID(ctx context.Context, id string) (*models.TransacaoRecorrente, error) {
 	var tr models.TransacaoRecorrente
 	sql := `
-		SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, dia_do_vencimento, ativa, created_at, updated_at 
+		SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, dia_do_vencimento, ativa, pausada_por_desativacao, created_at, updated_at
 		FROM transacoes_recorrentes WHERE id = $1`
 	err := r.db.QueryRow(ctx, sql, id).Scan(
-		&tr.ID, &tr.AtivoFinanceiroID, &tr.CategoriaID, &tr.Descricao, &tr.Valor, &tr.Tipo, &tr.DiaDoVencimento, &tr.Ativa, &tr.CreatedAt, &tr.UpdatedAt,
+		&tr.ID, &tr.AtivoFinanceiroID, &tr.CategoriaID, &tr.Descricao, &tr.Valor, &tr.Tipo, &tr.DiaDoVencimento, &tr.Ativa, &tr.PausadaPorDesativacao, &tr.CreatedAt, &tr.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -48,13 +77,17 @@ func (r *pgTransacaoRecorrenteRepository) FindByID(ctx context.Context, id strin
 		}
 		return nil, err
 	}
-	return &tr, nil
+	lista := []models.TransacaoRecorrente{tr}
+	if err := r.carregarTagsRecorrencias(ctx, lista); err != nil {
+		return nil, err
+	}
+	return &lista[0], nil
 }
 
 func (r *pgTransacaoRecorrenteRepository) FindAllByAtivoID(ctx context.Context, ativoID string) ([]models.TransacaoRecorrente, error) {
 	var recorrentes []models.TransacaoRecorrente
 	sql := `
-		SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, dia_do_vencimento, ativa, created_at, updated_at 
+		SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, dia_do_vencimento, ativa, pausada_por_desativacao, created_at, updated_at
 		FROM transacoes_recorrentes WHERE ativo_financeiro_id = $1 ORDER BY dia_do_vencimento ASC`
 	rows, err := r.db.Query(ctx, sql, ativoID)
 	if err != nil {
@@ -65,19 +98,23 @@ func (r *pgTransacaoRecorrenteRepository) FindAllByAtivoID(ctx context.Context,
 	for rows.Next() {
 		var tr models.TransacaoRecorrente
 		if err := rows.Scan(
-			&tr.ID, &tr.AtivoFinanceiroID, &tr.CategoriaID, &tr.Descricao, &tr.Valor, &tr.Tipo, &tr.DiaDoVencimento, &tr.Ativa, &tr.CreatedAt, &tr.UpdatedAt,
+			&tr.ID, &tr.AtivoFinanceiroID, &tr.CategoriaID, &tr.Descricao, &tr.Valor, &tr.Tipo, &tr.DiaDoVencimento, &tr.Ativa, &tr.PausadaPorDesativacao, &tr.CreatedAt, &tr.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
 		recorrentes = append(recorrentes, tr)
 	}
+	rows.Close()
+	if err := r.carregarTagsRecorrencias(ctx, recorrentes); err != nil {
+		return nil, err
+	}
 	return recorrentes, nil
 }
 
 func (r *pgTransacaoRecorrenteRepository) FindActiveByDay(ctx context.Context, dia int) ([]models.TransacaoRecorrente, error) {
 	var recorrentes []models.TransacaoRecorrente
 	sql := `
-		SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, dia_do_vencimento, ativa, created_at, updated_at 
+		SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, dia_do_vencimento, ativa, pausada_por_desativacao, created_at, updated_at
 		FROM transacoes_recorrentes WHERE dia_do_vencimento = $1 AND ativa = TRUE`
 	rows, err := r.db.Query(ctx, sql, dia)
 	if err != nil {
@@ -88,12 +125,16 @@ func (r *pgTransacaoRecorrenteRepository) FindActiveByDay(ctx context.Context, d
 	for rows.Next() {
 		var tr models.TransacaoRecorrente
 		if err := rows.Scan(
-			&tr.ID, &tr.AtivoFinanceiroID, &tr.CategoriaID, &tr.Descricao, &tr.Valor, &tr.Tipo, &tr.DiaDoVencimento, &tr.Ativa, &tr.CreatedAt, &tr.UpdatedAt,
+			&tr.ID, &tr.AtivoFinanceiroID, &tr.CategoriaID, &tr.Descricao, &tr.Valor, &tr.Tipo, &tr.DiaDoVencimento, &tr.Ativa, &tr.PausadaPorDesativacao, &tr.CreatedAt, &tr.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
 		recorrentes = append(recorrentes, tr)
 	}
+	rows.Close()
+	if err := r.carregarTagsRecorrencias(ctx, recorrentes); err != nil {
+		return nil, err
+	}
 	return recorrentes, nil
 }
 
@@ -102,12 +143,60 @@ func (r *pgTransacaoRecorrenteRepository) Update(ctx context.Context, tr *models
 		UPDATE transacoes_recorrentes SET 
 		ativo_financeiro_id = $1, categoria_id = $2, descricao = $3, valor = $4, tipo = $5, dia_do_vencimento = $6, ativa = $7, updated_at = $8
 		WHERE id = $9`
-	_, err := r.db.Exec(ctx, sql, tr.AtivoFinanceiroID, tr.CategoriaID, tr.Descricao, tr.Valor, tr.Tipo, tr.DiaDoVencimento, tr.Ativa, tr.UpdatedAt, tr.ID)
-	return err
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, sql, tr.AtivoFinanceiroID, tr.CategoriaID, tr.Descricao, tr.Valor, tr.Tipo, tr.DiaDoVencimento, tr.Ativa, tr.UpdatedAt, tr.ID); err != nil {
+		return err
+	}
+	if err := vincularTags(ctx, tx, "transacao_recorrente_tags", "transacao_recorrente_id", tr.ID, tr.Tags); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *pgTransacaoRecorrenteRepository) Delete(ctx context.Context, id string) error {
 	sql := `DELETE FROM transacoes_recorrentes WHERE id = $1`
 	_, err := r.db.Exec(ctx, sql, id)
 	return err
-}
\ No newline at end of file
+}
+
+// PausarPorAtivo desativa as recorrências ativas do ativo, marcando-as como pausadas pela
+// desativação, e retorna os IDs afetados.
+func (r *pgTransacaoRecorrenteRepository) PausarPorAtivo(ctx context.Context, tx pgx.Tx, ativoID string) ([]string, error) {
+	sql := `
+		UPDATE transacoes_recorrentes SET ativa = FALSE, pausada_por_desativacao = TRUE, updated_at = NOW()
+		WHERE ativo_financeiro_id = $1 AND ativa = TRUE
+		RETURNING id`
+	return coletarIDs(ctx, tx, sql, ativoID)
+}
+
+// RetomarPorAtivo reativa apenas as recorrências que foram pausadas pela desativação do ativo.
+// Recorrências desativadas manualmente continuam desativadas.
+func (r *pgTransacaoRecorrenteRepository) RetomarPorAtivo(ctx context.Context, tx pgx.Tx, ativoID string) ([]string, error) {
+	sql := `
+		UPDATE transacoes_recorrentes SET ativa = TRUE, pausada_por_desativacao = FALSE, updated_at = NOW()
+		WHERE ativo_financeiro_id = $1 AND pausada_por_desativacao = TRUE
+		RETURNING id`
+	return coletarIDs(ctx, tx, sql, ativoID)
+}
+
+func coletarIDs(ctx context.Context, q querier, sql string, args ...any) ([]string, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -12,8 +13,54 @@ import (
 
 type TransacaoRepository interface {
 	Create(ctx context.Context, tx pgx.Tx, transacao *models.Transacao) error
-	FindAll(ctx context.Context) ([]models.Transacao, error)
+	FindAll(ctx context.Context, filtro models.FiltroTransacoes) ([]models.Transacao, error)
+	FindAllTx(ctx context.Context, tx pgx.Tx, filtro models.FiltroTransacoes) ([]models.Transacao, error)
+	// Percorrer chama 'visitar' para cada transação do filtro à medida que as linhas chegam do
+	// banco, sem carregar o resultado inteiro. Com 'porAtivo', as transações vêm agrupadas por ativo.
+	Percorrer(ctx context.Context, filtro models.FiltroTransacoes, porAtivo bool, visitar func(models.TransacaoExportada) error) error
 	FindByID(ctx context.Context, id string) (*models.Transacao, error)
+	FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Transacao, error)
+	Update(ctx context.Context, tx pgx.Tx, transacao *models.Transacao) error
+	Delete(ctx context.Context, tx pgx.Tx, id string) error
+	AtualizarStatus(ctx context.Context, tx pgx.Tx, id string, status models.StatusTransacao) error
+	MarcarConciliadas(ctx context.Context, tx pgx.Tx, ids []string, conciliada bool) error
+	BloquearConciliadas(ctx context.Context, tx pgx.Tx, conciliacao models.Conciliacao, ate time.Time) error
+	Desbloquear(ctx context.Context, tx pgx.Tx, id string) error
+	RegistrarEstorno(ctx context.Context, tx pgx.Tx, id string, valor float64) (bool, error)
+	FindByIDs(ctx context.Context, ids []string) ([]models.Transacao, error)
+	FindByIDsTx(ctx context.Context, tx pgx.Tx, ids []string) ([]models.Transacao, error)
+	FindCandidatasDuplicata(ctx context.Context, t models.Transacao, criterio models.CriterioDuplicata) ([]models.Transacao, error)
+	FindParesDuplicata(ctx context.Context, criterio models.CriterioDuplicata, inicio, fim *time.Time) ([][2]string, error)
+	TransferirAnexos(ctx context.Context, tx pgx.Tx, deID, paraID string) error
+}
+
+// transacaoColumns lista as colunas lidas em todas as consultas de transações, na ordem esperada por scanTransacao.
+const transacaoColumns = `id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, status, moeda, data, notas, reversal_of, motivo_estorno, estorno_parcial, valor_estornado, conciliada, bloqueada, conciliacao_id, origem, created_at`
+
+// querier é satisfeito tanto pelo pool quanto por uma transação de banco.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// filtroTransacoesSQL aplica models.FiltroTransacoes sobre o alias 't'; parâmetros vazios são ignorados.
+// Estornos seguem as tags da transação original.
+const filtroTransacoesSQL = `
+	($1 = '' OR t.ativo_financeiro_id::text = $1)
+	AND ($2 = '' OR t.categoria_id::text = $2)
+	AND ($3 = '' OR EXISTS (
+		SELECT 1 FROM transacao_tags tt JOIN tags g ON g.id = tt.tag_id
+		WHERE tt.transacao_id = COALESCE(t.reversal_of, t.id) AND g.nome = $3))
+	AND ($4::timestamptz IS NULL OR t.data >= $4)
+	AND ($5::timestamptz IS NULL OR t.data < $5)
+	AND (cardinality($6::text[]) = 0 OR t.status = ANY($6))
+	AND ($7::boolean IS NULL OR t.conciliada = $7)`
+
+func filtroTransacoesArgs(f models.FiltroTransacoes) []any {
+	status := make([]string, len(f.Status))
+	for i, st := range f.Status {
+		status[i] = string(st)
+	}
+	return []any{f.AtivoFinanceiroID, f.CategoriaID, f.Tag, f.Inicio, f.Fim, status, f.Conciliada}
 }
 
 type pgTransacaoRepository struct {
@@ -24,42 +71,312 @@ func NewPgTransacaoRepository(db *pgxpool.Pool) TransacaoRepository {
 	return &pgTransacaoRepository{db: db}
 }
 
+func scanTransacao(row pgx.Row) (*models.Transacao, error) {
+	var t models.Transacao
+	err := row.Scan(&t.ID, &t.AtivoFinanceiroID, &t.CategoriaID, &t.Descricao, &t.Valor, &t.Tipo, &t.Status, &t.Moeda, &t.Data, &t.Notas, &t.ReversalOf, &t.MotivoEstorno, &t.EstornoParcial, &t.ValorEstornado, &t.Conciliada, &t.Bloqueada, &t.ConciliacaoID, &t.Origem, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
 func (r *pgTransacaoRepository) Create(ctx context.Context, tx pgx.Tx, transacao *models.Transacao) error {
-	// ALTERAÇÃO: Adicionado 'categoria_id' ao INSERT.
-	sql := `INSERT INTO transacoes (id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, created_at, reversal_of) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
-	_, err := tx.Exec(ctx, sql, transacao.ID, transacao.AtivoFinanceiroID, transacao.CategoriaID, transacao.Descricao, transacao.Valor, transacao.Tipo, transacao.CreatedAt, transacao.ReversalOf)
-	return err
+	// Um estorno de valor menor que o restante da original é marcado como parcial;
+	// o índice único sobre 'reversal_of' só permite um estorno total por transação.
+	// A moeda é sempre copiada do ativo, de modo que nenhuma transação fique em moeda diferente da dele.
+	sql := `
+		INSERT INTO transacoes (id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, status, data, notas, reversal_of, motivo_estorno, estorno_parcial, created_at, origem, moeda)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, (SELECT moeda FROM ativos_financeiros WHERE id = $2))
+		RETURNING moeda`
+	err := tx.QueryRow(ctx, sql, transacao.ID, transacao.AtivoFinanceiroID, transacao.CategoriaID, transacao.Descricao, transacao.Valor, transacao.Tipo, transacao.Status, transacao.Data, transacao.Notas, transacao.ReversalOf, transacao.MotivoEstorno, transacao.EstornoParcial, transacao.CreatedAt, transacao.Origem).Scan(&transacao.Moeda)
+	if err != nil {
+		return err
+	}
+	if err := r.createDivisoes(ctx, tx, transacao); err != nil {
+		return err
+	}
+	return vincularTags(ctx, tx, "transacao_tags", "transacao_id", transacao.ID, transacao.Tags)
+}
+
+// createDivisoes grava as linhas de rateio da transação.
+func (r *pgTransacaoRepository) createDivisoes(ctx context.Context, tx pgx.Tx, transacao *models.Transacao) error {
+	sql := `INSERT INTO transacao_divisoes (id, transacao_id, categoria_id, valor, memo) VALUES ($1, $2, $3, $4, $5)`
+	for i := range transacao.Divisoes {
+		d := &transacao.Divisoes[i]
+		d.TransacaoID = transacao.ID
+		if _, err := tx.Exec(ctx, sql, d.ID, d.TransacaoID, d.CategoriaID, d.Valor, d.Memo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// carregarDetalhes preenche as linhas de rateio e as tags de todas as transações,
+// com uma consulta para cada em vez de uma por transação.
+func carregarDetalhes(ctx context.Context, q querier, transacoes []models.Transacao) error {
+	if len(transacoes) == 0 {
+		return nil
+	}
+	ids := make([]string, len(transacoes))
+	indice := make(map[string]int, len(transacoes))
+	for i, t := range transacoes {
+		ids[i] = t.ID
+		indice[t.ID] = i
+	}
+
+	tags, err := carregarTags(ctx, q, "transacao_tags", "transacao_id", ids)
+	if err != nil {
+		return err
+	}
+	for i := range transacoes {
+		transacoes[i].Tags = tags[transacoes[i].ID]
+	}
+
+	sql := `SELECT id, transacao_id, categoria_id, valor, memo FROM transacao_divisoes WHERE transacao_id = ANY($1) ORDER BY valor DESC`
+	rows, err := q.Query(ctx, sql, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var d models.TransacaoDivisao
+		if err := rows.Scan(&d.ID, &d.TransacaoID, &d.CategoriaID, &d.Valor, &d.Memo); err != nil {
+			return err
+		}
+		i := indice[d.TransacaoID]
+		transacoes[i].Divisoes = append(transacoes[i].Divisoes, d)
+	}
+	return rows.Err()
 }
 
 func (r *pgTransacaoRepository) FindByID(ctx context.Context, id string) (*models.Transacao, error) {
-	var t models.Transacao
-	// ALTERAÇÃO: Adicionado 'categoria_id' ao SELECT e ao Scan.
-	sql := `SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, created_at, reversal_of FROM transacoes WHERE id = $1`
-	err := r.db.QueryRow(ctx, sql, id).Scan(&t.ID, &t.AtivoFinanceiroID, &t.CategoriaID, &t.Descricao, &t.Valor, &t.Tipo, &t.CreatedAt, &t.ReversalOf)
+	sql := `SELECT ` + transacaoColumns + ` FROM transacoes WHERE id = $1`
+	t, err := scanTransacao(r.db.QueryRow(ctx, sql, id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
-	return &t, nil
+	return r.comDetalhes(ctx, r.db, t)
+}
+
+// comDetalhes carrega as linhas de rateio e as tags de uma única transação.
+func (r *pgTransacaoRepository) comDetalhes(ctx context.Context, q querier, t *models.Transacao) (*models.Transacao, error) {
+	lista := []models.Transacao{*t}
+	if err := carregarDetalhes(ctx, q, lista); err != nil {
+		return nil, err
+	}
+	return &lista[0], nil
+}
+
+// FindByIDForUpdate busca a transação bloqueando a linha até o fim da transação de banco.
+func (r *pgTransacaoRepository) FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Transacao, error) {
+	sql := `SELECT ` + transacaoColumns + ` FROM transacoes WHERE id = $1 FOR UPDATE`
+	t, err := scanTransacao(tx.QueryRow(ctx, sql, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.comDetalhes(ctx, tx, t)
+}
+
+func (r *pgTransacaoRepository) Update(ctx context.Context, tx pgx.Tx, transacao *models.Transacao) error {
+	sql := `UPDATE transacoes SET ativo_financeiro_id = $1, categoria_id = $2, descricao = $3, valor = $4, tipo = $5, data = $6, notas = $7 WHERE id = $8`
+	if _, err := tx.Exec(ctx, sql, transacao.AtivoFinanceiroID, transacao.CategoriaID, transacao.Descricao, transacao.Valor, transacao.Tipo, transacao.Data, transacao.Notas, transacao.ID); err != nil {
+		return err
+	}
+	// O rateio e as tags são sempre regravados por inteiro a partir da versão atualizada.
+	if _, err := tx.Exec(ctx, `DELETE FROM transacao_divisoes WHERE transacao_id = $1`, transacao.ID); err != nil {
+		return err
+	}
+	if err := r.createDivisoes(ctx, tx, transacao); err != nil {
+		return err
+	}
+	return vincularTags(ctx, tx, "transacao_tags", "transacao_id", transacao.ID, transacao.Tags)
+}
+
+func (r *pgTransacaoRepository) Delete(ctx context.Context, tx pgx.Tx, id string) error {
+	sql := `DELETE FROM transacoes WHERE id = $1`
+	_, err := tx.Exec(ctx, sql, id)
+	return err
 }
 
-func (r *pgTransacaoRepository) FindAll(ctx context.Context) ([]models.Transacao, error) {
+func (r *pgTransacaoRepository) AtualizarStatus(ctx context.Context, tx pgx.Tx, id string, status models.StatusTransacao) error {
+	_, err := tx.Exec(ctx, `UPDATE transacoes SET status = $1 WHERE id = $2`, status, id)
+	return err
+}
+
+func (r *pgTransacaoRepository) MarcarConciliadas(ctx context.Context, tx pgx.Tx, ids []string, conciliada bool) error {
+	_, err := tx.Exec(ctx, `UPDATE transacoes SET conciliada = $1 WHERE id = ANY($2)`, conciliada, ids)
+	return err
+}
+
+// BloquearConciliadas bloqueia as transações conferidas do ativo com data anterior a 'ate',
+// vinculando-as à conciliação concluída.
+func (r *pgTransacaoRepository) BloquearConciliadas(ctx context.Context, tx pgx.Tx, conciliacao models.Conciliacao, ate time.Time) error {
+	sql := `
+		UPDATE transacoes SET bloqueada = TRUE, conciliacao_id = $1
+		WHERE ativo_financeiro_id = $2 AND conciliada AND NOT bloqueada AND data < $3`
+	_, err := tx.Exec(ctx, sql, conciliacao.ID, conciliacao.AtivoFinanceiroID, ate)
+	return err
+}
+
+func (r *pgTransacaoRepository) Desbloquear(ctx context.Context, tx pgx.Tx, id string) error {
+	_, err := tx.Exec(ctx, `UPDATE transacoes SET bloqueada = FALSE WHERE id = $1`, id)
+	return err
+}
+
+// findAllSQL seleciona as transações de models.FiltroTransacoes, as mais recentes primeiro.
+const findAllSQL = `SELECT ` + transacaoColumns + ` FROM transacoes t WHERE ` + filtroTransacoesSQL + ` ORDER BY created_at DESC`
+
+func (r *pgTransacaoRepository) FindAll(ctx context.Context, filtro models.FiltroTransacoes) ([]models.Transacao, error) {
+	return r.query(ctx, r.db, findAllSQL, filtroTransacoesArgs(filtro)...)
+}
+
+// FindAllTx é FindAll lido dentro de uma transação de banco, enxergando o que ela já gravou.
+func (r *pgTransacaoRepository) FindAllTx(ctx context.Context, tx pgx.Tx, filtro models.FiltroTransacoes) ([]models.Transacao, error) {
+	return r.query(ctx, tx, findAllSQL, filtroTransacoesArgs(filtro)...)
+}
+
+func (r *pgTransacaoRepository) Percorrer(ctx context.Context, filtro models.FiltroTransacoes, porAtivo bool, visitar func(models.TransacaoExportada) error) error {
+	ordem := `t.data, t.created_at, t.id`
+	if porAtivo {
+		ordem = `a.nome, t.ativo_financeiro_id, ` + ordem
+	}
+	sql := `
+		SELECT t.id, t.ativo_financeiro_id, t.categoria_id, t.descricao, t.valor, t.tipo, t.status, t.moeda, t.data, t.notas,
+		       t.reversal_of, t.origem, t.created_at,
+		       ARRAY(SELECT g.nome FROM transacao_tags tt JOIN tags g ON g.id = tt.tag_id
+		             WHERE tt.transacao_id = COALESCE(t.reversal_of, t.id) ORDER BY g.nome),
+		       a.nome, a.tipo, a.saldo_atual, c.nome, o.tipo
+		FROM transacoes t
+		JOIN ativos_financeiros a ON a.id = t.ativo_financeiro_id
+		JOIN categorias c ON c.id = t.categoria_id
+		LEFT JOIN transacoes o ON o.id = t.reversal_of
+		WHERE ` + filtroTransacoesSQL + `
+		ORDER BY ` + ordem
+	rows, err := r.db.Query(ctx, sql, filtroTransacoesArgs(filtro)...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.TransacaoExportada
+		t := &e.Transacao
+		if err := rows.Scan(&t.ID, &t.AtivoFinanceiroID, &t.CategoriaID, &t.Descricao, &t.Valor, &t.Tipo, &t.Status, &t.Moeda, &t.Data, &t.Notas,
+			&t.ReversalOf, &t.Origem, &t.CreatedAt, &t.Tags, &e.AtivoNome, &e.AtivoTipo, &e.AtivoSaldo, &e.CategoriaNome, &e.TipoOriginal); err != nil {
+			return err
+		}
+		if err := visitar(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// RegistrarEstorno soma 'valor' ao total já estornado da transação de forma atômica.
+// Retorna false se o estorno ultrapassaria o valor original da transação.
+func (r *pgTransacaoRepository) RegistrarEstorno(ctx context.Context, tx pgx.Tx, id string, valor float64) (bool, error) {
+	sql := `UPDATE transacoes SET valor_estornado = valor_estornado + $1 WHERE id = $2 AND reversal_of IS NULL AND valor_estornado + $1 <= valor`
+	tag, err := tx.Exec(ctx, sql, valor, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FindByIDs busca várias transações de uma vez, na ordem de criação mais recente primeiro.
+func (r *pgTransacaoRepository) FindByIDs(ctx context.Context, ids []string) ([]models.Transacao, error) {
+	return r.query(ctx, r.db, findByIDsSQL, ids)
+}
+
+// FindByIDsTx é FindByIDs lido dentro de uma transação de banco.
+func (r *pgTransacaoRepository) FindByIDsTx(ctx context.Context, tx pgx.Tx, ids []string) ([]models.Transacao, error) {
+	return r.query(ctx, tx, findByIDsSQL, ids)
+}
+
+const findByIDsSQL = `SELECT ` + transacaoColumns + ` FROM transacoes WHERE id = ANY($1) ORDER BY created_at DESC`
+
+// duplicataSQL seleciona transações "próximas" de outra: mesmo ativo, valor dentro da tolerância e data
+// dentro da janela. Transações canceladas, estornadas por completo e os próprios estornos nunca são duplicatas.
+const duplicataSQL = `
+	reversal_of IS NULL AND valor_estornado < valor AND status <> 'CANCELADA'`
+
+// FindCandidatasDuplicata busca transações que podem ser duplicatas de 't' pelo ativo, valor e data.
+// A semelhança da descrição é avaliada pelo serviço.
+func (r *pgTransacaoRepository) FindCandidatasDuplicata(ctx context.Context, t models.Transacao, criterio models.CriterioDuplicata) ([]models.Transacao, error) {
+	sql := `
+		SELECT ` + transacaoColumns + ` FROM transacoes
+		WHERE ativo_financeiro_id = $1 AND id::text <> $2 AND ` + duplicataSQL + `
+		  AND ABS(valor - $3) <= GREATEST($4, $3 * $5)
+		  AND data BETWEEN $6 AND $7
+		ORDER BY data DESC`
+	return r.query(ctx, r.db, sql, t.AtivoFinanceiroID, t.ID, t.Valor, criterio.ToleranciaMinima, criterio.ToleranciaPercentual,
+		t.Data.Add(-criterio.Janela), t.Data.Add(criterio.Janela))
+}
+
+// FindParesDuplicata busca pares de transações do mesmo ativo com valor e data próximos.
+// Cada par aparece uma vez, com o ID mais antigo primeiro.
+func (r *pgTransacaoRepository) FindParesDuplicata(ctx context.Context, criterio models.CriterioDuplicata, inicio, fim *time.Time) ([][2]string, error) {
+	var pares [][2]string
+	sql := `
+		SELECT a.id, b.id
+		FROM transacoes a
+		JOIN transacoes b ON b.ativo_financeiro_id = a.ativo_financeiro_id
+		 AND (b.created_at, b.id) > (a.created_at, a.id)
+		 AND ABS(a.valor - b.valor) <= GREATEST($1, GREATEST(a.valor, b.valor) * $2)
+		 AND ABS(EXTRACT(EPOCH FROM (a.data - b.data))) <= $3
+		WHERE a.reversal_of IS NULL AND a.valor_estornado < a.valor AND a.status <> 'CANCELADA'
+		  AND b.reversal_of IS NULL AND b.valor_estornado < b.valor AND b.status <> 'CANCELADA'
+		  AND ($4::timestamptz IS NULL OR a.data >= $4)
+		  AND ($5::timestamptz IS NULL OR a.data < $5)
+		ORDER BY a.data DESC`
+	rows, err := r.db.Query(ctx, sql, criterio.ToleranciaMinima, criterio.ToleranciaPercentual, criterio.Janela.Seconds(), inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var par [2]string
+		if err := rows.Scan(&par[0], &par[1]); err != nil {
+			return nil, err
+		}
+		pares = append(pares, par)
+	}
+	return pares, rows.Err()
+}
+
+// TransferirAnexos move os anexos de uma transação para outra.
+func (r *pgTransacaoRepository) TransferirAnexos(ctx context.Context, tx pgx.Tx, deID, paraID string) error {
+	_, err := tx.Exec(ctx, `UPDATE anexos SET transacao_id = $1 WHERE transacao_id = $2`, paraID, deID)
+	return err
+}
+
+// query executa uma consulta de transações e carrega o rateio e as tags do resultado.
+func (r *pgTransacaoRepository) query(ctx context.Context, q querier, sql string, args ...any) ([]models.Transacao, error) {
 	var transacoes []models.Transacao
-	// ALTERAÇÃO: Adicionado 'categoria_id' ao SELECT e ao Scan.
-	sql := `SELECT id, ativo_financeiro_id, categoria_id, descricao, valor, tipo, created_at, reversal_of FROM transacoes ORDER BY created_at DESC`
-	rows, err := r.db.Query(ctx, sql)
+	rows, err := q.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var t models.Transacao
-		if err := rows.Scan(&t.ID, &t.AtivoFinanceiroID, &t.CategoriaID, &t.Descricao, &t.Valor, &t.Tipo, &t.CreatedAt, &t.ReversalOf); err != nil {
+		t, err := scanTransacao(rows)
+		if err != nil {
 			return nil, err
 		}
-		transacoes = append(transacoes, t)
+		transacoes = append(transacoes, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if err := carregarDetalhes(ctx, q, transacoes); err != nil {
+		return nil, err
 	}
 	return transacoes, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type TransferenciaRepository interface {
+	Create(ctx context.Context, tx pgx.Tx, transferencia *models.Transferencia) error
+	FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Transferencia, error)
+	FindByTransacaoID(ctx context.Context, transacaoID string) (*models.Transferencia, error)
+}
+
+const transferenciaColumns = `id, transacao_origem_id, transacao_destino_id, moeda_origem, moeda_destino, valor_origem, valor_destino, taxa, created_at`
+
+type pgTransferenciaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgTransferenciaRepository(db *pgxpool.Pool) TransferenciaRepository {
+	return &pgTransferenciaRepository{db: db}
+}
+
+func scanTransferencia(row pgx.Row) (*models.Transferencia, error) {
+	var t models.Transferencia
+	err := row.Scan(&t.ID, &t.TransacaoOrigemID, &t.TransacaoDestinoID, &t.MoedaOrigem, &t.MoedaDestino, &t.ValorOrigem, &t.ValorDestino, &t.Taxa, &t.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *pgTransferenciaRepository) Create(ctx context.Context, tx pgx.Tx, t *models.Transferencia) error {
+	sql := `
+		INSERT INTO transferencias (id, transacao_origem_id, transacao_destino_id, moeda_origem, moeda_destino, valor_origem, valor_destino, taxa, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := tx.Exec(ctx, sql, t.ID, t.TransacaoOrigemID, t.TransacaoDestinoID, t.MoedaOrigem, t.MoedaDestino, t.ValorOrigem, t.ValorDestino, t.Taxa, t.CreatedAt)
+	return err
+}
+
+// FindByIDForUpdate bloqueia a transferência, serializando as operações sobre as duas pontas.
+func (r *pgTransferenciaRepository) FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*models.Transferencia, error) {
+	return scanTransferencia(tx.QueryRow(ctx, `SELECT `+transferenciaColumns+` FROM transferencias WHERE id = $1 FOR UPDATE`, id))
+}
+
+// FindByTransacaoID encontra a transferência de que a transação é uma das pontas.
+func (r *pgTransferenciaRepository) FindByTransacaoID(ctx context.Context, transacaoID string) (*models.Transferencia, error) {
+	sql := `SELECT ` + transferenciaColumns + ` FROM transferencias WHERE transacao_origem_id = $1 OR transacao_destino_id = $1`
+	return scanTransferencia(r.db.QueryRow(ctx, sql, transacaoID))
+}
@@ -0,0 +1,258 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+)
+
+type WebhookRepository interface {
+	// CreateEvento grava o evento no outbox, na transação de banco da alteração que o originou.
+	CreateEvento(ctx context.Context, tx pgx.Tx, evento *models.EventoOutbox) error
+	FindEventosNaoDistribuidos(ctx context.Context, tx pgx.Tx, limite int) ([]models.EventoOutbox, error)
+	MarcarEventosDistribuidos(ctx context.Context, tx pgx.Tx, ids []string, em time.Time) error
+	CreateEntrega(ctx context.Context, tx pgx.Tx, entrega *models.EntregaWebhook) error
+
+	CreateAssinatura(ctx context.Context, assinatura *models.WebhookAssinatura) error
+	FindAssinaturas(ctx context.Context) ([]models.WebhookAssinatura, error)
+	FindAssinaturaByID(ctx context.Context, id string) (*models.WebhookAssinatura, error)
+	DeleteAssinatura(ctx context.Context, id string) error
+
+	// ReservarEnvios seleciona até 'limite' entregas pendentes vencidas em 'agora' e adia a próxima
+	// tentativa delas para 'reservaAte', para que outra execução do worker não as envie em paralelo.
+	ReservarEnvios(ctx context.Context, agora, reservaAte time.Time, limite int) ([]models.EnvioWebhook, error)
+	RegistrarTentativa(ctx context.Context, entrega *models.EntregaWebhook) error
+	FindEntregas(ctx context.Context, assinaturaID string, limite int) ([]models.EntregaWebhook, error)
+	FindEntregaByID(ctx context.Context, id string) (*models.EntregaWebhook, error)
+	ReagendarEntrega(ctx context.Context, id string, em time.Time) error
+}
+
+type pgWebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgWebhookRepository(db *pgxpool.Pool) WebhookRepository {
+	return &pgWebhookRepository{db: db}
+}
+
+func eventosParaTexto(eventos []models.TipoEventoWebhook) []string {
+	textos := make([]string, len(eventos))
+	for i, e := range eventos {
+		textos[i] = string(e)
+	}
+	return textos
+}
+
+func (r *pgWebhookRepository) CreateEvento(ctx context.Context, tx pgx.Tx, evento *models.EventoOutbox) error {
+	sql := `INSERT INTO eventos_outbox (id, tipo, dados, created_at) VALUES ($1, $2, $3, $4)`
+	_, err := tx.Exec(ctx, sql, evento.ID, evento.Tipo, evento.Dados, evento.CreatedAt)
+	return err
+}
+
+// FindEventosNaoDistribuidos bloqueia os eventos retornados; eventos já bloqueados por outra
+// execução são pulados.
+func (r *pgWebhookRepository) FindEventosNaoDistribuidos(ctx context.Context, tx pgx.Tx, limite int) ([]models.EventoOutbox, error) {
+	sql := `
+		SELECT id, tipo, dados, created_at
+		FROM eventos_outbox
+		WHERE distribuido_em IS NULL
+		ORDER BY created_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+	rows, err := tx.Query(ctx, sql, limite)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var eventos []models.EventoOutbox
+	for rows.Next() {
+		var e models.EventoOutbox
+		if err := rows.Scan(&e.ID, &e.Tipo, &e.Dados, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		eventos = append(eventos, e)
+	}
+	return eventos, rows.Err()
+}
+
+func (r *pgWebhookRepository) MarcarEventosDistribuidos(ctx context.Context, tx pgx.Tx, ids []string, em time.Time) error {
+	_, err := tx.Exec(ctx, `UPDATE eventos_outbox SET distribuido_em = $2 WHERE id = ANY($1)`, ids, em)
+	return err
+}
+
+func (r *pgWebhookRepository) CreateEntrega(ctx context.Context, tx pgx.Tx, e *models.EntregaWebhook) error {
+	sql := `
+		INSERT INTO webhook_entregas (id, assinatura_id, evento_id, status, tentativas, proxima_tentativa_em, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (assinatura_id, evento_id) DO NOTHING`
+	_, err := tx.Exec(ctx, sql, e.ID, e.AssinaturaID, e.EventoID, e.Status, e.Tentativas, e.ProximaTentativaEm, e.CreatedAt, e.UpdatedAt)
+	return err
+}
+
+func (r *pgWebhookRepository) CreateAssinatura(ctx context.Context, a *models.WebhookAssinatura) error {
+	sql := `
+		INSERT INTO webhook_assinaturas (id, url, segredo, eventos, ativo, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.Exec(ctx, sql, a.ID, a.URL, a.Segredo, eventosParaTexto(a.Eventos), a.Ativo, a.CreatedAt, a.UpdatedAt)
+	return err
+}
+
+const assinaturaColumns = `id, url, segredo, eventos, ativo, created_at, updated_at`
+
+func scanAssinatura(row pgx.Row) (*models.WebhookAssinatura, error) {
+	var a models.WebhookAssinatura
+	var eventos []string
+	if err := row.Scan(&a.ID, &a.URL, &a.Segredo, &eventos, &a.Ativo, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	a.Eventos = make([]models.TipoEventoWebhook, len(eventos))
+	for i, e := range eventos {
+		a.Eventos[i] = models.TipoEventoWebhook(e)
+	}
+	return &a, nil
+}
+
+func (r *pgWebhookRepository) FindAssinaturas(ctx context.Context) ([]models.WebhookAssinatura, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+assinaturaColumns+` FROM webhook_assinaturas ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assinaturas []models.WebhookAssinatura
+	for rows.Next() {
+		a, err := scanAssinatura(rows)
+		if err != nil {
+			return nil, err
+		}
+		assinaturas = append(assinaturas, *a)
+	}
+	return assinaturas, rows.Err()
+}
+
+func (r *pgWebhookRepository) FindAssinaturaByID(ctx context.Context, id string) (*models.WebhookAssinatura, error) {
+	a, err := scanAssinatura(r.db.QueryRow(ctx, `SELECT `+assinaturaColumns+` FROM webhook_assinaturas WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return a, err
+}
+
+func (r *pgWebhookRepository) DeleteAssinatura(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM webhook_assinaturas WHERE id = $1`, id)
+	return err
+}
+
+const entregaColumns = `e.id, e.assinatura_id, e.evento_id, o.tipo, e.status, e.tentativas, e.proxima_tentativa_em,
+	e.ultimo_status_http, e.ultimo_erro, e.entregue_em, e.created_at, e.updated_at`
+
+func scanEntrega(row pgx.Row, extras ...any) (*models.EntregaWebhook, error) {
+	var e models.EntregaWebhook
+	destinos := append([]any{&e.ID, &e.AssinaturaID, &e.EventoID, &e.Evento, &e.Status, &e.Tentativas, &e.ProximaTentativaEm,
+		&e.UltimoStatusHTTP, &e.UltimoErro, &e.EntregueEm, &e.CreatedAt, &e.UpdatedAt}, extras...)
+	if err := row.Scan(destinos...); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *pgWebhookRepository) ReservarEnvios(ctx context.Context, agora, reservaAte time.Time, limite int) ([]models.EnvioWebhook, error) {
+	sql := `
+		WITH reservadas AS (
+			SELECT e.id
+			FROM webhook_entregas e
+			JOIN webhook_assinaturas a ON a.id = e.assinatura_id
+			WHERE e.status = 'PENDENTE' AND e.proxima_tentativa_em <= $1 AND a.ativo
+			ORDER BY e.proxima_tentativa_em
+			LIMIT $3
+			FOR UPDATE OF e SKIP LOCKED
+		)
+		UPDATE webhook_entregas e
+		SET proxima_tentativa_em = $2
+		FROM reservadas r, webhook_assinaturas a, eventos_outbox o
+		WHERE e.id = r.id AND a.id = e.assinatura_id AND o.id = e.evento_id
+		RETURNING ` + entregaColumns + `, a.url, a.segredo, o.dados, o.created_at`
+	rows, err := r.db.Query(ctx, sql, agora, reservaAte, limite)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var envios []models.EnvioWebhook
+	for rows.Next() {
+		var envio models.EnvioWebhook
+		entrega, err := scanEntrega(rows, &envio.URL, &envio.Segredo, &envio.Evento.Dados, &envio.Evento.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		envio.Entrega = *entrega
+		envio.Evento.ID, envio.Evento.Tipo = entrega.EventoID, entrega.Evento
+		envios = append(envios, envio)
+	}
+	return envios, rows.Err()
+}
+
+func (r *pgWebhookRepository) RegistrarTentativa(ctx context.Context, e *models.EntregaWebhook) error {
+	sql := `
+		UPDATE webhook_entregas
+		SET status = $2, tentativas = $3, proxima_tentativa_em = $4, ultimo_status_http = $5,
+		    ultimo_erro = $6, entregue_em = $7, updated_at = $8
+		WHERE id = $1`
+	_, err := r.db.Exec(ctx, sql, e.ID, e.Status, e.Tentativas, e.ProximaTentativaEm, e.UltimoStatusHTTP, e.UltimoErro, e.EntregueEm, e.UpdatedAt)
+	return err
+}
+
+// FindEntregas lista as entregas mais recentes da assinatura, das mais novas para as mais antigas.
+func (r *pgWebhookRepository) FindEntregas(ctx context.Context, assinaturaID string, limite int) ([]models.EntregaWebhook, error) {
+	sql := `
+		SELECT ` + entregaColumns + `
+		FROM webhook_entregas e
+		JOIN eventos_outbox o ON o.id = e.evento_id
+		WHERE e.assinatura_id = $1
+		ORDER BY e.created_at DESC
+		LIMIT $2`
+	rows, err := r.db.Query(ctx, sql, assinaturaID, limite)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entregas []models.EntregaWebhook
+	for rows.Next() {
+		e, err := scanEntrega(rows)
+		if err != nil {
+			return nil, err
+		}
+		entregas = append(entregas, *e)
+	}
+	return entregas, rows.Err()
+}
+
+func (r *pgWebhookRepository) FindEntregaByID(ctx context.Context, id string) (*models.EntregaWebhook, error) {
+	sql := `
+		SELECT ` + entregaColumns + `
+		FROM webhook_entregas e
+		JOIN eventos_outbox o ON o.id = e.evento_id
+		WHERE e.id = $1`
+	e, err := scanEntrega(r.db.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return e, err
+}
+
+// ReagendarEntrega volta a entrega para pendente, com um novo ciclo de tentativas a partir de 'em'.
+func (r *pgWebhookRepository) ReagendarEntrega(ctx context.Context, id string, em time.Time) error {
+	sql := `
+		UPDATE webhook_entregas
+		SET status = 'PENDENTE', tentativas = 0, proxima_tentativa_em = $2, entregue_em = NULL, updated_at = $2
+		WHERE id = $1`
+	_, err := r.db.Exec(ctx, sql, id, em)
+	return err
+}
@@ -0,0 +1,270 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"controlador/backend/internal/handlers"
+	"controlador/backend/internal/middleware"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+	"controlador/backend/internal/services"
+)
+
+// Os repositórios abaixo guardam os dados em memória; só implementam os métodos que os serviços
+// exercitados pelos testes de contrato chamam.
+
+type ativoRepoFake struct {
+	repositories.AtivoRepository
+	ativos []models.AtivoFinanceiro
+}
+
+func (f *ativoRepoFake) FindAll(ctx context.Context) ([]models.AtivoFinanceiro, error) {
+	return f.ativos, nil
+}
+
+func (f *ativoRepoFake) FindByID(ctx context.Context, id string) (*models.AtivoFinanceiro, error) {
+	for _, a := range f.ativos {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+type categoriaRepoFake struct {
+	repositories.CategoriaRepository
+	mu         sync.Mutex
+	categorias []models.Categoria
+	falha      error
+}
+
+func (f *categoriaRepoFake) Create(ctx context.Context, categoria *models.Categoria) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.categorias = append(f.categorias, *categoria)
+	return nil
+}
+
+func (f *categoriaRepoFake) FindAll(ctx context.Context) ([]models.Categoria, error) {
+	return f.categorias, f.falha
+}
+
+func (f *categoriaRepoFake) FindByID(ctx context.Context, id string) (*models.Categoria, error) {
+	for _, c := range f.categorias {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *categoriaRepoFake) FindByName(ctx context.Context, nome string) (*models.Categoria, error) {
+	for _, c := range f.categorias {
+		if c.Nome == nome {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+type recorrenciaRepoFake struct {
+	repositories.TransacaoRecorrenteRepository
+	recorrencias []models.TransacaoRecorrente
+}
+
+func (f *recorrenciaRepoFake) Create(ctx context.Context, t *models.TransacaoRecorrente) error {
+	f.recorrencias = append(f.recorrencias, *t)
+	return nil
+}
+
+func (f *recorrenciaRepoFake) FindAllByAtivoID(ctx context.Context, ativoID string) ([]models.TransacaoRecorrente, error) {
+	var lista []models.TransacaoRecorrente
+	for _, r := range f.recorrencias {
+		if r.AtivoFinanceiroID == ativoID {
+			lista = append(lista, r)
+		}
+	}
+	return lista, nil
+}
+
+type taxaCambioRepoFake struct {
+	repositories.TaxaCambioRepository
+	taxas []models.TaxaCambio
+}
+
+func (f *taxaCambioRepoFake) Save(ctx context.Context, taxa *models.TaxaCambio) error {
+	f.taxas = append(f.taxas, *taxa)
+	return nil
+}
+
+func (f *taxaCambioRepoFake) FindAll(ctx context.Context, moedaOrigem, moedaDestino string) ([]models.TaxaCambio, error) {
+	return f.taxas, nil
+}
+
+// contrato envia requisições ao roteador com os handlers reais e confere cada resposta com a
+// especificação publicada em /api/docs/openapi.json.
+type contrato struct {
+	t         *testing.T
+	router    http.Handler
+	docs      *handlers.DocsHandler
+	categoria *categoriaRepoFake
+}
+
+func novoContrato(t *testing.T) *contrato {
+	ativos := &ativoRepoFake{ativos: []models.AtivoFinanceiro{
+		{ID: "conta", Instituicao: "Banco", Nome: "Conta corrente", Tipo: models.AtivoContaCorrente, SaldoAtual: -150, LimiteChequeEspecial: 1000, Moeda: "BRL", IsActive: true},
+		{ID: "cartao", Instituicao: "Banco", Nome: "Cartão", Tipo: models.AtivoCartaoCredito, LimiteTotal: 2000, LimiteDisponivel: 1800, Moeda: "USD", IsActive: true},
+	}}
+	categorias := &categoriaRepoFake{categorias: []models.Categoria{{ID: "mercado", Nome: "Mercado", Icone: "cart"}}}
+	recorrencias := &recorrenciaRepoFake{}
+	taxas := &taxaCambioRepoFake{}
+
+	h := handlersDeTeste{
+		ativo:     handlers.NewAtivoHandler(nil, services.NewListAtivosService(ativos), services.NewGetAtivoService(ativos), nil, nil, nil, nil, nil, nil),
+		categoria: handlers.NewCategoriaHandler(services.NewCreateCategoriaService(categorias), services.NewListCategoriasService(categorias)),
+		recorrente: handlers.NewTransacaoRecorrenteHandler(
+			services.NewCreateTransacaoRecorrenteService(recorrencias, ativos, categorias), services.NewListTransacoesRecorrentesService(recorrencias), nil),
+		cambio:        handlers.NewCambioHandler(services.NewCreateTaxaCambioService(taxas), services.NewListTaxasCambioService(taxas)),
+		financiamento: handlers.NewFinanciamentoHandler(services.NewSimularFinanciamentoService(), nil, nil, nil, nil, nil, nil),
+	}
+	r, docs := roteador(t, h)
+	return &contrato{t: t, router: r, docs: docs, categoria: categorias}
+}
+
+// requisitar envia a requisição e confere o status esperado e a resposta com a operação 'rota',
+// o caminho na sintaxe do gin. Devolve o corpo da resposta.
+func (c *contrato) requisitar(metodo, rota, url, corpo string, status int) []byte {
+	c.t.Helper()
+	req := httptest.NewRequest(metodo, url, strings.NewReader(corpo))
+	if corpo != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	c.router.ServeHTTP(w, req)
+
+	if w.Code != status {
+		c.t.Errorf("%s %s: status %d, esperado %d; corpo: %s", metodo, url, w.Code, status, w.Body.String())
+	}
+	if err := c.docs.Especificacao().ValidarResposta(metodo, rota, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes()); err != nil {
+		c.t.Error(err)
+	}
+	return w.Body.Bytes()
+}
+
+func TestContratoRespostas(t *testing.T) {
+	c := novoContrato(t)
+
+	casos := []struct {
+		nome   string
+		metodo string
+		rota   string
+		url    string
+		corpo  string
+		status int
+	}{
+		{"ping", http.MethodGet, "/ping", "/ping", "", http.StatusOK},
+		{"página da documentação", http.MethodGet, "/api/docs", "/api/docs", "", http.StatusOK},
+		{"especificação", http.MethodGet, "/api/docs/openapi.json", "/api/docs/openapi.json", "", http.StatusOK},
+		{"script da documentação", http.MethodGet, "/api/docs/arquivos/:arquivo", "/api/docs/arquivos/visualizador.js", "", http.StatusOK},
+		{"estilo da documentação", http.MethodGet, "/api/docs/arquivos/:arquivo", "/api/docs/arquivos/visualizador.css", "", http.StatusOK},
+		{"arquivo da documentação inexistente", http.MethodGet, "/api/docs/arquivos/:arquivo", "/api/docs/arquivos/nada.js", "", http.StatusNotFound},
+
+		{"lista os ativos", http.MethodGet, "/api/v1/ativos", "/api/v1/ativos", "", http.StatusOK},
+		{"busca um ativo", http.MethodGet, "/api/v1/ativos/:id", "/api/v1/ativos/conta", "", http.StatusOK},
+		{"ativo inexistente", http.MethodGet, "/api/v1/ativos/:id", "/api/v1/ativos/nada", "", http.StatusNotFound},
+
+		{"cria uma categoria", http.MethodPost, "/api/v1/categorias", "/api/v1/categorias", `{"nome": "Lazer", "icone": "bola"}`, http.StatusCreated},
+		{"categoria repetida", http.MethodPost, "/api/v1/categorias", "/api/v1/categorias", `{"nome": "Mercado"}`, http.StatusConflict},
+		{"lista as categorias", http.MethodGet, "/api/v1/categorias", "/api/v1/categorias", "", http.StatusOK},
+
+		{"cria uma recorrência", http.MethodPost, "/api/v1/recorrencias", "/api/v1/recorrencias",
+			`{"ativo_financeiro_id": "conta", "categoria_id": "mercado", "descricao": "Feira", "valor": 80, "tipo": "DEBITO", "dia_do_vencimento": 31, "tags": ["casa"]}`, http.StatusCreated},
+		{"recorrência em ativo inexistente", http.MethodPost, "/api/v1/recorrencias", "/api/v1/recorrencias",
+			`{"ativo_financeiro_id": "nada", "categoria_id": "mercado", "valor": 80, "tipo": "DEBITO", "dia_do_vencimento": 5}`, http.StatusNotFound},
+		{"recorrência com dia inválido", http.MethodPost, "/api/v1/recorrencias", "/api/v1/recorrencias",
+			`{"ativo_financeiro_id": "conta", "categoria_id": "mercado", "valor": 80, "tipo": "DEBITO", "dia_do_vencimento": 40}`, http.StatusUnprocessableEntity},
+		{"recorrência com tipo desconhecido", http.MethodPost, "/api/v1/recorrencias", "/api/v1/recorrencias",
+			`{"ativo_financeiro_id": "conta", "categoria_id": "mercado", "valor": 80, "tipo": "PIX", "dia_do_vencimento": 5}`, http.StatusBadRequest},
+		{"lista as recorrências do ativo", http.MethodGet, "/api/v1/ativos/:id/recorrencias", "/api/v1/ativos/conta/recorrencias", "", http.StatusOK},
+
+		{"registra uma taxa de câmbio", http.MethodPost, "/api/v1/taxas-cambio", "/api/v1/taxas-cambio",
+			`{"moeda_origem": "usd", "moeda_destino": "BRL", "data": "2026-10-01", "taxa": 5.4}`, http.StatusCreated},
+		{"taxa com moedas iguais", http.MethodPost, "/api/v1/taxas-cambio", "/api/v1/taxas-cambio",
+			`{"moeda_origem": "BRL", "moeda_destino": "BRL", "taxa": 1}`, http.StatusUnprocessableEntity},
+		{"taxa com data inválida", http.MethodPost, "/api/v1/taxas-cambio", "/api/v1/taxas-cambio",
+			`{"moeda_origem": "USD", "moeda_destino": "BRL", "data": "01/10/2026", "taxa": 5.4}`, http.StatusBadRequest},
+		{"lista as taxas de câmbio", http.MethodGet, "/api/v1/taxas-cambio", "/api/v1/taxas-cambio", "", http.StatusOK},
+
+		{"simula um financiamento", http.MethodPost, "/api/v1/financiamentos/simular", "/api/v1/financiamentos/simular",
+			`{"sistema": "PRICE", "valor_principal": 12000, "taxa_juros_mensal": 0.01, "prazo_meses": 12, "data_primeira_parcela": "2026-11-10"}`, http.StatusOK},
+		{"simulação sem prazo", http.MethodPost, "/api/v1/financiamentos/simular", "/api/v1/financiamentos/simular",
+			`{"sistema": "SAC", "valor_principal": 12000, "taxa_juros_mensal": 0.01}`, http.StatusUnprocessableEntity},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			c.t = t
+			c.requisitar(caso.metodo, caso.rota, caso.url, caso.corpo, caso.status)
+		})
+	}
+}
+
+func TestContratoErroInterno(t *testing.T) {
+	c := novoContrato(t)
+	c.categoria.falha = errors.New("conexão recusada")
+
+	corpo := c.requisitar(http.MethodGet, "/api/v1/categorias", "/api/v1/categorias", "", http.StatusInternalServerError)
+	if strings.Contains(string(corpo), "conexão recusada") {
+		t.Errorf("erro interno exposto ao cliente: %s", corpo)
+	}
+}
+
+// Corpos que não seguem o esquema publicado são recusados antes de chegar ao handler.
+func TestContratoValidaRequisicao(t *testing.T) {
+	casos := []struct {
+		nome   string
+		rota   string
+		corpo  string
+		campos []string
+	}{
+		{"campo obrigatório ausente", "/api/v1/categorias", `{"icone": "tag"}`, []string{"nome"}},
+		{"tipo errado", "/api/v1/categorias", `{"nome": 5}`, []string{"nome"}},
+		{"corpo que não é objeto", "/api/v1/categorias", `["Mercado"]`, []string{"corpo"}},
+		{"obrigatórios da operação", "/api/v1/recorrencias", `{"descricao": "Feira", "valor": "80"}`,
+			[]string{"ativo_financeiro_id", "categoria_id", "dia_do_vencimento", "tipo", "valor"}},
+		{"item de lista", "/api/v1/recorrencias",
+			`{"ativo_financeiro_id": "conta", "categoria_id": "mercado", "valor": 80, "tipo": "DEBITO", "dia_do_vencimento": 5.5, "tags": ["casa", 1]}`,
+			[]string{"dia_do_vencimento", "tags[1]"}},
+	}
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			c := novoContrato(t)
+			antes := len(c.categoria.categorias)
+
+			corpo := c.requisitar(http.MethodPost, caso.rota, caso.rota, caso.corpo, http.StatusBadRequest)
+			var problema middleware.Problema
+			if err := json.Unmarshal(corpo, &problema); err != nil {
+				t.Fatalf("corpo da resposta: %v", err)
+			}
+			if problema.Code != "dados_invalidos" {
+				t.Errorf("code = %q, esperado dados_invalidos", problema.Code)
+			}
+			var campos []string
+			for _, e := range problema.Errors {
+				campos = append(campos, e.Campo)
+			}
+			if strings.Join(campos, ",") != strings.Join(caso.campos, ",") {
+				t.Errorf("campos = %v, esperado %v", campos, caso.campos)
+			}
+			if len(c.categoria.categorias) != antes {
+				t.Error("o handler foi chamado com um corpo inválido")
+			}
+		})
+	}
+}
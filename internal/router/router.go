@@ -2,6 +2,8 @@ package router
 
 import (
 	"controlador/backend/internal/handlers"
+	"controlador/backend/internal/middleware"
+	"controlador/backend/internal/services"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -14,10 +16,37 @@ func SetupRouter(
 	transacaoHandler *handlers.TransacaoHandler,
 	categoriaHandler *handlers.CategoriaHandler,
 	transacaoRecorrenteHandler *handlers.TransacaoRecorrenteHandler,
+	relatorioHandler *handlers.RelatorioHandler,
+	tagHandler *handlers.TagHandler,
+	anexoHandler *handlers.AnexoHandler,
+	regraHandler *handlers.RegraHandler,
+	conciliacaoHandler *handlers.ConciliacaoHandler,
+	cambioHandler *handlers.CambioHandler,
+	transferenciaHandler *handlers.TransferenciaHandler,
+	investimentoHandler *handlers.InvestimentoHandler,
+	financiamentoHandler *handlers.FinanciamentoHandler,
+	metaHandler *handlers.MetaHandler,
+	notificacaoHandler *handlers.NotificacaoHandler,
+	orcamentoHandler *handlers.OrcamentoHandler,
+	webhookHandler *handlers.WebhookHandler,
+	streamHandler *handlers.StreamHandler,
+	exportacaoHandler *handlers.ExportacaoHandler,
+	backupHandler *handlers.BackupHandler,
+	idempotenciaHandler *handlers.IdempotenciaHandler,
+	docsHandler *handlers.DocsHandler,
+	idempotenciaSvc *services.IdempotenciaService,
 ) *gin.Engine {
 	router := gin.New()
 	router.Use(ginZerologLogger())
-	router.Use(gin.Recovery())
+	router.Use(middleware.Recuperacao())
+	// Rotas que alteram dados aceitam o cabeçalho Idempotency-Key para tolerar reenvios do cliente.
+	router.Use(middleware.Idempotencia(idempotenciaSvc))
+	// Os erros registrados pelos handlers viram respostas problem+json; fica depois da
+	// idempotência para que a resposta de erro também seja guardada.
+	router.Use(middleware.Erros())
+	// Os corpos JSON são conferidos com a especificação publicada em /api/docs antes dos handlers.
+	router.Use(middleware.ValidarRequisicao(docsHandler.Especificacao()))
+	router.NoRoute(middleware.RotaNaoEncontrada)
 
 	router.GET("/ping", func(c *gin.Context) {
 		log.Debug().Msg("Recebida requisição na rota /ping")
@@ -29,29 +58,144 @@ func SetupRouter(
 		// Rotas de Ativos
 		apiV1.POST("/ativos", ativoHandler.CreateAtivoFinanceiro)
 		apiV1.GET("/ativos", ativoHandler.GetAtivosFinanceiros)
+		apiV1.GET("/ativos/:id", ativoHandler.GetAtivoFinanceiro)
+		apiV1.PATCH("/ativos/:id", ativoHandler.UpdateAtivoFinanceiro)
 		apiV1.DELETE("/ativos/:id", ativoHandler.DeactivateAtivoFinanceiro)
+		apiV1.POST("/ativos/:id/reativar", ativoHandler.ReactivateAtivoFinanceiro)
+		apiV1.POST("/ativos/:id/recalcular-saldo", ativoHandler.RecalcularSaldo)
+		apiV1.GET("/ativos/:id/saldo-projetado", ativoHandler.GetSaldoProjetado)
 
 		// Rotas de Transações
 		apiV1.POST("/transacoes", transacaoHandler.CreateTransacao)
 		apiV1.GET("/transacoes", transacaoHandler.GetTransacoes)
+		apiV1.GET("/transacoes/duplicatas", transacaoHandler.GetDuplicatas)
+		apiV1.GET("/transacoes/exportar", exportacaoHandler.ExportarTransacoes)
 		// ALTERAÇÃO: Nova rota para estornar uma transação.
 		apiV1.POST("/transacoes/:id/reverter", transacaoHandler.ReverseTransacao)
+		apiV1.PATCH("/transacoes/:id", transacaoHandler.UpdateTransacao)
+		apiV1.DELETE("/transacoes/:id", transacaoHandler.DeleteTransacao)
+		apiV1.GET("/transacoes/:id/historico", transacaoHandler.GetTransacaoHistorico)
+		apiV1.POST("/transacoes/:id/mesclar", transacaoHandler.MesclarTransacoes)
+		apiV1.POST("/transacoes/:id/efetivar", transacaoHandler.EfetivarTransacao)
+		apiV1.POST("/transacoes/:id/cancelar", transacaoHandler.CancelarTransacao)
+
+		// Rotas de Transferências e Câmbio
+		apiV1.POST("/transferencias", transferenciaHandler.CreateTransferencia)
+		apiV1.POST("/transferencias/:id/efetivar", transferenciaHandler.EfetivarTransferencia)
+		apiV1.POST("/taxas-cambio", cambioHandler.CreateTaxaCambio)
+		apiV1.GET("/taxas-cambio", cambioHandler.GetTaxasCambio)
+
+		// Rotas de Investimentos
+		apiV1.POST("/investimentos/titulos", investimentoHandler.CreateTitulo)
+		apiV1.GET("/investimentos/titulos", investimentoHandler.GetTitulos)
+		apiV1.POST("/investimentos/titulos/:id/cotacoes", investimentoHandler.RegistrarCotacao)
+		apiV1.POST("/investimentos/cotacoes/importar", investimentoHandler.ImportarCotacoes)
+		apiV1.POST("/investimentos/operacoes", investimentoHandler.CreateOperacao)
+		apiV1.GET("/investimentos/operacoes", investimentoHandler.GetOperacoes)
+		apiV1.DELETE("/investimentos/operacoes/:id", investimentoHandler.DeleteOperacao)
+		apiV1.GET("/investimentos/posicoes", investimentoHandler.GetPosicoes)
+		apiV1.GET("/investimentos/alocacao", investimentoHandler.GetAlocacao)
+
+		// Rotas de Financiamentos
+		apiV1.POST("/financiamentos/simular", financiamentoHandler.SimularFinanciamento)
+		apiV1.POST("/financiamentos", financiamentoHandler.CreateFinanciamento)
+		apiV1.GET("/financiamentos", financiamentoHandler.GetFinanciamentos)
+		apiV1.GET("/financiamentos/:id", financiamentoHandler.GetFinanciamento)
+		apiV1.POST("/financiamentos/:id/pagar-parcela", financiamentoHandler.PagarParcela)
+		apiV1.POST("/financiamentos/:id/amortizacoes", financiamentoHandler.AmortizarFinanciamento)
+
+		// Rotas de Metas
+		apiV1.POST("/metas", metaHandler.CreateMeta)
+		apiV1.GET("/metas", metaHandler.GetMetas)
+		apiV1.GET("/metas/em-risco", metaHandler.GetMetasEmRisco)
+		apiV1.GET("/metas/:id", metaHandler.GetMeta)
+		apiV1.DELETE("/metas/:id", metaHandler.DeleteMeta)
+
+		// Rotas de Conciliação
+		apiV1.POST("/ativos/:id/conciliacoes", conciliacaoHandler.CreateConciliacao)
+		apiV1.GET("/ativos/:id/conciliacoes", conciliacaoHandler.ListConciliacoes)
+		apiV1.GET("/conciliacoes/:id", conciliacaoHandler.GetConciliacao)
+		apiV1.POST("/conciliacoes/:id/transacoes", conciliacaoHandler.MarcarTransacoes)
+		apiV1.POST("/conciliacoes/:id/concluir", conciliacaoHandler.ConcluirConciliacao)
+		apiV1.DELETE("/conciliacoes/:id", conciliacaoHandler.DeleteConciliacao)
+		apiV1.POST("/transacoes/:id/desbloquear", conciliacaoHandler.DesbloquearTransacao)
+
+		// Rotas de Anexos
+		apiV1.POST("/transacoes/:id/anexos", anexoHandler.UploadAnexo)
+		apiV1.GET("/transacoes/:id/anexos", anexoHandler.ListAnexos)
+		apiV1.GET("/anexos/:id", anexoHandler.DownloadAnexo)
+		apiV1.GET("/anexos/:id/miniatura", anexoHandler.DownloadMiniatura)
+		apiV1.DELETE("/anexos/:id", anexoHandler.DeleteAnexo)
 
 		// Rotas de Categorias
 		apiV1.POST("/categorias", categoriaHandler.CreateCategoria)
 		apiV1.GET("/categorias", categoriaHandler.GetCategorias)
 
+		// Rotas de Orçamentos
+		apiV1.GET("/orcamentos", orcamentoHandler.GetOrcamentos)
+		apiV1.PUT("/categorias/:id/orcamento", orcamentoHandler.SalvarOrcamento)
+		apiV1.DELETE("/categorias/:id/orcamento", orcamentoHandler.DeleteOrcamento)
+
+		// Rotas de Notificações
+		apiV1.GET("/notificacoes", notificacaoHandler.GetNotificacoes)
+		apiV1.POST("/notificacoes/:id/lida", notificacaoHandler.MarcarLida)
+		apiV1.POST("/notificacoes/lidas", notificacaoHandler.MarcarTodasLidas)
+		apiV1.GET("/notificacoes/preferencias", notificacaoHandler.GetPreferencias)
+		apiV1.PUT("/notificacoes/preferencias/:evento", notificacaoHandler.UpdatePreferencia)
+
+		// Rotas de Webhooks
+		apiV1.POST("/webhooks", webhookHandler.CreateWebhook)
+		apiV1.GET("/webhooks", webhookHandler.GetWebhooks)
+		apiV1.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+		apiV1.GET("/webhooks/:id/entregas", webhookHandler.GetEntregas)
+		apiV1.POST("/webhooks/entregas/:id/reenviar", webhookHandler.ReenviarEntrega)
+
 		// Rotas de Transações Recorrentes
 		apiV1.POST("/recorrencias", transacaoRecorrenteHandler.CreateTransacaoRecorrente)
 		// CORREÇÃO: Esta rota estava causando o 404 e agora está corretamente registrada.
 		apiV1.GET("/ativos/:id/recorrencias", transacaoRecorrenteHandler.ListTransacoesRecorrentesPorAtivo)
+
+		// Rotas de Regras de Categorização
+		apiV1.POST("/regras", regraHandler.CreateRegra)
+		apiV1.GET("/regras", regraHandler.GetRegras)
+		apiV1.DELETE("/regras/:id", regraHandler.DeleteRegra)
+		apiV1.POST("/regras/aplicar", regraHandler.AplicarRegras)
+
+		// Rotas de Tags
+		apiV1.GET("/tags", tagHandler.GetTags)
+
+		// Rotas de Relatórios
+		apiV1.GET("/relatorios/categorias", relatorioHandler.GetRelatorioCategorias)
+		apiV1.GET("/relatorios/tags", relatorioHandler.GetRelatorioTags)
+		apiV1.GET("/relatorios/mensal", relatorioHandler.GetRelatorioMensal)
+		apiV1.GET("/relatorios/categorias/exportar", exportacaoHandler.ExportarRelatorioCategorias)
+		apiV1.GET("/relatorios/mensal/exportar", exportacaoHandler.ExportarRelatorioMensal)
+
+		// Stream de saldos e transações (Server-Sent Events)
+		apiV1.GET("/stream", streamHandler.GetStream)
+
+		// Backup e restauração
+		apiV1.GET("/backup", backupHandler.GetBackup)
+		apiV1.POST("/backup/restaurar", backupHandler.RestaurarBackup)
 	}
 
 	admin := router.Group("/admin")
 	{
 		admin.POST("/workers/processar-recorrencias", transacaoRecorrenteHandler.ProcessarRecorrencias)
+		admin.POST("/workers/efetivar-agendadas", transacaoHandler.ProcessarAgendadas)
+		admin.POST("/workers/limpar-chaves-idempotencia", idempotenciaHandler.LimparChavesExpiradas)
+		admin.POST("/workers/juros-cheque-especial", ativoHandler.ProcessarJurosChequeEspecial)
+		admin.POST("/workers/parcelas-financiamento", financiamentoHandler.ProcessarParcelas)
+		admin.POST("/workers/notificacoes", notificacaoHandler.VerificarNotificacoes)
+		admin.POST("/workers/webhooks", webhookHandler.DespacharWebhooks)
+		admin.POST("/workers/limpar-eventos-stream", streamHandler.LimparEventosStream)
 	}
 
+	// Documentação; TestRotasDocumentadas confere que ela descreve todas as rotas acima.
+	router.GET("/api/docs", docsHandler.GetPagina)
+	router.GET("/api/docs/openapi.json", docsHandler.GetEspecificacao)
+	router.GET("/api/docs/arquivos/:arquivo", docsHandler.GetArquivo)
+
 	return router
 }
 
@@ -82,7 +226,11 @@ func ginZerologLogger() gin.HandlerFunc {
 			Str("query", query).
 			Str("ip", c.ClientIP()).
 			Dur("latency", latency).
-			Str("user_agent", c.Request.UserAgent()).
-			Msg("Requisição HTTP Recebida")
+			Str("user_agent", c.Request.UserAgent())
+		// O erro original, inclusive o interno que não é exposto ao cliente, só aparece no log.
+		if len(c.Errors) > 0 {
+			logEvent = logEvent.Err(c.Errors.Last().Err)
+		}
+		logEvent.Msg("Requisição HTTP Recebida")
 	}
 }
\ No newline at end of file
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"controlador/backend/internal/handlers"
+)
+
+// roteador monta as rotas de SetupRouter com os handlers informados; os ausentes ficam nil, o
+// que basta para registrar as rotas enquanto elas não são chamadas.
+func roteador(t *testing.T, h handlersDeTeste) (*gin.Engine, *handlers.DocsHandler) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	docs, err := handlers.NewDocsHandler()
+	if err != nil {
+		t.Fatalf("NewDocsHandler: %v", err)
+	}
+	r := SetupRouter(h.ativo, nil, h.categoria, h.recorrente, nil, nil, nil, nil, nil, h.cambio, nil, nil, h.financiamento,
+		nil, nil, nil, nil, nil, nil, nil, nil, docs, nil)
+	return r, docs
+}
+
+type handlersDeTeste struct {
+	ativo         *handlers.AtivoHandler
+	categoria     *handlers.CategoriaHandler
+	recorrente    *handlers.TransacaoRecorrenteHandler
+	cambio        *handlers.CambioHandler
+	financiamento *handlers.FinanciamentoHandler
+}
+
+// Toda rota registrada precisa estar descrita na documentação, pelo handler que a atende, e toda
+// operação documentada precisa ter rota.
+func TestRotasDocumentadas(t *testing.T) {
+	r, docs := roteador(t, handlersDeTeste{})
+	if err := docs.Verificar(r.Routes()); err != nil {
+		t.Fatal(err)
+	}
+}
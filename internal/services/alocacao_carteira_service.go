@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"controlador/backend/internal/models"
+)
+
+type AlocacaoCarteiraService struct {
+	posicoesSvc *PosicoesInvestimentoService
+	conversor   *ConversorMoedas
+	moedaBase   string
+}
+
+func NewAlocacaoCarteiraService(posicoesSvc *PosicoesInvestimentoService, conversor *ConversorMoedas, moedaBase string) *AlocacaoCarteiraService {
+	return &AlocacaoCarteiraService{posicoesSvc: posicoesSvc, conversor: conversor, moedaBase: moedaBase}
+}
+
+// Execute distribui o valor de mercado das posições em aberto por classe e por título,
+// convertido para 'moeda' (a moeda base, se vazia) pela taxa de câmbio de hoje.
+func (s *AlocacaoCarteiraService) Execute(ctx context.Context, moeda string) (*models.AlocacaoCarteira, error) {
+	if moeda == "" {
+		moeda = s.moedaBase
+	}
+	moeda, err := models.ParseMoeda(moeda)
+	if err != nil {
+		return nil, ErrMoedaInvalida
+	}
+
+	posicoes, err := s.posicoesSvc.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alocacao := &models.AlocacaoCarteira{Moeda: moeda}
+	porClasse := make(map[string]float64)
+	porTitulo := make(map[string]float64)
+	hoje := time.Now()
+	for _, p := range posicoes {
+		if p.Quantidade == 0 {
+			continue
+		}
+		taxa, err := s.conversor.Taxa(ctx, p.Titulo.Moeda, moeda, hoje)
+		if err != nil {
+			return nil, err
+		}
+		valor := p.ValorMercado * taxa
+		porClasse[string(p.Titulo.Classe)] += valor
+		porTitulo[p.Titulo.Codigo] += valor
+		alocacao.ValorTotal += valor
+	}
+
+	alocacao.PorClasse = itensAlocacao(porClasse, alocacao.ValorTotal)
+	alocacao.PorTitulo = itensAlocacao(porTitulo, alocacao.ValorTotal)
+	alocacao.ValorTotal = math.Round(alocacao.ValorTotal*100) / 100
+	return alocacao, nil
+}
+
+// itensAlocacao converte os valores agrupados em itens com percentual, do maior para o menor.
+func itensAlocacao(valores map[string]float64, total float64) []models.ItemAlocacao {
+	itens := make([]models.ItemAlocacao, 0, len(valores))
+	for chave, valor := range valores {
+		item := models.ItemAlocacao{Chave: chave, Valor: math.Round(valor*100) / 100}
+		if total > 0 {
+			item.Percentual = math.Round(valor/total*10000) / 100
+		}
+		itens = append(itens, item)
+	}
+	sort.Slice(itens, func(i, j int) bool {
+		if itens[i].Valor != itens[j].Valor {
+			return itens[i].Valor > itens[j].Valor
+		}
+		return itens[i].Chave < itens[j].Chave
+	})
+	return itens
+}
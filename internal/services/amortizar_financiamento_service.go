@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrAmortizacaoInvalida    = erros.RegraNegocio("amortizacao_invalida", "a amortização deve ter valor maior que zero e modo PRAZO ou PARCELA")
+	ErrAmortizacaoExcedeSaldo = erros.RegraNegocio("amortizacao_excede_saldo", "a amortização excede o saldo devedor do financiamento")
+	ErrPrazoNaoReduzivel      = erros.RegraNegocio("prazo_nao_reduzivel", "a parcela atual não cobre os juros do novo saldo; amortize reduzindo a parcela")
+)
+
+// AmortizarFinanciamentoInput descreve uma amortização extraordinária. 'Modo' PRAZO mantém o
+// valor das parcelas e elimina as últimas; PARCELA mantém a quantidade e reduz o valor de cada uma.
+type AmortizarFinanciamentoInput struct {
+	Valor float64                     `json:"valor"`
+	Modo  models.ModoAmortizacaoExtra `json:"modo" binding:"required"`
+	Data  time.Time                   `json:"-"`
+}
+
+type AmortizarFinanciamentoService struct {
+	db                *pgxpool.Pool
+	financiamentoRepo repositories.FinanciamentoRepository
+	transacaoRepo     repositories.TransacaoRepository
+	ativoRepo         repositories.AtivoRepository
+	categoriaRepo     repositories.CategoriaRepository
+	eventos           *PublicadorEventos
+}
+
+func NewAmortizarFinanciamentoService(db *pgxpool.Pool, fRepo repositories.FinanciamentoRepository, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, cRepo repositories.CategoriaRepository, eventos *PublicadorEventos) *AmortizarFinanciamentoService {
+	return &AmortizarFinanciamentoService{
+		db:                db,
+		financiamentoRepo: fRepo,
+		transacaoRepo:     tRepo,
+		ativoRepo:         aRepo,
+		categoriaRepo:     cRepo,
+		eventos:           eventos,
+	}
+}
+
+// Execute abate o valor do saldo devedor e refaz as parcelas pendentes a partir do novo saldo,
+// mantendo as datas de vencimento. Os juros da próxima parcela passam a incidir sobre o saldo
+// já amortizado. Uma amortização do saldo inteiro quita o financiamento.
+func (s *AmortizarFinanciamentoService) Execute(ctx context.Context, financiamentoID string, input AmortizarFinanciamentoInput) (*models.Financiamento, error) {
+	valor := math.Round(input.Valor*100) / 100
+	if valor <= 0 || (input.Modo != models.ReduzirPrazo && input.Modo != models.ReduzirParcela) {
+		return nil, ErrAmortizacaoInvalida
+	}
+	data := input.Data
+	if data.IsZero() {
+		data = time.Now()
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Carregar o financiamento e conferir o valor
+	financiamento, err := s.financiamentoRepo.FindByIDForUpdate(ctx, tx, financiamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if financiamento == nil {
+		return nil, ErrFinanciamentoNaoEncontrado
+	}
+	if financiamento.Status == models.FinanciamentoQuitado {
+		return nil, ErrFinanciamentoQuitado
+	}
+	if centavos(valor) > centavos(financiamento.SaldoDevedor) {
+		return nil, ErrAmortizacaoExcedeSaldo
+	}
+	parcelas, err := s.financiamentoRepo.FindParcelasTx(ctx, tx, financiamento.ID)
+	if err != nil {
+		return nil, err
+	}
+	proxima := proximaParcelaPendente(parcelas)
+	if proxima == nil {
+		return nil, ErrFinanciamentoQuitado
+	}
+
+	// 2. Recalcular as parcelas pendentes com o novo saldo
+	financiamento.SaldoDevedor = math.Round((financiamento.SaldoDevedor-valor)*100) / 100
+	var novas []models.ParcelaFinanciamento
+	if centavos(financiamento.SaldoDevedor) == 0 {
+		financiamento.Status = models.FinanciamentoQuitado
+	} else {
+		pendentes := len(parcelas) - len(parcelasPagas(parcelas))
+		prazo := pendentes
+		if input.Modo == models.ReduzirPrazo {
+			prazo = prazoParaParcela(financiamento.Sistema, financiamento.SaldoDevedor, financiamento.TaxaJurosMensal, *proxima)
+			if prazo < 1 {
+				return nil, ErrPrazoNaoReduzivel
+			}
+			prazo = min(prazo, pendentes)
+		}
+		novas = gerarParcelas(financiamento.Sistema, financiamento.SaldoDevedor, financiamento.TaxaJurosMensal, prazo, proxima.DataVencimento, proxima.Numero)
+		for i := range novas {
+			novas[i].FinanciamentoID = financiamento.ID
+		}
+	}
+
+	// 3. Lançar o pagamento extraordinário, todo ele amortização
+	emprestimo, pagamento, err := carregarAtivosFinanciamento(ctx, tx, s.ativoRepo, financiamento)
+	if err != nil {
+		return nil, err
+	}
+	descricao := fmt.Sprintf("Amortização extraordinária - %s", emprestimo.Nome)
+	transacaoID, err := liquidarFinanciamento(ctx, tx, s.transacaoRepo, s.ativoRepo, s.categoriaRepo, s.eventos, emprestimo, pagamento, 0, valor, data, descricao)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Gravar a amortização, o novo cronograma e o saldo
+	agora := time.Now()
+	if err := s.financiamentoRepo.CreateAmortizacaoExtra(ctx, tx, &models.AmortizacaoExtra{
+		ID:              uuid.New().String(),
+		FinanciamentoID: financiamento.ID,
+		Data:            inicioDoDia(data),
+		Valor:           valor,
+		Modo:            input.Modo,
+		TransacaoID:     transacaoID,
+		CreatedAt:       agora,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.financiamentoRepo.DeleteParcelasPendentes(ctx, tx, financiamento.ID); err != nil {
+		return nil, err
+	}
+	if err := s.financiamentoRepo.SaveParcelas(ctx, tx, novas); err != nil {
+		return nil, err
+	}
+	financiamento.UpdatedAt = agora
+	if err := s.financiamentoRepo.AtualizarSaldo(ctx, tx, financiamento); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	financiamento.Parcelas = append(parcelasPagas(parcelas), novas...)
+	return financiamento, nil
+}
+
+// parcelasPagas retorna as parcelas já pagas do cronograma.
+func parcelasPagas(parcelas []models.ParcelaFinanciamento) []models.ParcelaFinanciamento {
+	var resultado []models.ParcelaFinanciamento
+	for _, p := range parcelas {
+		if p.Status == models.ParcelaPaga {
+			resultado = append(resultado, p)
+		}
+	}
+	return resultado
+}
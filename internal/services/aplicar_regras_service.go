@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// AplicarRegrasInput delimita a reaplicação retroativa das regras.
+// Com 'Simular', nada é gravado e apenas as diferenças são retornadas.
+type AplicarRegrasInput struct {
+	Periodo Periodo
+	Simular bool
+}
+
+type AplicarRegrasService struct {
+	db            *pgxpool.Pool
+	regraRepo     repositories.RegraRepository
+	transacaoRepo repositories.TransacaoRepository
+	historicoRepo repositories.TransacaoHistoricoRepository
+}
+
+func NewAplicarRegrasService(db *pgxpool.Pool, rRepo repositories.RegraRepository, tRepo repositories.TransacaoRepository, hRepo repositories.TransacaoHistoricoRepository) *AplicarRegrasService {
+	return &AplicarRegrasService{
+		db:            db,
+		regraRepo:     rRepo,
+		transacaoRepo: tRepo,
+		historicoRepo: hRepo,
+	}
+}
+
+// Execute reaplica as regras às transações do período. Diferente da criação, a categoria
+// existente é substituída quando uma regra a define. Estornos são ignorados: eles seguem a original.
+// Transações bloqueadas por uma conciliação também são ignoradas.
+// Só descrição, categoria e tags mudam, então os saldos dos ativos não são afetados.
+func (s *AplicarRegrasService) Execute(ctx context.Context, input AplicarRegrasInput) ([]models.DiferencaRegra, error) {
+	inicio, fim, err := input.Periodo.Limites()
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Carregar regras e transações
+	regras, err := s.regraRepo.FindAtivas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transacoes, err := s.transacaoRepo.FindAll(ctx, models.FiltroTransacoes{Inicio: inicio, Fim: fim})
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Calcular as diferenças
+	diferencas := []models.DiferencaRegra{}
+	for _, original := range transacoes {
+		if original.ReversalOf != nil || original.Bloqueada {
+			continue
+		}
+		nova := original
+		nova.Tags = append([]string(nil), original.Tags...)
+		aplicadas := aplicarRegras(regras, &nova, false)
+		if len(aplicadas) == 0 {
+			continue
+		}
+		adicionadas := nova.Tags[len(original.Tags):]
+		if nova.CategoriaID == original.CategoriaID && nova.Descricao == original.Descricao && len(adicionadas) == 0 {
+			continue
+		}
+		diferencas = append(diferencas, models.DiferencaRegra{
+			TransacaoID:       original.ID,
+			RegrasAplicadas:   aplicadas,
+			DescricaoAnterior: original.Descricao,
+			DescricaoNova:     nova.Descricao,
+			CategoriaAnterior: original.CategoriaID,
+			CategoriaNova:     nova.CategoriaID,
+			TagsAdicionadas:   adicionadas,
+		})
+	}
+	if input.Simular || len(diferencas) == 0 {
+		return diferencas, nil
+	}
+
+	// 3. Gravar tudo em uma única transação, guardando as versões anteriores no histórico
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	for _, d := range diferencas {
+		// As regras são reaplicadas sobre a versão bloqueada, para não sobrescrever uma edição concorrente.
+		anterior, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, d.TransacaoID)
+		if err != nil {
+			return nil, err
+		}
+		if anterior == nil || anterior.Bloqueada {
+			continue
+		}
+		nova := *anterior
+		nova.Tags = append([]string(nil), anterior.Tags...)
+		aplicarRegras(regras, &nova, false)
+		if err := s.historicoRepo.Create(ctx, tx, &models.TransacaoHistorico{
+			ID:          uuid.New().String(),
+			TransacaoID: anterior.ID,
+			Operacao:    models.HistoricoAtualizacao,
+			Dados:       *anterior,
+			CreatedAt:   now,
+		}); err != nil {
+			return nil, err
+		}
+		if err := s.transacaoRepo.Update(ctx, tx, &nova); err != nil {
+			return nil, err
+		}
+	}
+	return diferencas, tx.Commit(ctx)
+}
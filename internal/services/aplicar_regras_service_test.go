@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type regraRepoFake struct {
+	repositories.RegraRepository
+	regras []models.Regra
+}
+
+func (f *regraRepoFake) FindAtivas(ctx context.Context) ([]models.Regra, error) {
+	return f.regras, nil
+}
+
+type transacaoRepoFiltroFake struct {
+	repositories.TransacaoRepository
+	filtro     *models.FiltroTransacoes
+	transacoes []models.Transacao
+}
+
+func (f *transacaoRepoFiltroFake) FindAll(ctx context.Context, filtro models.FiltroTransacoes) ([]models.Transacao, error) {
+	f.filtro = &filtro
+	return f.transacoes, nil
+}
+
+func TestAplicarRegrasRestringeAoPeriodo(t *testing.T) {
+	regras := &regraRepoFake{regras: []models.Regra{{
+		ID:        "r1",
+		Ativa:     true,
+		Condicoes: []models.CondicaoRegra{{Campo: models.CondicaoDescricao, Operador: models.OperadorContem, Valor: "mercado"}},
+		Acoes:     []models.AcaoRegra{{Tipo: models.AcaoDefinirCategoria, Valor: "cat-mercado"}},
+	}}}
+	transacoes := &transacaoRepoFiltroFake{transacoes: []models.Transacao{
+		{ID: "t1", Descricao: "Mercado Central", CategoriaID: "cat-outros"},
+	}}
+	s := NewAplicarRegrasService(nil, regras, transacoes, nil)
+
+	inicio := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	fim := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	diferencas, err := s.Execute(context.Background(), AplicarRegrasInput{
+		Periodo: Periodo{Inicio: &inicio, Fim: &fim},
+		Simular: true,
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if transacoes.filtro == nil {
+		t.Fatal("FindAll não foi chamado")
+	}
+	if transacoes.filtro.Inicio == nil || !transacoes.filtro.Inicio.Equal(inicio) {
+		t.Errorf("Inicio = %v, esperado %v", transacoes.filtro.Inicio, inicio)
+	}
+	if want := fim.AddDate(0, 0, 1); transacoes.filtro.Fim == nil || !transacoes.filtro.Fim.Equal(want) {
+		t.Errorf("Fim = %v, esperado %v", transacoes.filtro.Fim, want)
+	}
+	if len(diferencas) != 1 || diferencas[0].CategoriaNova != "cat-mercado" {
+		t.Errorf("diferencas = %+v", diferencas)
+	}
+}
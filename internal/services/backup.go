@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+
+	"controlador/backend/internal/erros"
+)
+
+var (
+	ErrBackupInvalido           = erros.RegraNegocio("backup_invalido", "arquivo de backup inválido")
+	ErrVersaoBackupNaoSuportada = erros.RegraNegocio("versao_backup_nao_suportada", "versão do backup não suportada")
+	ErrChecksumBackupInvalido   = erros.RegraNegocio("checksum_backup_invalido", "o checksum do backup não confere: o arquivo foi alterado ou está incompleto")
+	ErrReferenciaBackupInvalida = erros.RegraNegocio("referencia_backup_invalida", "o backup tem referências para registros que não fazem parte dele")
+	ErrModoRestauracaoInvalido  = erros.Invalido("modo_restauracao_invalido", "modo de restauração inválido: use substituir ou mesclar")
+)
+
+// tabelaBackup descreve uma tabela incluída no backup.
+type tabelaBackup struct {
+	nome  string
+	ordem string
+	// referencias liga cada coluna de chave estrangeira à tabela referenciada, para validar o
+	// arquivo antes da carga.
+	referencias map[string]string
+	// chaveNatural faz a restauração mesclada reaproveitar a linha existente com o mesmo valor
+	// nessa coluna, em vez de criar uma nova.
+	chaveNatural string
+	// ignorarConflitos descarta, na restauração mesclada, linhas que repetem uma chave única já
+	// existente; são tabelas que nenhuma outra referencia.
+	ignorarConflitos bool
+}
+
+// tabelasBackup lista as tabelas na ordem de carga, sempre depois das que elas referenciam.
+// Chaves de idempotência, outbox, entregas de webhook e o stream de eventos são transitórios e
+// ficam de fora. Dos anexos só vão os metadados: os arquivos continuam no armazenamento.
+var tabelasBackup = []tabelaBackup{
+	{nome: "ativos_financeiros", ordem: "id"},
+	{nome: "categorias", ordem: "id", chaveNatural: "nome"},
+	{nome: "tags", ordem: "id", chaveNatural: "nome"},
+	{nome: "conciliacoes", ordem: "id", referencias: map[string]string{"ativo_financeiro_id": "ativos_financeiros"}},
+	{nome: "transacoes", ordem: "id", referencias: map[string]string{
+		"ativo_financeiro_id": "ativos_financeiros", "categoria_id": "categorias", "reversal_of": "transacoes", "conciliacao_id": "conciliacoes",
+	}},
+	{nome: "transacao_divisoes", ordem: "id", referencias: map[string]string{"transacao_id": "transacoes", "categoria_id": "categorias"}},
+	{nome: "transacoes_historico", ordem: "id"},
+	{nome: "transacao_tags", ordem: "transacao_id, tag_id", referencias: map[string]string{"transacao_id": "transacoes", "tag_id": "tags"}},
+	{nome: "transacoes_recorrentes", ordem: "id", referencias: map[string]string{"ativo_financeiro_id": "ativos_financeiros", "categoria_id": "categorias"}},
+	{nome: "transacao_recorrente_tags", ordem: "transacao_recorrente_id, tag_id", referencias: map[string]string{
+		"transacao_recorrente_id": "transacoes_recorrentes", "tag_id": "tags",
+	}},
+	{nome: "anexos", ordem: "id", referencias: map[string]string{"transacao_id": "transacoes"}},
+	{nome: "regras", ordem: "id"},
+	{nome: "juros_cheque_especial", ordem: "ativo_financeiro_id, data", referencias: map[string]string{
+		"ativo_financeiro_id": "ativos_financeiros", "transacao_id": "transacoes",
+	}},
+	{nome: "taxas_cambio", ordem: "id", ignorarConflitos: true},
+	{nome: "transferencias", ordem: "id", referencias: map[string]string{"transacao_origem_id": "transacoes", "transacao_destino_id": "transacoes"}},
+	{nome: "titulos", ordem: "id", chaveNatural: "codigo"},
+	{nome: "operacoes_investimento", ordem: "id", referencias: map[string]string{
+		"titulo_id": "titulos", "ativo_financeiro_id": "ativos_financeiros", "transacao_id": "transacoes",
+	}},
+	{nome: "cotacoes_titulos", ordem: "titulo_id, data", referencias: map[string]string{"titulo_id": "titulos"}, ignorarConflitos: true},
+	{nome: "financiamentos", ordem: "id", referencias: map[string]string{"ativo_financeiro_id": "ativos_financeiros", "ativo_pagamento_id": "ativos_financeiros"}},
+	{nome: "parcelas_financiamento", ordem: "id", referencias: map[string]string{"financiamento_id": "financiamentos", "transacao_id": "transacoes"}},
+	{nome: "amortizacoes_extras", ordem: "id", referencias: map[string]string{"financiamento_id": "financiamentos", "transacao_id": "transacoes"}},
+	{nome: "metas", ordem: "id"},
+	{nome: "meta_ativos", ordem: "meta_id, ativo_financeiro_id", referencias: map[string]string{"meta_id": "metas", "ativo_financeiro_id": "ativos_financeiros"}},
+	{nome: "orcamentos", ordem: "categoria_id", referencias: map[string]string{"categoria_id": "categorias"}, ignorarConflitos: true},
+	{nome: "preferencias_notificacao", ordem: "evento", ignorarConflitos: true},
+	{nome: "notificacoes", ordem: "id", ignorarConflitos: true},
+	{nome: "webhook_assinaturas", ordem: "id"},
+}
+
+// tabelasTransitorias são esvaziadas na restauração com substituição, pois descrevem os dados
+// que estão sendo substituídos.
+var tabelasTransitorias = []string{"eventos_outbox", "webhook_entregas", "eventos_stream"}
+
+// validarBackup confere que os IDs não se repetem e que toda chave estrangeira aponta para uma
+// linha presente no próprio backup.
+func validarBackup(dados map[string][]map[string]any) error {
+	ids := make(map[string]map[string]bool, len(tabelasBackup))
+	for _, t := range tabelasBackup {
+		ids[t.nome] = make(map[string]bool)
+		for _, linha := range dados[t.nome] {
+			id, ok := linha["id"].(string)
+			if !ok {
+				continue
+			}
+			if ids[t.nome][id] {
+				return fmt.Errorf("%w: ID %s repetido em %s", ErrBackupInvalido, id, t.nome)
+			}
+			ids[t.nome][id] = true
+		}
+	}
+
+	for _, t := range tabelasBackup {
+		for coluna, alvo := range t.referencias {
+			for _, linha := range dados[t.nome] {
+				valor := linha[coluna]
+				if valor == nil {
+					continue
+				}
+				if id, ok := valor.(string); !ok || !ids[alvo][id] {
+					return fmt.Errorf("%w: %s.%s = %v não existe em %s", ErrReferenciaBackupInvalida, t.nome, coluna, valor, alvo)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// substituirIDs troca, em qualquer ponto do valor, os textos que são IDs remapeados. Isso alcança
+// também IDs guardados dentro de colunas JSON, como as ações das regras e o histórico de transações.
+func substituirIDs(v any, novos map[string]string) any {
+	switch x := v.(type) {
+	case string:
+		if novo, ok := novos[x]; ok {
+			return novo
+		}
+	case map[string]any:
+		for k, e := range x {
+			x[k] = substituirIDs(e, novos)
+		}
+	case []any:
+		for i, e := range x {
+			x[i] = substituirIDs(e, novos)
+		}
+	}
+	return v
+}
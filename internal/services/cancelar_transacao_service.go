@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type CancelarTransacaoService struct {
+	db            *pgxpool.Pool
+	transacaoRepo repositories.TransacaoRepository
+	historicoRepo repositories.TransacaoHistoricoRepository
+}
+
+func NewCancelarTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, hRepo repositories.TransacaoHistoricoRepository) *CancelarTransacaoService {
+	return &CancelarTransacaoService{db: db, transacaoRepo: tRepo, historicoRepo: hRepo}
+}
+
+// Execute cancela uma transação agendada ou pendente. Como ela ainda não alterou o ativo,
+// nada precisa ser desfeito; transações efetivadas devem ser estornadas.
+func (s *CancelarTransacaoService) Execute(ctx context.Context, id string) (*models.Transacao, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	cancelada, err := s.ExecuteTx(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return cancelada, tx.Commit(ctx)
+}
+
+// ExecuteTx cancela a transação dentro de uma transação de banco aberta por quem chama.
+func (s *CancelarTransacaoService) ExecuteTx(ctx context.Context, tx pgx.Tx, id string) (*models.Transacao, error) {
+	original, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, ErrTransacaoNaoEncontrada
+	}
+	if original.Status != models.StatusAgendada && original.Status != models.StatusPendente {
+		return nil, ErrTransacaoNaoPendente
+	}
+	if original.Origem != "" {
+		return nil, ErrTransacaoGerada
+	}
+
+	if err := s.historicoRepo.Create(ctx, tx, &models.TransacaoHistorico{
+		ID:          uuid.New().String(),
+		TransacaoID: original.ID,
+		Operacao:    models.HistoricoAtualizacao,
+		Dados:       *original,
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+	cancelada := *original
+	cancelada.Status = models.StatusCancelada
+	if err := s.transacaoRepo.AtualizarStatus(ctx, tx, cancelada.ID, cancelada.Status); err != nil {
+		return nil, err
+	}
+	return &cancelada, nil
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// categoriaDoSistema retorna a categoria usada pelos lançamentos gerados automaticamente,
+// criando-a na primeira vez em que for necessária.
+func categoriaDoSistema(ctx context.Context, repo repositories.CategoriaRepository, nome, icone string) (*models.Categoria, error) {
+	categoria, err := repo.FindByName(ctx, nome)
+	if err != nil {
+		return nil, err
+	}
+	if categoria != nil {
+		return categoria, nil
+	}
+	categoria = &models.Categoria{ID: uuid.New().String(), Nome: nome, Icone: icone}
+	if err := repo.Create(ctx, categoria); err != nil {
+		return nil, err
+	}
+	return categoria, nil
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ConcluirConciliacaoService struct {
+	db              *pgxpool.Pool
+	conciliacaoRepo repositories.ConciliacaoRepository
+	transacaoRepo   repositories.TransacaoRepository
+	ativoRepo       repositories.AtivoRepository
+}
+
+func NewConcluirConciliacaoService(db *pgxpool.Pool, cRepo repositories.ConciliacaoRepository, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository) *ConcluirConciliacaoService {
+	return &ConcluirConciliacaoService{db: db, conciliacaoRepo: cRepo, transacaoRepo: tRepo, ativoRepo: aRepo}
+}
+
+// Execute conclui a conciliação quando o saldo conciliado confere com o extrato, bloqueando
+// as transações conferidas até a data do extrato contra edição, exclusão e estorno.
+func (s *ConcluirConciliacaoService) Execute(ctx context.Context, id string) (*models.ResumoConciliacao, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	conciliacao, err := s.conciliacaoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conciliacao == nil {
+		return nil, ErrConciliacaoNaoEncontrada
+	}
+	if conciliacao.Status != models.ConciliacaoAberta {
+		return nil, ErrConciliacaoConcluida
+	}
+
+	// O resumo é calculado na mesma transação de banco, com o ativo bloqueado, para refletir
+	// exatamente o que será confirmado.
+	ativo, err := s.ativoRepo.FindByIDForUpdate(ctx, tx, conciliacao.AtivoFinanceiroID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	resumo, err := resumirConciliacao(ctx, transacoesNaTx{repo: s.transacaoRepo, tx: tx}, *ativo, *conciliacao)
+	if err != nil {
+		return nil, err
+	}
+	if centavos(resumo.Diferenca) != 0 {
+		return nil, ErrConciliacaoComDiferenca
+	}
+
+	if err := s.transacaoRepo.BloquearConciliadas(ctx, tx, *conciliacao, limiteConciliacao(*conciliacao)); err != nil {
+		return nil, err
+	}
+	agora := time.Now()
+	conciliacao.Status = models.ConciliacaoConcluida
+	conciliacao.ConcluidaEm = &agora
+	if err := s.conciliacaoRepo.Concluir(ctx, tx, conciliacao); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	resumo.Conciliacao = *conciliacao
+	return resumo, nil
+}
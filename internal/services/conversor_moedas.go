@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"controlador/backend/internal/cambio"
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrTaxaCambioNaoEncontrada = erros.RegraNegocio("taxa_cambio_nao_encontrada", "não há taxa de câmbio cadastrada para o par de moedas na data")
+
+// ConversorMoedas encontra a taxa de câmbio vigente entre duas moedas em uma data. As taxas
+// cadastradas têm precedência; sem elas, o provedor configurado (se houver) é consultado.
+type ConversorMoedas struct {
+	repo     repositories.TaxaCambioRepository
+	provedor cambio.Provedor
+}
+
+// NewConversorMoedas cria o conversor; 'provedor' pode ser nil para usar só as taxas cadastradas.
+func NewConversorMoedas(repo repositories.TaxaCambioRepository, provedor cambio.Provedor) *ConversorMoedas {
+	return &ConversorMoedas{repo: repo, provedor: provedor}
+}
+
+// Taxa retorna quanto vale 1 unidade de 'origem' em 'destino' na data. Uma taxa cadastrada
+// apenas no sentido inverso também é aceita.
+func (c *ConversorMoedas) Taxa(ctx context.Context, origem, destino string, data time.Time) (float64, error) {
+	if origem == destino {
+		return 1, nil
+	}
+
+	direta, err := c.repo.FindVigente(ctx, origem, destino, data)
+	if err != nil {
+		return 0, err
+	}
+	if direta != nil {
+		return direta.Taxa, nil
+	}
+	inversa, err := c.repo.FindVigente(ctx, destino, origem, data)
+	if err != nil {
+		return 0, err
+	}
+	if inversa != nil {
+		return 1 / inversa.Taxa, nil
+	}
+
+	if c.provedor != nil {
+		taxa, err := c.provedor.Cotacao(ctx, origem, destino, data)
+		if err == nil {
+			return taxa, nil
+		}
+		if !errors.Is(err, cambio.ErrCotacaoIndisponivel) {
+			return 0, err
+		}
+	}
+	return 0, fmt.Errorf("%w: %s/%s em %s", ErrTaxaCambioNaoEncontrada, origem, destino, data.Format("2006-01-02"))
+}
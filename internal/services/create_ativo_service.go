@@ -5,30 +5,124 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 
+	"controlador/backend/internal/erros"
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/repositories"
+)
+
+const categoriaSaldoInicial = "Saldo inicial"
 
+var (
+	ErrDataSaldoInicialInvalida = erros.RegraNegocio("data_saldo_inicial_invalida", "a data do saldo inicial não pode estar no futuro")
+	ErrMoedaInvalida            = erros.Invalido("moeda_invalida", "moeda inválida: use um código ISO 4217 de três letras, como BRL ou USD")
 )
 
 type CreateAtivoService struct {
-	repo repositories.AtivoRepository
+	db            *pgxpool.Pool
+	repo          repositories.AtivoRepository
+	transacaoRepo repositories.TransacaoRepository
+	categoriaRepo repositories.CategoriaRepository
+	eventos       *PublicadorEventos
 }
 
-func NewCreateAtivoService(repo repositories.AtivoRepository) *CreateAtivoService {
-	return &CreateAtivoService{repo: repo}
+func NewCreateAtivoService(db *pgxpool.Pool, repo repositories.AtivoRepository, tRepo repositories.TransacaoRepository, cRepo repositories.CategoriaRepository, eventos *PublicadorEventos) *CreateAtivoService {
+	return &CreateAtivoService{db: db, repo: repo, transacaoRepo: tRepo, categoriaRepo: cRepo, eventos: eventos}
 }
 
+// Execute cria o ativo zerado e registra o saldo informado como um lançamento de saldo inicial
+// na data 'data_saldo_inicial' (hoje, se ausente). Assim o saldo do ativo sempre pode ser
+// recalculado a partir das suas transações.
+// Para cartões, 'limite_total' é o limite contratado; sem ele, o limite disponível informado é
+// tomado como total, e sem limite disponível o cartão começa com todo o limite livre.
 func (s *CreateAtivoService) Execute(ctx context.Context, input models.AtivoFinanceiro) (*models.AtivoFinanceiro, error) {
-	input.ID = uuid.New().String()
+	estrategia, err := estrategiaDoAtivo(input.Tipo)
+	if err != nil {
+		return nil, err
+	}
+	if input.Moeda == "" {
+		input.Moeda = models.MoedaPadrao
+	}
+	if input.Moeda, err = models.ParseMoeda(input.Moeda); err != nil {
+		return nil, ErrMoedaInvalida
+	}
+	if input.Tipo == models.AtivoCartaoCredito {
+		if input.LimiteTotal == 0 {
+			input.LimiteTotal = input.LimiteDisponivel
+		} else if input.LimiteDisponivel == 0 {
+			input.LimiteDisponivel = input.LimiteTotal
+		}
+	}
+	if err := estrategia.ValidarAtivo(input); err != nil {
+		return nil, err
+	}
+	// O saldo informado precisa ser alcançável a partir do ativo zerado, como qualquer lançamento.
+	zerado := input
+	zerado.SaldoAtual = 0
+	zerado.LimiteDisponivel = input.LimiteTotal
+	if err := estrategia.ValidarSaldo(zerado, input); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
+	dataSaldoInicial := now
+	if input.DataSaldoInicial != nil {
+		if !input.DataSaldoInicial.Before(inicioDoDia(now).AddDate(0, 0, 1)) {
+			return nil, ErrDataSaldoInicialInvalida
+		}
+		dataSaldoInicial = *input.DataSaldoInicial
+	}
+	valorInicial := estrategia.ValorSaldoInicial(input)
+
+	input.ID = uuid.New().String()
 	input.CreatedAt = now
 	input.UpdatedAt = now
+	input.IsActive = true
+	input.DataSaldoInicial = nil
+
+	var categoria *models.Categoria
+	if centavos(valorInicial) != 0 {
+		categoria, err = categoriaDoSistema(ctx, s.categoriaRepo, categoriaSaldoInicial, "flag")
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	err := s.repo.Save(ctx, &input)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	return &input, nil
-}
\ No newline at end of file
+	// O ativo nasce zerado; o saldo informado entra pelo lançamento de saldo inicial.
+	zerado.ID, zerado.CreatedAt, zerado.UpdatedAt, zerado.IsActive = input.ID, now, now, true
+	if err := s.repo.Save(ctx, tx, &zerado); err != nil {
+		return nil, err
+	}
+
+	if categoria != nil {
+		abertura := models.Transacao{
+			ID:                uuid.New().String(),
+			AtivoFinanceiroID: input.ID,
+			CategoriaID:       categoria.ID,
+			Descricao:         categoriaSaldoInicial,
+			Valor:             valorInicial,
+			Tipo:              models.TransacaoSaldoInicial,
+			Status:            models.StatusEfetivada,
+			Data:              dataSaldoInicial,
+			CreatedAt:         now,
+		}
+		if err := s.transacaoRepo.Create(ctx, tx, &abertura); err != nil {
+			return nil, err
+		}
+		if err := s.repo.UpdateBalance(ctx, tx, input.ID, efeitoAplicado(input.Tipo, abertura)); err != nil {
+			return nil, err
+		}
+		if err := s.eventos.Publicar(ctx, tx, models.WebhookTransacaoCriada, abertura); err != nil {
+			return nil, err
+		}
+	}
+
+	return &input, tx.Commit(ctx)
+}
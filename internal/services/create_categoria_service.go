@@ -2,16 +2,16 @@ package services
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 
+	"controlador/backend/internal/erros"
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/repositories"
 
 )
 
-var ErrCategoriaJaExiste = errors.New("uma categoria com este nome já existe")
+var ErrCategoriaJaExiste = erros.Conflito("categoria_ja_existe", "uma categoria com este nome já existe")
 
 type CreateCategoriaService struct {
 	repo repositories.CategoriaRepository
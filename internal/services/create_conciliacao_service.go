@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrConciliacaoNaoEncontrada = erros.NaoEncontrado("conciliacao_nao_encontrada", "conciliação não encontrada")
+	ErrConciliacaoJaAberta      = erros.Conflito("conciliacao_ja_aberta", "já existe uma conciliação aberta para este ativo")
+	ErrConciliacaoConcluida     = erros.Conflito("conciliacao_concluida", "a conciliação já foi concluída")
+	ErrConciliacaoComDiferenca  = erros.Conflito("conciliacao_com_diferenca", "o saldo conciliado não confere com o saldo do extrato")
+	ErrTransacaoNaoConciliavel  = erros.RegraNegocio("transacao_nao_conciliavel", "somente transações efetivadas do ativo, com data até a do extrato, podem ser conferidas")
+	ErrDataExtratoInvalida      = erros.RegraNegocio("data_extrato_invalida", "a data do extrato é obrigatória e não pode estar no futuro")
+)
+
+// CreateConciliacaoInput contém os dados do extrato do banco a ser conciliado.
+type CreateConciliacaoInput struct {
+	DataExtrato  time.Time
+	SaldoExtrato float64
+}
+
+type CreateConciliacaoService struct {
+	db              *pgxpool.Pool
+	conciliacaoRepo repositories.ConciliacaoRepository
+	ativoRepo       repositories.AtivoRepository
+}
+
+func NewCreateConciliacaoService(db *pgxpool.Pool, cRepo repositories.ConciliacaoRepository, aRepo repositories.AtivoRepository) *CreateConciliacaoService {
+	return &CreateConciliacaoService{db: db, conciliacaoRepo: cRepo, ativoRepo: aRepo}
+}
+
+// Execute abre uma conciliação do ativo com a data e o saldo do extrato. Só uma conciliação
+// pode ficar aberta por ativo; o índice único em 'conciliacoes' garante isso também no banco.
+func (s *CreateConciliacaoService) Execute(ctx context.Context, ativoID string, input CreateConciliacaoInput) (*models.Conciliacao, error) {
+	if input.DataExtrato.IsZero() || input.DataExtrato.After(time.Now()) {
+		return nil, ErrDataExtratoInvalida
+	}
+	ativo, err := s.ativoRepo.FindByID(ctx, ativoID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	aberta, err := s.conciliacaoRepo.FindAbertaByAtivoID(ctx, tx, ativo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if aberta != nil {
+		return nil, ErrConciliacaoJaAberta
+	}
+
+	conciliacao := &models.Conciliacao{
+		ID:                uuid.New().String(),
+		AtivoFinanceiroID: ativo.ID,
+		DataExtrato:       input.DataExtrato,
+		SaldoExtrato:      input.SaldoExtrato,
+		Status:            models.ConciliacaoAberta,
+		CreatedAt:         time.Now(),
+	}
+	if err := s.conciliacaoRepo.Create(ctx, tx, conciliacao); err != nil {
+		return nil, err
+	}
+	return conciliacao, tx.Commit(ctx)
+}
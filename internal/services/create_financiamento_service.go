@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrFinanciamentoNaoEncontrado = erros.NaoEncontrado("financiamento_nao_encontrado", "financiamento não encontrado")
+	ErrFinanciamentoDuplicado     = erros.Conflito("financiamento_duplicado", "este empréstimo já tem um financiamento cadastrado")
+	ErrAtivoNaoEhEmprestimo       = erros.RegraNegocio("ativo_nao_eh_emprestimo", "o financiamento deve ser vinculado a um ativo do tipo EMPRESTIMO")
+	ErrFinanciamentoSemDivida     = erros.RegraNegocio("financiamento_sem_divida", "o empréstimo não tem saldo devedor; registre o saldo inicial da dívida no ativo")
+	ErrAtivoPagamentoInvalido     = erros.RegraNegocio("ativo_pagamento_invalido", "o ativo de pagamento deve ser outro ativo ativo, na mesma moeda do empréstimo")
+)
+
+// CreateFinanciamentoInput descreve o contrato. O principal é o saldo devedor atual do ativo de
+// empréstimo, de modo que um financiamento já em andamento pode ser cadastrado com o prazo restante.
+type CreateFinanciamentoInput struct {
+	AtivoFinanceiroID   string                    `json:"ativo_financeiro_id" binding:"required"`
+	AtivoPagamentoID    string                    `json:"ativo_pagamento_id" binding:"required"`
+	Sistema             models.SistemaAmortizacao `json:"sistema" binding:"required"`
+	TaxaJurosMensal     float64                   `json:"taxa_juros_mensal"`
+	PrazoMeses          int                       `json:"prazo_meses"`
+	DataPrimeiraParcela time.Time                 `json:"-"`
+}
+
+type CreateFinanciamentoService struct {
+	db                *pgxpool.Pool
+	financiamentoRepo repositories.FinanciamentoRepository
+	ativoRepo         repositories.AtivoRepository
+}
+
+func NewCreateFinanciamentoService(db *pgxpool.Pool, fRepo repositories.FinanciamentoRepository, aRepo repositories.AtivoRepository) *CreateFinanciamentoService {
+	return &CreateFinanciamentoService{db: db, financiamentoRepo: fRepo, ativoRepo: aRepo}
+}
+
+// Execute cadastra o financiamento e gera seu cronograma; sem data, a primeira parcela vence
+// daqui a um mês.
+func (s *CreateFinanciamentoService) Execute(ctx context.Context, input CreateFinanciamentoInput) (*models.Financiamento, error) {
+	if err := validarCondicoesFinanciamento(input.Sistema, input.TaxaJurosMensal, input.PrazoMeses); err != nil {
+		return nil, err
+	}
+
+	// 1. Validar o empréstimo e o ativo que pagará as parcelas
+	emprestimo, err := s.ativoRepo.FindByID(ctx, input.AtivoFinanceiroID)
+	if err != nil {
+		return nil, err
+	}
+	pagamento, err := s.ativoRepo.FindByID(ctx, input.AtivoPagamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if emprestimo == nil || pagamento == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	if emprestimo.Tipo != models.AtivoEmprestimo {
+		return nil, ErrAtivoNaoEhEmprestimo
+	}
+	if !emprestimo.IsActive {
+		return nil, ErrAtivoDesativado
+	}
+	if centavos(emprestimo.SaldoAtual) >= 0 {
+		return nil, ErrFinanciamentoSemDivida
+	}
+	if err := validarAtivoPagamento(emprestimo, pagamento); err != nil {
+		return nil, err
+	}
+	existente, err := s.financiamentoRepo.FindByAtivoID(ctx, emprestimo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existente != nil {
+		return nil, ErrFinanciamentoDuplicado
+	}
+
+	// 2. Gerar o cronograma a partir do saldo devedor
+	agora := time.Now()
+	primeira := inicioDoDia(input.DataPrimeiraParcela)
+	if input.DataPrimeiraParcela.IsZero() {
+		primeira = adicionarMeses(inicioDoDia(agora), 1)
+	}
+	financiamento := &models.Financiamento{
+		ID:                  uuid.New().String(),
+		AtivoFinanceiroID:   emprestimo.ID,
+		AtivoPagamentoID:    pagamento.ID,
+		Sistema:             input.Sistema,
+		TaxaJurosMensal:     input.TaxaJurosMensal,
+		ValorPrincipal:      -emprestimo.SaldoAtual,
+		SaldoDevedor:        -emprestimo.SaldoAtual,
+		PrazoMeses:          input.PrazoMeses,
+		DataPrimeiraParcela: primeira,
+		Status:              models.FinanciamentoAtivo,
+		CreatedAt:           agora,
+		UpdatedAt:           agora,
+	}
+	financiamento.Parcelas = gerarParcelas(financiamento.Sistema, financiamento.SaldoDevedor, financiamento.TaxaJurosMensal, financiamento.PrazoMeses, primeira, 1)
+	for i := range financiamento.Parcelas {
+		financiamento.Parcelas[i].FinanciamentoID = financiamento.ID
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.financiamentoRepo.Create(ctx, tx, financiamento); err != nil {
+		return nil, err
+	}
+	if err := s.financiamentoRepo.SaveParcelas(ctx, tx, financiamento.Parcelas); err != nil {
+		return nil, err
+	}
+	return financiamento, tx.Commit(ctx)
+}
+
+// validarAtivoPagamento confere se o ativo pode pagar as parcelas do empréstimo.
+func validarAtivoPagamento(emprestimo, pagamento *models.AtivoFinanceiro) error {
+	if pagamento.ID == emprestimo.ID || !pagamento.IsActive || pagamento.Moeda != emprestimo.Moeda {
+		return ErrAtivoPagamentoInvalido
+	}
+	if !tipoTransacaoAceito(pagamento.Tipo, models.TransacaoDebito) && !tipoTransacaoAceito(pagamento.Tipo, models.TransacaoCredito) {
+		return ErrAtivoPagamentoInvalido
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrMetaInvalida      = erros.RegraNegocio("meta_invalida", "a meta exige nome, valor alvo maior que zero e data alvo futura")
+	ErrMetaFonteInvalida = erros.RegraNegocio("meta_fonte_invalida", "a meta deve ser vinculada a ativos ou a uma tag, nunca aos dois")
+	ErrAtivoMetaInvalido = erros.RegraNegocio("ativo_meta_invalido", "cartões de crédito e empréstimos não podem ser vinculados a metas")
+	ErrMetaNaoEncontrada = erros.NaoEncontrado("meta_nao_encontrada", "meta não encontrada")
+)
+
+// CreateMetaInput descreve uma nova meta. 'Moeda', se ausente, é a do primeiro ativo vinculado
+// ou, em metas por tag, a moeda base.
+type CreateMetaInput struct {
+	Nome      string    `json:"nome" binding:"required"`
+	ValorAlvo float64   `json:"valor_alvo" binding:"required"`
+	DataAlvo  time.Time `json:"-"`
+	Moeda     string    `json:"moeda"`
+	Tag       string    `json:"tag"`
+	AtivoIDs  []string  `json:"ativo_ids"`
+}
+
+type CreateMetaService struct {
+	db        *pgxpool.Pool
+	repo      repositories.MetaRepository
+	ativoRepo repositories.AtivoRepository
+	moedaBase string
+}
+
+func NewCreateMetaService(db *pgxpool.Pool, repo repositories.MetaRepository, aRepo repositories.AtivoRepository, moedaBase string) *CreateMetaService {
+	return &CreateMetaService{db: db, repo: repo, ativoRepo: aRepo, moedaBase: moedaBase}
+}
+
+func (s *CreateMetaService) Execute(ctx context.Context, input CreateMetaInput) (*models.Meta, error) {
+	agora := time.Now()
+	meta := &models.Meta{
+		ID:        uuid.New().String(),
+		Nome:      strings.TrimSpace(input.Nome),
+		ValorAlvo: math.Round(input.ValorAlvo*100) / 100,
+		DataAlvo:  inicioDoDia(input.DataAlvo),
+		CreatedAt: agora,
+		UpdatedAt: agora,
+	}
+	if meta.Nome == "" || meta.ValorAlvo <= 0 || !meta.DataAlvo.After(inicioDoDia(agora)) {
+		return nil, ErrMetaInvalida
+	}
+
+	// 1. Validar a fonte do progresso: ativos vinculados ou uma tag
+	tag := strings.TrimSpace(input.Tag)
+	if (tag == "") == (len(input.AtivoIDs) == 0) {
+		return nil, ErrMetaFonteInvalida
+	}
+	moeda := s.moedaBase
+	if tag != "" {
+		tags, err := normalizarTags([]string{tag})
+		if err != nil {
+			return nil, err
+		}
+		meta.Tag = &tags[0]
+	}
+	vistos := make(map[string]bool, len(input.AtivoIDs))
+	for _, id := range input.AtivoIDs {
+		if vistos[id] {
+			continue
+		}
+		vistos[id] = true
+		ativo, err := s.ativoRepo.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if ativo == nil {
+			return nil, ErrAtivoNaoEncontrado
+		}
+		if !ativo.IsActive {
+			return nil, ErrAtivoDesativado
+		}
+		if ativo.Tipo == models.AtivoCartaoCredito || ativo.Tipo == models.AtivoEmprestimo {
+			return nil, ErrAtivoMetaInvalido
+		}
+		if len(meta.AtivoIDs) == 0 {
+			moeda = ativo.Moeda
+		}
+		meta.AtivoIDs = append(meta.AtivoIDs, ativo.ID)
+	}
+
+	if input.Moeda != "" {
+		moeda = input.Moeda
+	}
+	var err error
+	if meta.Moeda, err = models.ParseMoeda(moeda); err != nil {
+		return nil, ErrMoedaInvalida
+	}
+
+	// 2. Gravar a meta com os vínculos
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.Create(ctx, tx, meta); err != nil {
+		return nil, err
+	}
+	return meta, tx.Commit(ctx)
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+const categoriaInvestimentos = "Investimentos"
+
+var (
+	ErrOperacaoInvestimentoInvalida = erros.RegraNegocio("operacao_investimento_invalida", "operação inválida: compras e vendas exigem quantidade e preço maiores que zero, e o valor líquido deve ser positivo")
+	ErrDataOperacaoInvalida         = erros.RegraNegocio("data_operacao_invalida", "a data da operação não pode estar no futuro")
+	ErrMoedaTituloDivergente        = erros.RegraNegocio("moeda_titulo_divergente", "o ativo de liquidação deve estar na mesma moeda do título")
+)
+
+// CreateOperacaoInvestimentoInput descreve uma operação. Em compras e vendas o valor é
+// 'Quantidade' x 'PrecoUnitario'; em proventos é 'Valor' (bruto). 'Taxas' inclui corretagem,
+// emolumentos e, em proventos, o imposto retido na fonte.
+type CreateOperacaoInvestimentoInput struct {
+	TituloID          string                          `json:"titulo_id" binding:"required"`
+	AtivoFinanceiroID string                          `json:"ativo_financeiro_id" binding:"required"`
+	Tipo              models.TipoOperacaoInvestimento `json:"tipo" binding:"required"`
+	Data              *time.Time                      `json:"data"`
+	Quantidade        float64                         `json:"quantidade"`
+	PrecoUnitario     float64                         `json:"preco_unitario"`
+	Valor             float64                         `json:"valor"`
+	Taxas             float64                         `json:"taxas"`
+}
+
+type CreateOperacaoInvestimentoService struct {
+	db               *pgxpool.Pool
+	investimentoRepo repositories.InvestimentoRepository
+	transacaoRepo    repositories.TransacaoRepository
+	ativoRepo        repositories.AtivoRepository
+	categoriaRepo    repositories.CategoriaRepository
+	eventos          *PublicadorEventos
+}
+
+func NewCreateOperacaoInvestimentoService(db *pgxpool.Pool, iRepo repositories.InvestimentoRepository, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, cRepo repositories.CategoriaRepository, eventos *PublicadorEventos) *CreateOperacaoInvestimentoService {
+	return &CreateOperacaoInvestimentoService{
+		db:               db,
+		investimentoRepo: iRepo,
+		transacaoRepo:    tRepo,
+		ativoRepo:        aRepo,
+		categoriaRepo:    cRepo,
+		eventos:          eventos,
+	}
+}
+
+// Execute registra a operação e sua liquidação no ativo financeiro em uma única transação de banco:
+// compras geram um débito do valor mais taxas; vendas e proventos, um recebimento do valor líquido.
+// As operações do título são reproduzidas em ordem cronológica para garantir que nenhuma venda,
+// inclusive as posteriores a uma operação retroativa, fique acima da quantidade em carteira.
+func (s *CreateOperacaoInvestimentoService) Execute(ctx context.Context, input CreateOperacaoInvestimentoInput) (*models.OperacaoInvestimento, error) {
+	// 1. Validar o título e o ativo de liquidação
+	titulo, err := s.investimentoRepo.FindTituloByID(ctx, input.TituloID)
+	if err != nil {
+		return nil, err
+	}
+	if titulo == nil {
+		return nil, ErrTituloNaoEncontrado
+	}
+
+	agora := time.Now()
+	operacao := &models.OperacaoInvestimento{
+		ID:                uuid.New().String(),
+		TituloID:          titulo.ID,
+		AtivoFinanceiroID: input.AtivoFinanceiroID,
+		Tipo:              input.Tipo,
+		Data:              inicioDoDia(agora),
+		Quantidade:        input.Quantidade,
+		PrecoUnitario:     input.PrecoUnitario,
+		Taxas:             math.Round(input.Taxas*100) / 100,
+		CreatedAt:         agora,
+	}
+	if input.Data != nil {
+		operacao.Data = inicioDoDia(*input.Data)
+	}
+	if operacao.Data.After(agora) {
+		return nil, ErrDataOperacaoInvalida
+	}
+
+	// 2. Calcular o valor líquido liquidado no ativo
+	tipoTransacao := models.TransacaoRecebimento
+	switch input.Tipo {
+	case models.OperacaoCompra, models.OperacaoVenda:
+		if input.Quantidade <= 0 || input.PrecoUnitario <= 0 {
+			return nil, ErrOperacaoInvestimentoInvalida
+		}
+		bruto := math.Round(input.Quantidade*input.PrecoUnitario*100) / 100
+		if input.Tipo == models.OperacaoCompra {
+			operacao.ValorTotal = bruto + operacao.Taxas
+			tipoTransacao = models.TransacaoDebito
+		} else {
+			operacao.ValorTotal = bruto - operacao.Taxas
+		}
+	default:
+		operacao.Quantidade, operacao.PrecoUnitario = 0, 0
+		operacao.ValorTotal = math.Round(input.Valor*100)/100 - operacao.Taxas
+	}
+	if operacao.Taxas < 0 || operacao.ValorTotal <= 0 {
+		return nil, ErrOperacaoInvestimentoInvalida
+	}
+
+	categoria, err := categoriaDoSistema(ctx, s.categoriaRepo, categoriaInvestimentos, "trending_up")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ativo, err := s.ativoRepo.FindByIDForUpdate(ctx, tx, input.AtivoFinanceiroID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	if ativo.Moeda != titulo.Moeda {
+		return nil, ErrMoedaTituloDivergente
+	}
+
+	// 3. Conferir a quantidade em carteira com a nova operação incluída
+	if err := s.investimentoRepo.LockTitulo(ctx, tx, titulo.ID); err != nil {
+		return nil, err
+	}
+	operacoes, err := s.investimentoRepo.FindOperacoesTx(ctx, tx, titulo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := apurarOperacoes(incluirOperacao(operacoes, *operacao)); err != nil {
+		return nil, err
+	}
+
+	// 4. Lançar a liquidação no ativo e registrar a operação
+	transacao := models.Transacao{
+		ID:                uuid.New().String(),
+		AtivoFinanceiroID: ativo.ID,
+		CategoriaID:       categoria.ID,
+		Descricao:         descricaoOperacao(operacao, titulo),
+		Valor:             operacao.ValorTotal,
+		Tipo:              tipoTransacao,
+		Status:            models.StatusEfetivada,
+		Data:              operacao.Data,
+		Origem:            models.OrigemInvestimento,
+		CreatedAt:         agora,
+	}
+	if err := validarTransacao(transacao, ativo); err != nil {
+		return nil, err
+	}
+	if err := s.transacaoRepo.Create(ctx, tx, &transacao); err != nil {
+		return nil, err
+	}
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, ativo.ID, efeitoAplicado(ativo.Tipo, transacao)); err != nil {
+		return nil, err
+	}
+	if err := s.eventos.Publicar(ctx, tx, models.WebhookTransacaoCriada, transacao); err != nil {
+		return nil, err
+	}
+
+	operacao.TransacaoID = transacao.ID
+	if err := s.investimentoRepo.CreateOperacao(ctx, tx, operacao); err != nil {
+		return nil, err
+	}
+
+	return operacao, tx.Commit(ctx)
+}
+
+// incluirOperacao insere a operação na lista mantendo a ordem cronológica; no mesmo dia,
+// a nova operação fica por último, como no banco.
+func incluirOperacao(operacoes []models.OperacaoInvestimento, nova models.OperacaoInvestimento) []models.OperacaoInvestimento {
+	i := len(operacoes)
+	for i > 0 && operacoes[i-1].Data.After(nova.Data) {
+		i--
+	}
+	resultado := make([]models.OperacaoInvestimento, 0, len(operacoes)+1)
+	resultado = append(resultado, operacoes[:i]...)
+	resultado = append(resultado, nova)
+	return append(resultado, operacoes[i:]...)
+}
+
+func descricaoOperacao(o *models.OperacaoInvestimento, titulo *models.Titulo) string {
+	switch o.Tipo {
+	case models.OperacaoCompra:
+		return fmt.Sprintf("Compra de %.8g %s a %.2f", o.Quantidade, titulo.Codigo, o.PrecoUnitario)
+	case models.OperacaoVenda:
+		return fmt.Sprintf("Venda de %.8g %s a %.2f", o.Quantidade, titulo.Codigo, o.PrecoUnitario)
+	case models.OperacaoJCP:
+		return fmt.Sprintf("JCP de %s", titulo.Codigo)
+	default:
+		return fmt.Sprintf("Dividendos de %s", titulo.Codigo)
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrRegraSemCondicoes = erros.RegraNegocio("regra_sem_condicoes", "a regra precisa de ao menos uma condição e uma ação")
+	ErrRegraInvalida     = erros.RegraNegocio("regra_invalida", "regra inválida")
+)
+
+type CreateRegraService struct {
+	repo          repositories.RegraRepository
+	categoriaRepo repositories.CategoriaRepository
+}
+
+func NewCreateRegraService(repo repositories.RegraRepository, cRepo repositories.CategoriaRepository) *CreateRegraService {
+	return &CreateRegraService{repo: repo, categoriaRepo: cRepo}
+}
+
+func (s *CreateRegraService) Execute(ctx context.Context, input models.Regra) (*models.Regra, error) {
+	// 1. Validar a estrutura da regra
+	input.Nome = strings.TrimSpace(input.Nome)
+	if input.Nome == "" {
+		return nil, fmt.Errorf("%w: o nome é obrigatório", ErrRegraInvalida)
+	}
+	if len(input.Condicoes) == 0 || len(input.Acoes) == 0 {
+		return nil, ErrRegraSemCondicoes
+	}
+	for _, c := range input.Condicoes {
+		if err := validarCondicao(c); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Validar as ações, normalizando tags e conferindo categorias
+	for i := range input.Acoes {
+		acao := &input.Acoes[i]
+		switch acao.Tipo {
+		case models.AcaoDefinirCategoria:
+			if err := validarCategoria(ctx, s.categoriaRepo, acao.Valor); err != nil {
+				return nil, err
+			}
+		case models.AcaoAdicionarTag:
+			tags, err := normalizarTags([]string{acao.Valor})
+			if err != nil {
+				return nil, err
+			}
+			acao.Valor = tags[0]
+		case models.AcaoRenomearDescricao:
+			if strings.TrimSpace(acao.Valor) == "" {
+				return nil, fmt.Errorf("%w: a nova descrição não pode ser vazia", ErrRegraInvalida)
+			}
+		default:
+			return nil, fmt.Errorf("%w: ação desconhecida '%s'", ErrRegraInvalida, acao.Tipo)
+		}
+	}
+
+	// 3. Preparar e salvar
+	input.ID = uuid.New().String()
+	input.Ativa = true
+	input.CreatedAt = time.Now()
+	if err := s.repo.Create(ctx, &input); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
+
+// validarCondicao confere se o operador é suportado pelo campo e se os valores necessários foram informados.
+func validarCondicao(c models.CondicaoRegra) error {
+	switch c.Campo {
+	case models.CondicaoDescricao:
+		switch c.Operador {
+		case models.OperadorContem, models.OperadorIgual:
+			if c.Valor == "" {
+				return fmt.Errorf("%w: informe o texto da condição de descrição", ErrRegraInvalida)
+			}
+			return nil
+		case models.OperadorRegex:
+			if _, err := regexp.Compile(c.Valor); err != nil {
+				return fmt.Errorf("%w: expressão regular inválida: %v", ErrRegraInvalida, err)
+			}
+			return nil
+		}
+	case models.CondicaoAtivo, models.CondicaoTipo:
+		if c.Operador == models.OperadorIgual && c.Valor != "" {
+			return nil
+		}
+	case models.CondicaoValor:
+		if c.Operador == models.OperadorEntre && (c.Minimo != nil || c.Maximo != nil) {
+			if c.Minimo != nil && c.Maximo != nil && *c.Minimo > *c.Maximo {
+				return fmt.Errorf("%w: o mínimo é maior que o máximo", ErrRegraInvalida)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: condição '%s %s' não suportada", ErrRegraInvalida, c.Campo, c.Operador)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrTaxaCambioInvalida = erros.RegraNegocio("taxa_cambio_invalida", "taxa de câmbio inválida: informe duas moedas diferentes e uma taxa maior que zero")
+
+type CreateTaxaCambioService struct {
+	repo repositories.TaxaCambioRepository
+}
+
+func NewCreateTaxaCambioService(repo repositories.TaxaCambioRepository) *CreateTaxaCambioService {
+	return &CreateTaxaCambioService{repo: repo}
+}
+
+// Execute cadastra manualmente a cotação de um par de moedas em uma data (hoje, se ausente).
+// Cadastrar de novo o mesmo par e dia substitui a cotação anterior.
+func (s *CreateTaxaCambioService) Execute(ctx context.Context, input models.TaxaCambio) (*models.TaxaCambio, error) {
+	var err error
+	if input.MoedaOrigem, err = models.ParseMoeda(input.MoedaOrigem); err != nil {
+		return nil, ErrMoedaInvalida
+	}
+	if input.MoedaDestino, err = models.ParseMoeda(input.MoedaDestino); err != nil {
+		return nil, ErrMoedaInvalida
+	}
+	if input.MoedaOrigem == input.MoedaDestino || input.Taxa <= 0 {
+		return nil, ErrTaxaCambioInvalida
+	}
+
+	input.ID = uuid.New().String()
+	input.CreatedAt = time.Now()
+	if input.Data.IsZero() {
+		input.Data = input.CreatedAt
+	}
+	input.Data = inicioDoDia(input.Data)
+	input.Fonte = "MANUAL"
+
+	if err := s.repo.Save(ctx, &input); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
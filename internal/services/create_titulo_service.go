@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrTituloInvalido      = erros.RegraNegocio("titulo_invalido", "informe o código, o nome e a classe do título")
+	ErrTituloDuplicado     = erros.Conflito("titulo_duplicado", "já existe um título com este código")
+	ErrTituloNaoEncontrado = erros.NaoEncontrado("titulo_nao_encontrado", "título não encontrado")
+)
+
+type CreateTituloService struct {
+	repo repositories.InvestimentoRepository
+}
+
+func NewCreateTituloService(repo repositories.InvestimentoRepository) *CreateTituloService {
+	return &CreateTituloService{repo: repo}
+}
+
+// Execute cadastra um título. O código é normalizado em maiúsculas e identifica o título
+// na importação de cotações; a moeda padrão é a mesma dos ativos.
+func (s *CreateTituloService) Execute(ctx context.Context, input models.Titulo) (*models.Titulo, error) {
+	input.Codigo = strings.ToUpper(strings.TrimSpace(input.Codigo))
+	input.Nome = strings.TrimSpace(input.Nome)
+	if input.Codigo == "" || input.Nome == "" || input.Classe == "" {
+		return nil, ErrTituloInvalido
+	}
+	if input.Moeda == "" {
+		input.Moeda = models.MoedaPadrao
+	}
+	moeda, err := models.ParseMoeda(input.Moeda)
+	if err != nil {
+		return nil, ErrMoedaInvalida
+	}
+	input.Moeda = moeda
+
+	existente, err := s.repo.FindTituloByCodigo(ctx, input.Codigo)
+	if err != nil {
+		return nil, err
+	}
+	if existente != nil {
+		return nil, ErrTituloDuplicado
+	}
+
+	input.ID = uuid.New().String()
+	input.CreatedAt = time.Now()
+	if err := s.repo.CreateTitulo(ctx, &input); err != nil {
+		return nil, err
+	}
+	return &input, nil
+}
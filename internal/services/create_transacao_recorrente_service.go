@@ -2,17 +2,17 @@ package services
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
 
+	"controlador/backend/internal/erros"
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/repositories"
 
 )
 
-var ErrDiaInvalido = errors.New("o dia do vencimento deve estar entre 1 e 31")
+var ErrDiaInvalido = erros.RegraNegocio("dia_invalido", "o dia do vencimento deve estar entre 1 e 31")
 
 type CreateTransacaoRecorrenteService struct {
 	trRepo        repositories.TransacaoRecorrenteRepository
@@ -33,6 +33,11 @@ func (s *CreateTransacaoRecorrenteService) Execute(ctx context.Context, input mo
 	if input.DiaDoVencimento < 1 || input.DiaDoVencimento > 31 {
 		return nil, ErrDiaInvalido
 	}
+	tags, err := normalizarTags(input.Tags)
+	if err != nil {
+		return nil, err
+	}
+	input.Tags = tags
 
 	// 2. Validações de existência e compatibilidade
 	ativo, err := s.ativoRepo.FindByID(ctx, input.AtivoFinanceiroID)
@@ -51,7 +56,7 @@ func (s *CreateTransacaoRecorrenteService) Execute(ctx context.Context, input mo
 		return nil, ErrCategoriaNaoEncontrada
 	}
 
-	if (input.Tipo == models.TransacaoRecebimento || input.Tipo == models.TransacaoDebito) && ativo.Tipo != models.AtivoContaCorrente {
+	if !tipoTransacaoAceito(ativo.Tipo, input.Tipo) {
 		return nil, ErrTipoTransacaoInvalido
 	}
 
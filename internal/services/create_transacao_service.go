@@ -2,54 +2,88 @@ package services
 
 import (
 	"context"
-	"errors"
+	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"controlador/backend/internal/erros"
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/repositories"
 
 )
 
 var (
-	ErrAtivoNaoEncontrado     = errors.New("ativo financeiro não encontrado")
-	ErrSaldoInsuficiente      = errors.New("saldo ou limite insuficiente para a transação")
-	ErrTipoTransacaoInvalido  = errors.New("tipo de transação inválido ou incompatível com o ativo")
-	ErrCategoriaNaoEncontrada = errors.New("categoria não encontrada")
+	ErrAtivoNaoEncontrado     = erros.NaoEncontrado("ativo_nao_encontrado", "ativo financeiro não encontrado")
+	ErrAtivoDesativado        = erros.RegraNegocio("ativo_desativado", "ativo financeiro está desativado")
+	ErrSaldoInsuficiente      = erros.RegraNegocio("saldo_insuficiente", "saldo ou limite insuficiente para a transação")
+	ErrTipoTransacaoInvalido  = erros.RegraNegocio("tipo_transacao_invalido", "tipo de transação inválido ou incompatível com o ativo")
+	ErrCategoriaNaoEncontrada = erros.NaoEncontrado("categoria_nao_encontrada", "categoria não encontrada")
+	ErrValorInvalido          = erros.RegraNegocio("valor_invalido", "o valor da transação deve ser maior que zero")
+	ErrDivisaoInvalida        = erros.RegraNegocio("divisao_invalida", "cada divisão deve ter categoria e valor maior que zero")
+	ErrDivisoesNaoConferem    = erros.RegraNegocio("divisoes_nao_conferem", "a soma das divisões deve ser igual ao valor da transação")
+	ErrTagInvalida            = erros.RegraNegocio("tag_invalida", "tags devem ter entre 1 e 50 caracteres")
+	ErrStatusInicialInvalido  = erros.RegraNegocio("status_inicial_invalido", "uma transação só pode ser criada como agendada, pendente ou efetivada")
 )
 
+// tamanhoMaximoTag acompanha o tamanho da coluna 'tags.nome'.
+const tamanhoMaximoTag = 50
+
 type CreateTransacaoService struct {
 	db            *pgxpool.Pool
 	transacaoRepo repositories.TransacaoRepository
 	ativoRepo     repositories.AtivoRepository
 	categoriaRepo repositories.CategoriaRepository
+	motorRegras   *MotorRegras
+	detector      *DetectorDuplicatas
+	eventos       *PublicadorEventos
 }
 
-func NewCreateTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, cRepo repositories.CategoriaRepository) *CreateTransacaoService {
+func NewCreateTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, cRepo repositories.CategoriaRepository, motor *MotorRegras, detector *DetectorDuplicatas, eventos *PublicadorEventos) *CreateTransacaoService {
 	return &CreateTransacaoService{
 		db:            db,
 		transacaoRepo: tRepo,
 		ativoRepo:     aRepo,
 		categoriaRepo: cRepo,
+		motorRegras:   motor,
+		detector:      detector,
+		eventos:       eventos,
 	}
 }
 
 func (s *CreateTransacaoService) Execute(ctx context.Context, input models.Transacao) (*models.Transacao, error) {
+	return s.executar(ctx, input, nil)
+}
+
+// executar cria a transação; 'aoCriar', se informado, roda na mesma transação de banco logo
+// após a criação, para que quem chama registre efeitos próprios de forma atômica.
+func (s *CreateTransacaoService) executar(ctx context.Context, input models.Transacao, aoCriar func(tx pgx.Tx, t *models.Transacao) error) (*models.Transacao, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
-	// 1. Validar a categoria
-	categoria, err := s.categoriaRepo.FindByID(ctx, input.CategoriaID)
+	// 0. Aplicar as regras de categorização; a categoria escolhida pelo usuário tem precedência
+	if _, err := s.motorRegras.Aplicar(ctx, &input, true); err != nil {
+		return nil, err
+	}
+
+	// 1. Validar o rateio, as tags e a categoria
+	tags, err := normalizarTags(input.Tags)
 	if err != nil {
 		return nil, err
 	}
-	if categoria == nil {
-		return nil, ErrCategoriaNaoEncontrada
+	input.Tags = tags
+	if err := prepararDivisoes(ctx, s.categoriaRepo, &input); err != nil {
+		return nil, err
+	}
+	if err := validarCategoria(ctx, s.categoriaRepo, input.CategoriaID); err != nil {
+		return nil, err
 	}
 
 	// 2. Validar o ativo
@@ -60,50 +94,157 @@ func (s *CreateTransacaoService) Execute(ctx context.Context, input models.Trans
 	if ativo == nil {
 		return nil, ErrAtivoNaoEncontrado
 	}
-	if !ativo.IsActive {
-		return nil, errors.New("ativo financeiro está desativado")
+
+	// 3. Preparar a transação e definir seu status
+	input.ID = uuid.New().String()
+	input.CreatedAt = time.Now()
+	if input.Data.IsZero() {
+		input.Data = input.CreatedAt
+	}
+	if err := definirStatusInicial(&input); err != nil {
+		return nil, err
 	}
 
-	// 3. Validar o tipo de transação e o saldo/limite
-	// ALTERAÇÃO: Lógica de validação refatorada com o novo tipo 'RECEBIMENTO'.
-	switch input.Tipo {
-	case models.TransacaoRecebimento:
-		// Um recebimento só pode ocorrer em uma conta corrente.
-		if ativo.Tipo != models.AtivoContaCorrente {
-			return nil, ErrTipoTransacaoInvalido
+	// 4. Validar o valor, o tipo de transação e o saldo/limite
+	if err := validarTransacao(input, ativo); err != nil {
+		return nil, err
+	}
+
+	// 5. Sinalizar possíveis duplicatas; a transação é registrada mesmo assim
+	duplicatas, err := s.detector.Candidatas(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	input.PossiveisDuplicatas = duplicatas
+
+	// 6. Chamar os repositórios, passando a transação (tx)
+	if err := s.transacaoRepo.Create(ctx, tx, &input); err != nil {
+		return nil, err
+	}
+	// Transações agendadas ou pendentes só alteram o ativo quando forem efetivadas.
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, input.AtivoFinanceiroID, efeitoAplicado(ativo.Tipo, input)); err != nil {
+		return nil, err
+	}
+	if err := s.eventos.Publicar(ctx, tx, models.WebhookTransacaoCriada, input); err != nil {
+		return nil, err
+	}
+	if aoCriar != nil {
+		if err := aoCriar(tx, &input); err != nil {
+			return nil, err
 		}
-		// Nenhuma verificação de saldo é necessária para recebimentos.
-	case models.TransacaoDebito:
-		// Um débito (gasto) só pode ocorrer em uma conta corrente.
-		if ativo.Tipo != models.AtivoContaCorrente {
-			return nil, ErrTipoTransacaoInvalido
+	}
+
+	return &input, tx.Commit(ctx)
+}
+
+// definirStatusInicial completa o status de uma nova transação: sem status informado, transações
+// com data a partir de amanhã ficam agendadas e as demais são efetivadas imediatamente.
+func definirStatusInicial(t *models.Transacao) error {
+	switch t.Status {
+	case "":
+		if !t.Data.Before(inicioDoDia(time.Now()).AddDate(0, 0, 1)) {
+			t.Status = models.StatusAgendada
+		} else {
+			t.Status = models.StatusEfetivada
 		}
-		if ativo.SaldoAtual < input.Valor {
-			return nil, ErrSaldoInsuficiente
+	case models.StatusAgendada, models.StatusPendente, models.StatusEfetivada:
+	default:
+		return ErrStatusInicialInvalido
+	}
+	return nil
+}
+
+func inicioDoDia(t time.Time) time.Time {
+	ano, mes, dia := t.Date()
+	return time.Date(ano, mes, dia, 0, 0, 0, 0, t.Location())
+}
+
+// validarCategoria garante que a categoria informada exista.
+func validarCategoria(ctx context.Context, repo repositories.CategoriaRepository, categoriaID string) error {
+	categoria, err := repo.FindByID(ctx, categoriaID)
+	if err != nil {
+		return err
+	}
+	if categoria == nil {
+		return ErrCategoriaNaoEncontrada
+	}
+	return nil
+}
+
+// prepararDivisoes valida o rateio da transação, se houver, e gera os IDs das linhas.
+// Sem categoria principal informada, a transação assume a categoria da primeira linha.
+// A soma é comparada em centavos para não depender de arredondamento de ponto flutuante.
+func prepararDivisoes(ctx context.Context, repo repositories.CategoriaRepository, t *models.Transacao) error {
+	if len(t.Divisoes) == 0 {
+		return nil
+	}
+	var soma int64
+	for i := range t.Divisoes {
+		d := &t.Divisoes[i]
+		if d.CategoriaID == "" || d.Valor <= 0 {
+			return ErrDivisaoInvalida
 		}
-	case models.TransacaoCredito:
-		// Um crédito (gasto) só pode ocorrer em um cartão de crédito.
-		if ativo.Tipo != models.AtivoCartaoCredito {
-			return nil, ErrTipoTransacaoInvalido
+		if err := validarCategoria(ctx, repo, d.CategoriaID); err != nil {
+			return err
+		}
+		d.ID = uuid.New().String()
+		soma += centavos(d.Valor)
+	}
+	if soma != centavos(t.Valor) {
+		return ErrDivisoesNaoConferem
+	}
+	if t.CategoriaID == "" {
+		t.CategoriaID = t.Divisoes[0].CategoriaID
+	}
+	return nil
+}
+
+// normalizarTags padroniza as tags em minúsculas, sem espaços nas pontas e sem repetições.
+func normalizarTags(tags []string) ([]string, error) {
+	var normalizadas []string
+	vistas := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > tamanhoMaximoTag {
+			return nil, ErrTagInvalida
 		}
-		if ativo.LimiteDisponivel < input.Valor {
-			return nil, ErrSaldoInsuficiente
+		if !vistas[tag] {
+			vistas[tag] = true
+			normalizadas = append(normalizadas, tag)
 		}
-	default:
-		return nil, ErrTipoTransacaoInvalido
 	}
+	return normalizadas, nil
+}
 
-	// 4. Preparar a transação
-	input.ID = uuid.New().String()
-	input.CreatedAt = time.Now()
+func centavos(valor float64) int64 {
+	return int64(math.Round(valor * 100))
+}
 
-	// 5. Chamar os repositórios, passando a transação (tx)
-	if err := s.transacaoRepo.Create(ctx, tx, &input); err != nil {
-		return nil, err
+// validarTransacao aplica as regras de negócio de uma transação sobre o ativo informado, usando a
+// estratégia do tipo de ativo para decidir se o tipo de transação é aceito e se o saldo resultante
+// é permitido. O saldo e o limite do ativo são usados como estão; quem edita uma transação existente
+// deve descontar antes o efeito da versão anterior. Saldo e limite só são exigidos de transações
+// efetivadas; as demais são conferidas de novo ao serem efetivadas.
+func validarTransacao(input models.Transacao, ativo *models.AtivoFinanceiro) error {
+	if !ativo.IsActive {
+		return ErrAtivoDesativado
 	}
-	if err := s.ativoRepo.UpdateBalance(ctx, tx, input.AtivoFinanceiroID, input.Valor, input.Tipo); err != nil {
-		return nil, err
+	if input.Valor <= 0 {
+		return ErrValorInvalido
 	}
 
-	return &input, tx.Commit(ctx)
-}
\ No newline at end of file
+	estrategia, err := estrategiaDoAtivo(ativo.Tipo)
+	if err != nil {
+		return err
+	}
+	efeito, ok := estrategia.Efeito(input.Tipo, input.Valor)
+	if !ok {
+		return ErrTipoTransacaoInvalido
+	}
+	if input.Status != models.StatusEfetivada {
+		return nil
+	}
+	resultante := *ativo
+	resultante.Aplicar(efeito)
+	return estrategia.ValidarSaldo(*ativo, resultante)
+}
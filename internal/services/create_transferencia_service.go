@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+const categoriaTransferencia = "Transferência"
+
+var (
+	ErrTransferenciaInvalida = erros.RegraNegocio("transferencia_invalida", "a transferência precisa de ativos de origem e destino diferentes")
+	ErrTaxaMesmaMoeda        = erros.Invalido("taxa_mesma_moeda", "a taxa só pode ser informada em transferências entre moedas diferentes")
+)
+
+// CreateTransferenciaInput descreve uma transferência entre dois ativos. 'Valor' está na moeda
+// do ativo de origem; o valor creditado no destino é convertido pela taxa informada ou, na falta
+// dela, pela taxa de câmbio vigente na data. A taxa só é aceita entre moedas diferentes.
+type CreateTransferenciaInput struct {
+	AtivoOrigemID  string     `json:"ativo_origem_id" binding:"required"`
+	AtivoDestinoID string     `json:"ativo_destino_id" binding:"required"`
+	Valor          float64    `json:"valor"`
+	Taxa           *float64   `json:"taxa"`
+	CategoriaID    string     `json:"categoria_id"`
+	Descricao      string     `json:"descricao"`
+	Data           *time.Time `json:"data"`
+}
+
+type CreateTransferenciaService struct {
+	db                *pgxpool.Pool
+	transacaoRepo     repositories.TransacaoRepository
+	ativoRepo         repositories.AtivoRepository
+	categoriaRepo     repositories.CategoriaRepository
+	transferenciaRepo repositories.TransferenciaRepository
+	conversor         *ConversorMoedas
+	eventos           *PublicadorEventos
+}
+
+func NewCreateTransferenciaService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, cRepo repositories.CategoriaRepository, trRepo repositories.TransferenciaRepository, conversor *ConversorMoedas, eventos *PublicadorEventos) *CreateTransferenciaService {
+	return &CreateTransferenciaService{
+		db:                db,
+		transacaoRepo:     tRepo,
+		ativoRepo:         aRepo,
+		categoriaRepo:     cRepo,
+		transferenciaRepo: trRepo,
+		conversor:         conversor,
+		eventos:           eventos,
+	}
+}
+
+// Execute registra a saída no ativo de origem e a entrada no ativo de destino em uma única
+// transação de banco, ligadas por um registro de transferência com a taxa usada.
+// A saída é um débito ou, em ativos que não aceitam débito (como cartões), um crédito.
+// As duas pontas são marcadas com a origem da transferência, de modo que nenhuma delas pode ser
+// editada, excluída ou estornada sozinha, deixando a outra com o efeito no saldo. Com data futura,
+// as duas ficam agendadas e são efetivadas juntas pela EfetivarTransferenciaService.
+func (s *CreateTransferenciaService) Execute(ctx context.Context, input CreateTransferenciaInput) (*models.Transferencia, error) {
+	if input.AtivoOrigemID == input.AtivoDestinoID {
+		return nil, ErrTransferenciaInvalida
+	}
+	origem, err := s.ativoRepo.FindByID(ctx, input.AtivoOrigemID)
+	if err != nil {
+		return nil, err
+	}
+	destino, err := s.ativoRepo.FindByID(ctx, input.AtivoDestinoID)
+	if err != nil {
+		return nil, err
+	}
+	if origem == nil || destino == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	if input.Taxa != nil && origem.Moeda == destino.Moeda {
+		return nil, ErrTaxaMesmaMoeda
+	}
+
+	// 1. Preparar as duas pontas com a mesma data e status
+	agora := time.Now()
+	data := agora
+	if input.Data != nil {
+		data = *input.Data
+	}
+	descricao := strings.TrimSpace(input.Descricao)
+	if descricao == "" {
+		descricao = categoriaTransferencia
+	}
+	categoriaID := input.CategoriaID
+	if categoriaID == "" {
+		categoria, err := categoriaDoSistema(ctx, s.categoriaRepo, categoriaTransferencia, "swap")
+		if err != nil {
+			return nil, err
+		}
+		categoriaID = categoria.ID
+	} else if err := validarCategoria(ctx, s.categoriaRepo, categoriaID); err != nil {
+		return nil, err
+	}
+
+	// 2. Converter o valor para a moeda de destino
+	taxa := 1.0
+	if input.Taxa != nil {
+		taxa = *input.Taxa
+	} else if taxa, err = s.conversor.Taxa(ctx, origem.Moeda, destino.Moeda, data); err != nil {
+		return nil, err
+	}
+	if taxa <= 0 {
+		return nil, ErrTaxaCambioInvalida
+	}
+	valorOrigem := math.Round(input.Valor*100) / 100
+	valorDestino := math.Round(valorOrigem*taxa*100) / 100
+
+	tipoSaida := models.TransacaoDebito
+	if !tipoTransacaoAceito(origem.Tipo, tipoSaida) {
+		tipoSaida = models.TransacaoCredito
+	}
+	saida := models.Transacao{
+		ID:                uuid.New().String(),
+		AtivoFinanceiroID: origem.ID,
+		CategoriaID:       categoriaID,
+		Descricao:         descricao,
+		Valor:             valorOrigem,
+		Tipo:              tipoSaida,
+		Data:              data,
+		Notas:             fmt.Sprintf("Transferência para %s", destino.Nome),
+		Origem:            models.OrigemTransferencia,
+		CreatedAt:         agora,
+	}
+	if err := definirStatusInicial(&saida); err != nil {
+		return nil, err
+	}
+	entrada := saida
+	entrada.ID = uuid.New().String()
+	entrada.AtivoFinanceiroID = destino.ID
+	entrada.Valor = valorDestino
+	entrada.Tipo = models.TransacaoRecebimento
+	entrada.Notas = fmt.Sprintf("Transferência de %s", origem.Nome)
+	if origem.Moeda != destino.Moeda {
+		cotacao := fmt.Sprintf(" (%.2f %s a %s/%s %.6f)", valorOrigem, origem.Moeda, origem.Moeda, destino.Moeda, taxa)
+		saida.Notas += cotacao
+		entrada.Notas += cotacao
+	}
+
+	// 3. Validar as duas pontas antes de gravar qualquer uma
+	if err := validarTransacao(saida, origem); err != nil {
+		return nil, err
+	}
+	if err := validarTransacao(entrada, destino); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, ponta := range []struct {
+		transacao *models.Transacao
+		ativo     *models.AtivoFinanceiro
+	}{{&saida, origem}, {&entrada, destino}} {
+		if err := s.transacaoRepo.Create(ctx, tx, ponta.transacao); err != nil {
+			return nil, err
+		}
+		if err := s.ativoRepo.UpdateBalance(ctx, tx, ponta.ativo.ID, efeitoAplicado(ponta.ativo.Tipo, *ponta.transacao)); err != nil {
+			return nil, err
+		}
+		if err := s.eventos.Publicar(ctx, tx, models.WebhookTransacaoCriada, ponta.transacao); err != nil {
+			return nil, err
+		}
+	}
+
+	transferencia := &models.Transferencia{
+		ID:                 uuid.New().String(),
+		TransacaoOrigemID:  saida.ID,
+		TransacaoDestinoID: entrada.ID,
+		MoedaOrigem:        origem.Moeda,
+		MoedaDestino:       destino.Moeda,
+		ValorOrigem:        valorOrigem,
+		ValorDestino:       valorDestino,
+		Taxa:               taxa,
+		CreatedAt:          agora,
+		Origem:             &saida,
+		Destino:            &entrada,
+	}
+	if err := s.transferenciaRepo.Create(ctx, tx, transferencia); err != nil {
+		return nil, err
+	}
+
+	return transferencia, tx.Commit(ctx)
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ativoRepoTransferenciaFake struct {
+	repositories.AtivoRepository
+	ativos map[string]*models.AtivoFinanceiro
+}
+
+func (f ativoRepoTransferenciaFake) FindByID(_ context.Context, id string) (*models.AtivoFinanceiro, error) {
+	return f.ativos[id], nil
+}
+
+func TestCreateTransferenciaRecusaTaxaNaMesmaMoeda(t *testing.T) {
+	ativos := ativoRepoTransferenciaFake{ativos: map[string]*models.AtivoFinanceiro{
+		"corrente": {ID: "corrente", Moeda: "BRL"},
+		"poupanca": {ID: "poupanca", Moeda: "BRL"},
+	}}
+	taxa := 5.0
+	s := NewCreateTransferenciaService(nil, nil, ativos, nil, nil, nil, nil)
+	_, err := s.Execute(context.Background(), CreateTransferenciaInput{AtivoOrigemID: "corrente", AtivoDestinoID: "poupanca", Valor: 100, Taxa: &taxa})
+	if !errors.Is(err, ErrTaxaMesmaMoeda) {
+		t.Errorf("err = %v, esperado ErrTaxaMesmaMoeda", err)
+	}
+}
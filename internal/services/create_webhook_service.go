@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrWebhookInvalido      = erros.RegraNegocio("webhook_invalido", "a assinatura exige uma URL http(s) absoluta e ao menos um evento conhecido (ou \"*\" para todos)")
+	ErrWebhookNaoEncontrado = erros.NaoEncontrado("webhook_nao_encontrado", "assinatura de webhook não encontrada")
+)
+
+type CreateWebhookInput struct {
+	URL     string                     `json:"url" binding:"required"`
+	Eventos []models.TipoEventoWebhook `json:"eventos" binding:"required"`
+}
+
+type CreateWebhookService struct {
+	repo repositories.WebhookRepository
+}
+
+func NewCreateWebhookService(repo repositories.WebhookRepository) *CreateWebhookService {
+	return &CreateWebhookService{repo: repo}
+}
+
+// Execute cadastra a assinatura com um segredo aleatório, retornado somente nesta resposta.
+func (s *CreateWebhookService) Execute(ctx context.Context, input CreateWebhookInput) (*models.WebhookAssinatura, error) {
+	endereco := strings.TrimSpace(input.URL)
+	destino, err := url.Parse(endereco)
+	if err != nil || (destino.Scheme != "http" && destino.Scheme != "https") || destino.Host == "" {
+		return nil, ErrWebhookInvalido
+	}
+
+	var eventos []models.TipoEventoWebhook
+	for _, evento := range input.Eventos {
+		if evento != models.WebhookTodosEventos && !slices.Contains(models.EventosWebhook, evento) {
+			return nil, ErrWebhookInvalido
+		}
+		if !slices.Contains(eventos, evento) {
+			eventos = append(eventos, evento)
+		}
+	}
+	if len(eventos) == 0 {
+		return nil, ErrWebhookInvalido
+	}
+
+	segredo := make([]byte, 32)
+	if _, err := rand.Read(segredo); err != nil {
+		return nil, err
+	}
+	agora := time.Now()
+	assinatura := &models.WebhookAssinatura{
+		ID:        uuid.New().String(),
+		URL:       endereco,
+		Segredo:   hex.EncodeToString(segredo),
+		Eventos:   eventos,
+		Ativo:     true,
+		CreatedAt: agora,
+		UpdatedAt: agora,
+	}
+	if err := s.repo.CreateAssinatura(ctx, assinatura); err != nil {
+		return nil, err
+	}
+	return assinatura, nil
+}
@@ -2,23 +2,56 @@ package services
 
 import (
 	"context"
+	"time"
 
-	"controlador/backend/internal/repositories"
+	"github.com/jackc/pgx/v5/pgxpool"
 
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
 )
 
 type DeactivateAtivoService struct {
-	repo repositories.AtivoRepository
+	db             *pgxpool.Pool
+	repo           repositories.AtivoRepository
+	recorrenteRepo repositories.TransacaoRecorrenteRepository
+	eventos        *PublicadorEventos
 }
 
-func NewDeactivateAtivoService(repo repositories.AtivoRepository) *DeactivateAtivoService {
-	return &DeactivateAtivoService{repo: repo}
+func NewDeactivateAtivoService(db *pgxpool.Pool, repo repositories.AtivoRepository, rRepo repositories.TransacaoRecorrenteRepository, eventos *PublicadorEventos) *DeactivateAtivoService {
+	return &DeactivateAtivoService{db: db, repo: repo, recorrenteRepo: rRepo, eventos: eventos}
 }
 
-func (s *DeactivateAtivoService) Execute(ctx context.Context, id string) error {
-	ativo, err := s.repo.FindByID(ctx, id)
-	if err != nil { return err }
-	if ativo == nil { return ErrAtivoNaoEncontrado }
+// Execute desativa o ativo e pausa suas recorrências ativas, que seriam rejeitadas pelo
+// worker enquanto o ativo estiver desativado. As recorrências pausadas são informadas
+// no resultado e retomadas automaticamente se o ativo for reativado.
+func (s *DeactivateAtivoService) Execute(ctx context.Context, id string) (*models.AlteracaoStatusAtivo, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ativo, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
 
-	return s.repo.Deactivate(ctx, id)
-}
\ No newline at end of file
+	if err := s.repo.Deactivate(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	pausadas, err := s.recorrenteRepo.PausarPorAtivo(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ativo.IsActive = false
+	ativo.UpdatedAt = time.Now()
+	alteracao := &models.AlteracaoStatusAtivo{Ativo: ativo, RecorrenciasPausadas: pausadas}
+	if err := s.eventos.Publicar(ctx, tx, models.WebhookAtivoDesativado, alteracao); err != nil {
+		return nil, err
+	}
+	return alteracao, tx.Commit(ctx)
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+	"controlador/backend/internal/storage"
+)
+
+type DeleteAnexoService struct {
+	storage   storage.Storage
+	anexoRepo repositories.AnexoRepository
+}
+
+func NewDeleteAnexoService(st storage.Storage, aRepo repositories.AnexoRepository) *DeleteAnexoService {
+	return &DeleteAnexoService{storage: st, anexoRepo: aRepo}
+}
+
+// Execute remove o registro do anexo e, em seguida, os arquivos. Uma falha ao apagar os arquivos
+// apenas é registrada em log: o anexo já deixou de existir para a aplicação.
+func (s *DeleteAnexoService) Execute(ctx context.Context, id string) error {
+	anexo, err := s.anexoRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if anexo == nil {
+		return ErrAnexoNaoEncontrado
+	}
+	if err := s.anexoRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	apagarArquivosAnexo(ctx, s.storage, *anexo)
+	return nil
+}
+
+// apagarArquivosAnexo remove o arquivo e a miniatura de um anexo já excluído do banco.
+// Falhas apenas são registradas em log: o anexo já deixou de existir para a aplicação.
+func apagarArquivosAnexo(ctx context.Context, st storage.Storage, anexo models.Anexo) {
+	chaves := []string{anexo.Chave}
+	if anexo.ChaveMiniatura != nil {
+		chaves = append(chaves, *anexo.ChaveMiniatura)
+	}
+	for _, chave := range chaves {
+		if err := st.Delete(ctx, chave); err != nil {
+			log.Error().Err(err).Str("chave", chave).Msg("Falha ao apagar arquivo de anexo excluído.")
+		}
+	}
+}
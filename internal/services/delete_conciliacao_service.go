@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type DeleteConciliacaoService struct {
+	db              *pgxpool.Pool
+	conciliacaoRepo repositories.ConciliacaoRepository
+}
+
+func NewDeleteConciliacaoService(db *pgxpool.Pool, cRepo repositories.ConciliacaoRepository) *DeleteConciliacaoService {
+	return &DeleteConciliacaoService{db: db, conciliacaoRepo: cRepo}
+}
+
+// Execute descarta uma conciliação aberta, por exemplo para corrigir o saldo do extrato.
+// As transações marcadas continuam conferidas e aparecem assim na próxima conciliação.
+func (s *DeleteConciliacaoService) Execute(ctx context.Context, id string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	conciliacao, err := s.conciliacaoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if conciliacao == nil {
+		return ErrConciliacaoNaoEncontrada
+	}
+	if conciliacao.Status != models.ConciliacaoAberta {
+		return ErrConciliacaoConcluida
+	}
+	if err := s.conciliacaoRepo.Delete(ctx, tx, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/repositories"
+)
+
+type DeleteMetaService struct {
+	repo repositories.MetaRepository
+}
+
+func NewDeleteMetaService(repo repositories.MetaRepository) *DeleteMetaService {
+	return &DeleteMetaService{repo: repo}
+}
+
+// Execute exclui a meta e seus vínculos; os ativos e as transações não são afetados.
+func (s *DeleteMetaService) Execute(ctx context.Context, id string) error {
+	meta, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		return ErrMetaNaoEncontrada
+	}
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrOperacaoInvestimentoNaoEncontrada = erros.NaoEncontrado("operacao_investimento_nao_encontrada", "operação de investimento não encontrada")
+
+type DeleteOperacaoInvestimentoService struct {
+	db               *pgxpool.Pool
+	investimentoRepo repositories.InvestimentoRepository
+	transacaoRepo    repositories.TransacaoRepository
+	ativoRepo        repositories.AtivoRepository
+	historicoRepo    repositories.TransacaoHistoricoRepository
+}
+
+func NewDeleteOperacaoInvestimentoService(db *pgxpool.Pool, iRepo repositories.InvestimentoRepository, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, hRepo repositories.TransacaoHistoricoRepository) *DeleteOperacaoInvestimentoService {
+	return &DeleteOperacaoInvestimentoService{
+		db:               db,
+		investimentoRepo: iRepo,
+		transacaoRepo:    tRepo,
+		ativoRepo:        aRepo,
+		historicoRepo:    hRepo,
+	}
+}
+
+// Execute exclui a operação e a transação que a liquidou, desfazendo o efeito no ativo.
+// A exclusão é recusada se deixar alguma venda posterior acima da quantidade em carteira.
+func (s *DeleteOperacaoInvestimentoService) Execute(ctx context.Context, id string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Carregar a operação e conferir as demais operações do título sem ela
+	operacao, err := s.investimentoRepo.FindOperacaoByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if operacao == nil {
+		return ErrOperacaoInvestimentoNaoEncontrada
+	}
+	if err := s.investimentoRepo.LockTitulo(ctx, tx, operacao.TituloID); err != nil {
+		return err
+	}
+	operacoes, err := s.investimentoRepo.FindOperacoesTx(ctx, tx, operacao.TituloID)
+	if err != nil {
+		return err
+	}
+	restantes := operacoes[:0]
+	for _, o := range operacoes {
+		if o.ID != operacao.ID {
+			restantes = append(restantes, o)
+		}
+	}
+	if _, err := apurarOperacoes(restantes); err != nil {
+		return err
+	}
+
+	// 2. Carregar a transação de liquidação; conciliada, ela precisa ser desbloqueada antes
+	transacao, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, operacao.TransacaoID)
+	if err != nil {
+		return err
+	}
+	if transacao == nil {
+		return ErrTransacaoNaoEncontrada
+	}
+	if transacao.Bloqueada {
+		return ErrTransacaoBloqueada
+	}
+	ativo, err := s.ativoRepo.FindByID(ctx, transacao.AtivoFinanceiroID)
+	if err != nil {
+		return err
+	}
+	if ativo == nil {
+		return ErrAtivoNaoEncontrado
+	}
+
+	// 3. Registrar a transação excluída no histórico, desfazer o efeito e excluir
+	if err := s.historicoRepo.Create(ctx, tx, &models.TransacaoHistorico{
+		ID:          uuid.New().String(),
+		TransacaoID: transacao.ID,
+		Operacao:    models.HistoricoExclusao,
+		Dados:       *transacao,
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		return err
+	}
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, ativo.ID, efeitoAplicado(ativo.Tipo, *transacao).Inverso()); err != nil {
+		return err
+	}
+	if err := s.investimentoRepo.DeleteOperacao(ctx, tx, operacao.ID); err != nil {
+		return err
+	}
+	if err := s.transacaoRepo.Delete(ctx, tx, transacao.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/repositories"
+)
+
+type DeleteOrcamentoService struct {
+	repo repositories.OrcamentoRepository
+}
+
+func NewDeleteOrcamentoService(repo repositories.OrcamentoRepository) *DeleteOrcamentoService {
+	return &DeleteOrcamentoService{repo: repo}
+}
+
+func (s *DeleteOrcamentoService) Execute(ctx context.Context, categoriaID string) error {
+	orcamento, err := s.repo.FindByCategoriaID(ctx, categoriaID)
+	if err != nil {
+		return err
+	}
+	if orcamento == nil {
+		return ErrOrcamentoNaoEncontrado
+	}
+	return s.repo.Delete(ctx, categoriaID)
+}
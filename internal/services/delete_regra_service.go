@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrRegraNaoEncontrada = erros.NaoEncontrado("regra_nao_encontrada", "regra não encontrada")
+
+type DeleteRegraService struct {
+	repo repositories.RegraRepository
+}
+
+func NewDeleteRegraService(repo repositories.RegraRepository) *DeleteRegraService {
+	return &DeleteRegraService{repo: repo}
+}
+
+func (s *DeleteRegraService) Execute(ctx context.Context, id string) error {
+	regra, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if regra == nil {
+		return ErrRegraNaoEncontrada
+	}
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+	"controlador/backend/internal/storage"
+)
+
+type DeleteTransacaoService struct {
+	db            *pgxpool.Pool
+	transacaoRepo repositories.TransacaoRepository
+	ativoRepo     repositories.AtivoRepository
+	historicoRepo repositories.TransacaoHistoricoRepository
+	anexoRepo     repositories.AnexoRepository
+	storage       storage.Storage
+}
+
+func NewDeleteTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, hRepo repositories.TransacaoHistoricoRepository, anRepo repositories.AnexoRepository, st storage.Storage) *DeleteTransacaoService {
+	return &DeleteTransacaoService{
+		db:            db,
+		transacaoRepo: tRepo,
+		ativoRepo:     aRepo,
+		historicoRepo: hRepo,
+		anexoRepo:     anRepo,
+		storage:       st,
+	}
+}
+
+// Execute exclui a transação, desfazendo seu efeito no ativo e guardando a última versão no histórico.
+// Os arquivos dos anexos só são apagados depois da confirmação, para não perdê-los em um rollback.
+func (s *DeleteTransacaoService) Execute(ctx context.Context, id string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Carregar e bloquear a transação
+	transacao, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := validarTransacaoEditavel(transacao); err != nil {
+		return err
+	}
+
+	ativo, err := s.ativoRepo.FindByID(ctx, transacao.AtivoFinanceiroID)
+	if err != nil {
+		return err
+	}
+	if ativo == nil {
+		return ErrAtivoNaoEncontrado
+	}
+
+	// 2. Registrar a versão excluída no histórico
+	if err := s.historicoRepo.Create(ctx, tx, &models.TransacaoHistorico{
+		ID:          uuid.New().String(),
+		TransacaoID: transacao.ID,
+		Operacao:    models.HistoricoExclusao,
+		Dados:       *transacao,
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	// 3. Desfazer o efeito no ativo e excluir
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, transacao.AtivoFinanceiroID, efeitoAplicado(ativo.Tipo, *transacao).Inverso()); err != nil {
+		return err
+	}
+	anexos, err := s.anexoRepo.DeleteAllByTransacaoID(ctx, tx, transacao.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.transacaoRepo.Delete(ctx, tx, transacao.ID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	for _, anexo := range anexos {
+		apagarArquivosAnexo(ctx, s.storage, anexo)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/repositories"
+)
+
+type DeleteWebhookService struct {
+	repo repositories.WebhookRepository
+}
+
+func NewDeleteWebhookService(repo repositories.WebhookRepository) *DeleteWebhookService {
+	return &DeleteWebhookService{repo: repo}
+}
+
+// Execute exclui a assinatura e, com ela, o registro de suas entregas.
+func (s *DeleteWebhookService) Execute(ctx context.Context, id string) error {
+	assinatura, err := s.repo.FindAssinaturaByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if assinatura == nil {
+		return ErrWebhookNaoEncontrado
+	}
+	return s.repo.DeleteAssinatura(ctx, id)
+}
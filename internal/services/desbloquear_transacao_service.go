@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type DesbloquearTransacaoService struct {
+	db            *pgxpool.Pool
+	transacaoRepo repositories.TransacaoRepository
+}
+
+func NewDesbloquearTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository) *DesbloquearTransacaoService {
+	return &DesbloquearTransacaoService{db: db, transacaoRepo: tRepo}
+}
+
+// Execute libera uma transação conciliada para edição, exclusão ou estorno. Ela continua
+// conferida e volta a ser bloqueada quando uma nova conciliação do ativo for concluída.
+func (s *DesbloquearTransacaoService) Execute(ctx context.Context, id string) (*models.Transacao, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrTransacaoNaoEncontrada
+	}
+	if err := s.transacaoRepo.Desbloquear(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	t.Bloqueada = false
+	return t, tx.Commit(ctx)
+}
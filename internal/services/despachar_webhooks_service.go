@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+	"controlador/backend/internal/webhook"
+)
+
+const (
+	loteEventosWebhook   = 100
+	loteEnviosWebhook    = 50
+	maxTentativasWebhook = 8
+	// intervaloBaseWebhook dobra a cada falha: 1, 2, 4... minutos, até o teto.
+	intervaloBaseWebhook   = time.Minute
+	intervaloMaximoWebhook = 6 * time.Hour
+	// reservaEnvioWebhook é por quanto tempo uma entrega em envio fica fora da fila.
+	reservaEnvioWebhook = 5 * time.Minute
+)
+
+// DespacharWebhooksService é o worker dos webhooks: distribui os eventos do outbox entre as
+// assinaturas interessadas e envia as entregas pendentes, com novas tentativas em backoff
+// exponencial até 'maxTentativasWebhook'.
+type DespacharWebhooksService struct {
+	db      *pgxpool.Pool
+	repo    repositories.WebhookRepository
+	cliente *webhook.Cliente
+}
+
+func NewDespacharWebhooksService(db *pgxpool.Pool, repo repositories.WebhookRepository, cliente *webhook.Cliente) *DespacharWebhooksService {
+	return &DespacharWebhooksService{db: db, repo: repo, cliente: cliente}
+}
+
+// corpoEventoWebhook é o JSON enviado ao destino; 'id' identifica o evento e se repete nas
+// novas tentativas, permitindo ao destino descartar duplicatas.
+type corpoEventoWebhook struct {
+	ID        string                   `json:"id"`
+	Tipo      models.TipoEventoWebhook `json:"tipo"`
+	CreatedAt time.Time                `json:"created_at"`
+	Dados     json.RawMessage          `json:"dados"`
+}
+
+func (s *DespacharWebhooksService) Execute(ctx context.Context) (*RelatorioProcessamento, error) {
+	for {
+		distribuidos, err := s.distribuir(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if distribuidos < loteEventosWebhook {
+			break
+		}
+	}
+
+	relatorio := &RelatorioProcessamento{}
+	for {
+		agora := time.Now()
+		envios, err := s.repo.ReservarEnvios(ctx, agora, agora.Add(reservaEnvioWebhook), loteEnviosWebhook)
+		if err != nil {
+			return nil, err
+		}
+		if len(envios) == 0 {
+			break
+		}
+		relatorio.TotalParaProcessar += len(envios)
+		for _, envio := range envios {
+			if err := s.enviar(ctx, envio); err != nil {
+				relatorio.Falhas++
+				relatorio.Erros = append(relatorio.Erros, fmt.Sprintf("entrega %s: %v", envio.Entrega.ID, err))
+			} else {
+				relatorio.Sucesso++
+			}
+		}
+	}
+
+	log.Info().Interface("relatorio", relatorio).Msg("Despacho de webhooks concluído.")
+	return relatorio, nil
+}
+
+// distribuir cria uma entrega por assinatura ativa interessada em cada evento ainda não
+// distribuído e marca os eventos como distribuídos, tudo na mesma transação de banco.
+// Retorna quantos eventos foram processados.
+func (s *DespacharWebhooksService) distribuir(ctx context.Context) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	eventos, err := s.repo.FindEventosNaoDistribuidos(ctx, tx, loteEventosWebhook)
+	if err != nil || len(eventos) == 0 {
+		return 0, err
+	}
+	assinaturas, err := s.repo.FindAssinaturas(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	agora := time.Now()
+	ids := make([]string, len(eventos))
+	for i, evento := range eventos {
+		ids[i] = evento.ID
+		for _, a := range assinaturas {
+			if !a.Ativo || (!slices.Contains(a.Eventos, evento.Tipo) && !slices.Contains(a.Eventos, models.WebhookTodosEventos)) {
+				continue
+			}
+			entrega := &models.EntregaWebhook{
+				ID:                 uuid.New().String(),
+				AssinaturaID:       a.ID,
+				EventoID:           evento.ID,
+				Status:             models.EntregaPendente,
+				ProximaTentativaEm: &agora,
+				CreatedAt:          agora,
+				UpdatedAt:          agora,
+			}
+			if err := s.repo.CreateEntrega(ctx, tx, entrega); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := s.repo.MarcarEventosDistribuidos(ctx, tx, ids, agora); err != nil {
+		return 0, err
+	}
+	return len(eventos), tx.Commit(ctx)
+}
+
+// enviar faz uma tentativa de entrega e registra o resultado; o erro retornado é o da tentativa.
+func (s *DespacharWebhooksService) enviar(ctx context.Context, envio models.EnvioWebhook) error {
+	corpo, err := json.Marshal(corpoEventoWebhook{
+		ID:        envio.Evento.ID,
+		Tipo:      envio.Evento.Tipo,
+		CreatedAt: envio.Evento.CreatedAt,
+		Dados:     envio.Evento.Dados,
+	})
+	if err != nil {
+		return err
+	}
+
+	entrega := envio.Entrega
+	entrega.Tentativas++
+	cabecalhos := map[string]string{
+		"X-Controlador-Evento":    string(envio.Evento.Tipo),
+		"X-Controlador-Entrega":   entrega.ID,
+		"X-Controlador-Tentativa": strconv.Itoa(entrega.Tentativas),
+	}
+	status, erroEnvio := s.cliente.Enviar(ctx, envio.URL, envio.Segredo, cabecalhos, corpo)
+
+	agora := time.Now()
+	entrega.UpdatedAt = agora
+	entrega.UltimoStatusHTTP = nil
+	if status != 0 {
+		entrega.UltimoStatusHTTP = &status
+	}
+	switch {
+	case erroEnvio == nil:
+		entrega.Status = models.EntregaEntregue
+		entrega.EntregueEm = &agora
+		entrega.ProximaTentativaEm = nil
+		entrega.UltimoErro = nil
+	case entrega.Tentativas >= maxTentativasWebhook:
+		mensagem := erroEnvio.Error()
+		entrega.Status = models.EntregaFalhou
+		entrega.ProximaTentativaEm = nil
+		entrega.UltimoErro = &mensagem
+	default:
+		mensagem := erroEnvio.Error()
+		proxima := agora.Add(intervaloNovaTentativa(entrega.Tentativas))
+		entrega.ProximaTentativaEm = &proxima
+		entrega.UltimoErro = &mensagem
+	}
+
+	if err := s.repo.RegistrarTentativa(ctx, &entrega); err != nil {
+		return err
+	}
+	if erroEnvio != nil {
+		log.Warn().Err(erroEnvio).Str("entrega_id", entrega.ID).Int("tentativa", entrega.Tentativas).Msg("Falha ao entregar webhook.")
+	}
+	return erroEnvio
+}
+
+// intervaloNovaTentativa calcula a espera após a n-ésima tentativa falha.
+func intervaloNovaTentativa(tentativas int) time.Duration {
+	intervalo := intervaloBaseWebhook << (tentativas - 1)
+	return min(intervalo, intervaloMaximoWebhook)
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// criterioDuplicataPadrao considera duplicatas transações do mesmo ativo com valores a até 1%
+// (ou R$ 0,01) de diferença, datas a até 3 dias e ao menos metade das palavras da descrição em comum.
+var criterioDuplicataPadrao = models.CriterioDuplicata{
+	ToleranciaMinima:     0.01,
+	ToleranciaPercentual: 0.01,
+	Janela:               3 * 24 * time.Hour,
+	SimilaridadeMinima:   0.5,
+}
+
+// DetectorDuplicatas identifica transações que provavelmente representam o mesmo lançamento,
+// como uma compra digitada à mão e depois importada, ou uma recorrência já registrada.
+type DetectorDuplicatas struct {
+	repo     repositories.TransacaoRepository
+	criterio models.CriterioDuplicata
+}
+
+func NewDetectorDuplicatas(repo repositories.TransacaoRepository) *DetectorDuplicatas {
+	return &DetectorDuplicatas{repo: repo, criterio: criterioDuplicataPadrao}
+}
+
+// Candidatas retorna os IDs das transações já registradas que parecem duplicatas de 't'.
+func (d *DetectorDuplicatas) Candidatas(ctx context.Context, t models.Transacao) ([]string, error) {
+	proximas, err := d.repo.FindCandidatasDuplicata(ctx, t, d.criterio)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, p := range proximas {
+		if similaridadeDescricao(t.Descricao, p.Descricao) >= d.criterio.SimilaridadeMinima {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids, nil
+}
+
+// Pares lista os pares de possíveis duplicatas do período.
+func (d *DetectorDuplicatas) Pares(ctx context.Context, periodo Periodo) ([]models.ParDuplicata, error) {
+	inicio, fim, err := periodo.Limites()
+	if err != nil {
+		return nil, err
+	}
+	idsPares, err := d.repo.FindParesDuplicata(ctx, d.criterio, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	if len(idsPares) == 0 {
+		return []models.ParDuplicata{}, nil
+	}
+
+	ids := make([]string, 0, len(idsPares)*2)
+	for _, par := range idsPares {
+		ids = append(ids, par[0], par[1])
+	}
+	transacoes, err := d.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	porID := make(map[string]models.Transacao, len(transacoes))
+	for _, t := range transacoes {
+		porID[t.ID] = t
+	}
+
+	pares := []models.ParDuplicata{}
+	for _, par := range idsPares {
+		a, b := porID[par[0]], porID[par[1]]
+		similaridade := similaridadeDescricao(a.Descricao, b.Descricao)
+		if similaridade >= d.criterio.SimilaridadeMinima {
+			pares = append(pares, models.ParDuplicata{Transacao: a, Duplicata: b, Similaridade: similaridade})
+		}
+	}
+	return pares, nil
+}
+
+// similaridadeDescricao mede a semelhança entre duas descrições pelo índice de Jaccard das palavras,
+// ignorando maiúsculas, pontuação e o prefixo usado pelas recorrências.
+func similaridadeDescricao(a, b string) float64 {
+	pa, pb := palavras(a), palavras(b)
+	if len(pa) == 0 || len(pb) == 0 {
+		return 0
+	}
+	comuns := 0
+	for p := range pa {
+		if pb[p] {
+			comuns++
+		}
+	}
+	return float64(comuns) / float64(len(pa)+len(pb)-comuns)
+}
+
+func palavras(s string) map[string]bool {
+	s = strings.TrimPrefix(strings.ToLower(s), "recorrência:")
+	campos := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	conjunto := make(map[string]bool, len(campos))
+	for _, c := range campos {
+		conjunto[c] = true
+	}
+	return conjunto
+}
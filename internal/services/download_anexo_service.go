@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+	"controlador/backend/internal/storage"
+)
+
+var (
+	ErrAnexoNaoEncontrado     = erros.NaoEncontrado("anexo_nao_encontrado", "anexo não encontrado")
+	ErrMiniaturaNaoEncontrada = erros.NaoEncontrado("miniatura_nao_encontrada", "o anexo não possui miniatura")
+)
+
+type DownloadAnexoService struct {
+	storage   storage.Storage
+	anexoRepo repositories.AnexoRepository
+}
+
+func NewDownloadAnexoService(st storage.Storage, aRepo repositories.AnexoRepository) *DownloadAnexoService {
+	return &DownloadAnexoService{storage: st, anexoRepo: aRepo}
+}
+
+// Execute abre o conteúdo do anexo ou, com 'miniatura', da sua miniatura em JPEG.
+// Quem chama é responsável por fechar o leitor retornado.
+func (s *DownloadAnexoService) Execute(ctx context.Context, id string, miniatura bool) (*models.Anexo, io.ReadCloser, error) {
+	anexo, err := s.anexoRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if anexo == nil {
+		return nil, nil, ErrAnexoNaoEncontrado
+	}
+
+	chave := anexo.Chave
+	if miniatura {
+		if anexo.ChaveMiniatura == nil {
+			return nil, nil, ErrMiniaturaNaoEncontrada
+		}
+		chave = *anexo.ChaveMiniatura
+	}
+
+	conteudo, err := s.storage.Get(ctx, chave)
+	if err != nil {
+		if errors.Is(err, storage.ErrArquivoNaoEncontrado) {
+			return nil, nil, ErrAnexoNaoEncontrado
+		}
+		return nil, nil, err
+	}
+	return anexo, conteudo, nil
+}
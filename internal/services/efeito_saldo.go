@@ -0,0 +1,32 @@
+package services
+
+import (
+	"controlador/backend/internal/models"
+)
+
+// efeitoTransacao calcula como uma transação do tipo informado altera o saldo ou o limite de um
+// ativo do tipo 'tipoAtivo', segundo a estratégia do tipo de ativo. Combinações não aceitas pela
+// estratégia não têm efeito; a validação da transação as rejeita antes.
+func efeitoTransacao(tipoAtivo models.TipoAtivo, tipo models.TipoTransacao, valor float64) models.EfeitoSaldo {
+	estrategia, err := estrategiaDoAtivo(tipoAtivo)
+	if err != nil {
+		return models.EfeitoSaldo{}
+	}
+	efeito, _ := estrategia.Efeito(tipo, valor)
+	return efeito
+}
+
+// efeitoEstorno calcula o efeito de estornar 'valor' de uma transação original,
+// desfazendo exatamente o que a original aplicou sobre o ativo.
+func efeitoEstorno(tipoAtivo models.TipoAtivo, original models.Transacao, valor float64) models.EfeitoSaldo {
+	return efeitoTransacao(tipoAtivo, original.Tipo, valor).Inverso()
+}
+
+// efeitoAplicado retorna o efeito que a transação tem hoje sobre o ativo: transações agendadas,
+// pendentes ou canceladas ainda não alteraram o saldo nem o limite.
+func efeitoAplicado(tipoAtivo models.TipoAtivo, t models.Transacao) models.EfeitoSaldo {
+	if t.Status != models.StatusEfetivada {
+		return models.EfeitoSaldo{}
+	}
+	return efeitoTransacao(tipoAtivo, t.Tipo, t.Valor)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrTransacaoNaoPendente = erros.Conflito("transacao_nao_pendente", "somente transações agendadas ou pendentes podem ser efetivadas ou canceladas")
+
+type EfetivarTransacaoService struct {
+	db            *pgxpool.Pool
+	transacaoRepo repositories.TransacaoRepository
+	ativoRepo     repositories.AtivoRepository
+	historicoRepo repositories.TransacaoHistoricoRepository
+}
+
+func NewEfetivarTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, hRepo repositories.TransacaoHistoricoRepository) *EfetivarTransacaoService {
+	return &EfetivarTransacaoService{
+		db:            db,
+		transacaoRepo: tRepo,
+		ativoRepo:     aRepo,
+		historicoRepo: hRepo,
+	}
+}
+
+// Execute efetiva uma transação agendada ou pendente, aplicando seu efeito sobre o ativo.
+// O saldo e o limite são conferidos agora, com os valores do momento da efetivação. As pontas de
+// uma transferência são efetivadas juntas, pela própria transferência.
+func (s *EfetivarTransacaoService) Execute(ctx context.Context, id string) (*models.Transacao, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Carregar e bloquear a transação
+	original, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, ErrTransacaoNaoEncontrada
+	}
+	if original.Origem != "" {
+		return nil, ErrTransacaoGerada
+	}
+
+	efetivada, err := s.efetivar(ctx, tx, original)
+	if err != nil {
+		return nil, err
+	}
+	return efetivada, tx.Commit(ctx)
+}
+
+// efetivar valida e aplica a transação já bloqueada dentro da transação de banco de quem chama.
+func (s *EfetivarTransacaoService) efetivar(ctx context.Context, tx pgx.Tx, original *models.Transacao) (*models.Transacao, error) {
+	if original.Status != models.StatusAgendada && original.Status != models.StatusPendente {
+		return nil, ErrTransacaoNaoPendente
+	}
+
+	// 2. Validar a transação como efetivada sobre o ativo atual
+	efetivada := *original
+	efetivada.Status = models.StatusEfetivada
+	ativo, err := s.ativoRepo.FindByID(ctx, efetivada.AtivoFinanceiroID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	if err := validarTransacao(efetivada, ativo); err != nil {
+		return nil, err
+	}
+
+	// 3. Registrar a versão anterior, aplicar o efeito e atualizar o status
+	if err := s.historicoRepo.Create(ctx, tx, &models.TransacaoHistorico{
+		ID:          uuid.New().String(),
+		TransacaoID: original.ID,
+		Operacao:    models.HistoricoAtualizacao,
+		Dados:       *original,
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, efetivada.AtivoFinanceiroID, efeitoAplicado(ativo.Tipo, efetivada)); err != nil {
+		return nil, err
+	}
+	if err := s.transacaoRepo.AtualizarStatus(ctx, tx, efetivada.ID, efetivada.Status); err != nil {
+		return nil, err
+	}
+	return &efetivada, nil
+}
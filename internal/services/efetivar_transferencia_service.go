@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrTransferenciaNaoEncontrada = erros.NaoEncontrado("transferencia_nao_encontrada", "transferência não encontrada")
+
+type EfetivarTransferenciaService struct {
+	db                *pgxpool.Pool
+	transacaoRepo     repositories.TransacaoRepository
+	transferenciaRepo repositories.TransferenciaRepository
+	efetivarService   *EfetivarTransacaoService
+}
+
+func NewEfetivarTransferenciaService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, trRepo repositories.TransferenciaRepository, efetivarSvc *EfetivarTransacaoService) *EfetivarTransferenciaService {
+	return &EfetivarTransferenciaService{
+		db:                db,
+		transacaoRepo:     tRepo,
+		transferenciaRepo: trRepo,
+		efetivarService:   efetivarSvc,
+	}
+}
+
+// Execute efetiva as duas pontas de uma transferência agendada em uma única transação de banco:
+// se a saída ou a entrada não puder ser efetivada, nenhuma delas é, e o valor não sai de um ativo
+// sem chegar ao outro.
+func (s *EfetivarTransferenciaService) Execute(ctx context.Context, id string) (*models.Transferencia, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Bloquear a transferência e as duas pontas
+	transferencia, err := s.transferenciaRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transferencia == nil {
+		return nil, ErrTransferenciaNaoEncontrada
+	}
+	pontas := make([]*models.Transacao, 0, 2)
+	for _, transacaoID := range []string{transferencia.TransacaoOrigemID, transferencia.TransacaoDestinoID} {
+		ponta, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, transacaoID)
+		if err != nil {
+			return nil, err
+		}
+		if ponta == nil {
+			return nil, ErrTransacaoNaoEncontrada
+		}
+		pontas = append(pontas, ponta)
+	}
+
+	// 2. Efetivar a saída e a entrada juntas
+	for i, ponta := range pontas {
+		if pontas[i], err = s.efetivarService.efetivar(ctx, tx, ponta); err != nil {
+			return nil, err
+		}
+	}
+	transferencia.Origem, transferencia.Destino = pontas[0], pontas[1]
+
+	return transferencia, tx.Commit(ctx)
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// limiteEntregasListadas limita o registro de entregas devolvido por assinatura.
+const limiteEntregasListadas = 100
+
+var ErrEntregaWebhookNaoEncontrada = erros.NaoEncontrado("entrega_webhook_nao_encontrada", "entrega de webhook não encontrada")
+
+type EntregasWebhookService struct {
+	repo repositories.WebhookRepository
+}
+
+func NewEntregasWebhookService(repo repositories.WebhookRepository) *EntregasWebhookService {
+	return &EntregasWebhookService{repo: repo}
+}
+
+// Listar retorna as entregas mais recentes da assinatura.
+func (s *EntregasWebhookService) Listar(ctx context.Context, assinaturaID string) ([]models.EntregaWebhook, error) {
+	assinatura, err := s.repo.FindAssinaturaByID(ctx, assinaturaID)
+	if err != nil {
+		return nil, err
+	}
+	if assinatura == nil {
+		return nil, ErrWebhookNaoEncontrado
+	}
+	entregas, err := s.repo.FindEntregas(ctx, assinaturaID, limiteEntregasListadas)
+	if err != nil {
+		return nil, err
+	}
+	if entregas == nil {
+		entregas = []models.EntregaWebhook{}
+	}
+	return entregas, nil
+}
+
+// Reenviar coloca a entrega de volta na fila com um novo ciclo de tentativas; ela é enviada na
+// próxima execução do worker, mesmo que já tenha sido entregue antes.
+func (s *EntregasWebhookService) Reenviar(ctx context.Context, id string) (*models.EntregaWebhook, error) {
+	entrega, err := s.repo.FindEntregaByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entrega == nil {
+		return nil, ErrEntregaWebhookNaoEncontrada
+	}
+	if err := s.repo.ReagendarEntrega(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.repo.FindEntregaByID(ctx, id)
+}
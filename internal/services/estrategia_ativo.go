@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+)
+
+var (
+	ErrTipoAtivoSemEstrategia  = erros.RegraNegocio("tipo_ativo_sem_estrategia", "tipo de ativo sem regras de transação registradas")
+	ErrPagamentoExcedeDivida   = erros.RegraNegocio("pagamento_excede_divida", "o pagamento excede o saldo devedor do empréstimo")
+	ErrSaldoEmprestimoInvalido = erros.RegraNegocio("saldo_emprestimo_invalido", "o saldo de um empréstimo representa a dívida e deve ser zero ou negativo")
+	ErrLimiteChequeEspecial    = erros.RegraNegocio("limite_cheque_especial", "a transação ultrapassa o limite do cheque especial")
+	ErrChequeEspecialInvalido  = erros.RegraNegocio("cheque_especial_invalido", "o limite e a taxa de juros do cheque especial não podem ser negativos")
+	ErrChequeEspecialIndevido  = erros.RegraNegocio("cheque_especial_indevido", "cheque especial só pode ser configurado em contas correntes")
+	ErrLimiteTotalInvalido     = erros.RegraNegocio("limite_total_invalido", "o limite total do cartão não pode ser negativo")
+	ErrLimiteExcedeTotal       = erros.RegraNegocio("limite_excede_total", "o limite disponível não pode ser maior que o limite total do cartão")
+)
+
+// EstrategiaAtivo define as regras de um tipo de ativo: quais tipos de transação ele aceita,
+// como cada uma move o saldo e o limite e quais estados de saldo são permitidos.
+// Novos tipos de ativo são suportados registrando uma estratégia com RegistrarEstrategiaAtivo.
+type EstrategiaAtivo interface {
+	// Efeito retorna a variação que a transação causa no ativo; 'ok' é falso se o tipo
+	// de transação não é aceito por este tipo de ativo.
+	Efeito(tipo models.TipoTransacao, valor float64) (efeito models.EfeitoSaldo, ok bool)
+	// ValidarSaldo confere se o ativo pode passar do estado 'atual' para 'resultante'.
+	ValidarSaldo(atual, resultante models.AtivoFinanceiro) error
+	// ValidarAtivo confere os valores iniciais de um novo ativo.
+	ValidarAtivo(ativo models.AtivoFinanceiro) error
+	// ValorSaldoInicial retorna o valor do lançamento de saldo inicial (models.TransacaoSaldoInicial)
+	// que leva um ativo recém-criado, zerado e com todo o limite disponível, ao estado informado.
+	ValorSaldoInicial(ativo models.AtivoFinanceiro) float64
+}
+
+var (
+	estrategiasMu sync.RWMutex
+	estrategias   = map[models.TipoAtivo]EstrategiaAtivo{
+		models.AtivoContaCorrente: estrategiaContaCorrente{},
+		models.AtivoPoupanca:      estrategiaSaldo{},
+		models.AtivoDinheiro:      estrategiaSaldo{},
+		models.AtivoInvestimento:  estrategiaSaldo{},
+		models.AtivoValeRefeicao:  estrategiaSaldo{},
+		models.AtivoCartaoCredito: estrategiaCartaoCredito{},
+		models.AtivoEmprestimo:    estrategiaEmprestimo{},
+	}
+)
+
+// RegistrarEstrategiaAtivo associa (ou substitui) a estratégia de um tipo de ativo.
+func RegistrarEstrategiaAtivo(tipo models.TipoAtivo, estrategia EstrategiaAtivo) {
+	estrategiasMu.Lock()
+	defer estrategiasMu.Unlock()
+	estrategias[tipo] = estrategia
+}
+
+func estrategiaDoAtivo(tipo models.TipoAtivo) (EstrategiaAtivo, error) {
+	estrategiasMu.RLock()
+	defer estrategiasMu.RUnlock()
+	estrategia, ok := estrategias[tipo]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTipoAtivoSemEstrategia, tipo)
+	}
+	return estrategia, nil
+}
+
+// tipoTransacaoAceito indica se o tipo de ativo aceita o tipo de transação.
+func tipoTransacaoAceito(tipoAtivo models.TipoAtivo, tipo models.TipoTransacao) bool {
+	estrategia, err := estrategiaDoAtivo(tipoAtivo)
+	if err != nil {
+		return false
+	}
+	_, ok := estrategia.Efeito(tipo, 0)
+	return ok
+}
+
+// estrategiaSaldo atende contas, poupança, carteira em dinheiro, investimentos e vales:
+// recebimentos aumentam o saldo, débitos o diminuem e o saldo não pode ficar negativo.
+type estrategiaSaldo struct{}
+
+func (estrategiaSaldo) Efeito(tipo models.TipoTransacao, valor float64) (models.EfeitoSaldo, bool) {
+	switch tipo {
+	case models.TransacaoRecebimento, models.TransacaoSaldoInicial:
+		return models.EfeitoSaldo{Saldo: valor}, true
+	case models.TransacaoDebito:
+		return models.EfeitoSaldo{Saldo: -valor}, true
+	}
+	return models.EfeitoSaldo{}, false
+}
+
+func (estrategiaSaldo) ValidarSaldo(atual, resultante models.AtivoFinanceiro) error {
+	if piorouAbaixoDeZero(atual.SaldoAtual, resultante.SaldoAtual) {
+		return ErrSaldoInsuficiente
+	}
+	return nil
+}
+
+func (estrategiaSaldo) ValidarAtivo(ativo models.AtivoFinanceiro) error {
+	return semChequeEspecial(ativo)
+}
+
+func (estrategiaSaldo) ValorSaldoInicial(ativo models.AtivoFinanceiro) float64 {
+	return ativo.SaldoAtual
+}
+
+// estrategiaContaCorrente funciona como estrategiaSaldo, mas permite que o saldo fique negativo
+// até o limite de cheque especial configurado na conta.
+type estrategiaContaCorrente struct{ estrategiaSaldo }
+
+func (estrategiaContaCorrente) ValidarSaldo(atual, resultante models.AtivoFinanceiro) error {
+	piso := -atual.LimiteChequeEspecial
+	if centavos(resultante.SaldoAtual) < centavos(piso) && resultante.SaldoAtual < atual.SaldoAtual {
+		if centavos(atual.LimiteChequeEspecial) > 0 {
+			return ErrLimiteChequeEspecial
+		}
+		return ErrSaldoInsuficiente
+	}
+	return nil
+}
+
+func (estrategiaContaCorrente) ValidarAtivo(ativo models.AtivoFinanceiro) error {
+	if ativo.LimiteChequeEspecial < 0 || ativo.TaxaJurosChequeEspecial < 0 {
+		return ErrChequeEspecialInvalido
+	}
+	return nil
+}
+
+// estrategiaCartaoCredito aceita apenas compras no crédito, que consomem o limite disponível.
+// O saldo inicial de um cartão é o limite já utilizado na data de abertura.
+type estrategiaCartaoCredito struct{}
+
+func (estrategiaCartaoCredito) Efeito(tipo models.TipoTransacao, valor float64) (models.EfeitoSaldo, bool) {
+	switch tipo {
+	case models.TransacaoCredito, models.TransacaoSaldoInicial:
+		return models.EfeitoSaldo{Limite: -valor}, true
+	}
+	return models.EfeitoSaldo{}, false
+}
+
+func (estrategiaCartaoCredito) ValidarSaldo(atual, resultante models.AtivoFinanceiro) error {
+	if piorouAbaixoDeZero(atual.LimiteDisponivel, resultante.LimiteDisponivel) {
+		return ErrSaldoInsuficiente
+	}
+	if centavos(resultante.LimiteDisponivel) > centavos(resultante.LimiteTotal) && resultante.LimiteDisponivel > atual.LimiteDisponivel {
+		return ErrLimiteExcedeTotal
+	}
+	return nil
+}
+
+func (estrategiaCartaoCredito) ValidarAtivo(ativo models.AtivoFinanceiro) error {
+	if ativo.LimiteTotal < 0 || ativo.LimiteDisponivel < 0 {
+		return ErrLimiteTotalInvalido
+	}
+	if centavos(ativo.LimiteDisponivel) > centavos(ativo.LimiteTotal) {
+		return ErrLimiteExcedeTotal
+	}
+	return semChequeEspecial(ativo)
+}
+
+func (estrategiaCartaoCredito) ValorSaldoInicial(ativo models.AtivoFinanceiro) float64 {
+	return ativo.LimiteTotal - ativo.LimiteDisponivel
+}
+
+// estrategiaEmprestimo representa uma dívida como saldo negativo: um crédito (novo valor tomado,
+// juros ou encargos) aumenta a dívida e um recebimento (pagamento de parcela) a reduz,
+// sem que o saldo possa ficar positivo.
+type estrategiaEmprestimo struct{}
+
+func (estrategiaEmprestimo) Efeito(tipo models.TipoTransacao, valor float64) (models.EfeitoSaldo, bool) {
+	switch tipo {
+	case models.TransacaoCredito:
+		return models.EfeitoSaldo{Saldo: -valor}, true
+	case models.TransacaoRecebimento, models.TransacaoSaldoInicial:
+		return models.EfeitoSaldo{Saldo: valor}, true
+	}
+	return models.EfeitoSaldo{}, false
+}
+
+func (estrategiaEmprestimo) ValidarSaldo(atual, resultante models.AtivoFinanceiro) error {
+	if centavos(resultante.SaldoAtual) > 0 && resultante.SaldoAtual > atual.SaldoAtual {
+		return ErrPagamentoExcedeDivida
+	}
+	return nil
+}
+
+func (estrategiaEmprestimo) ValidarAtivo(ativo models.AtivoFinanceiro) error {
+	if centavos(ativo.SaldoAtual) > 0 {
+		return ErrSaldoEmprestimoInvalido
+	}
+	return semChequeEspecial(ativo)
+}
+
+func (estrategiaEmprestimo) ValorSaldoInicial(ativo models.AtivoFinanceiro) float64 {
+	return ativo.SaldoAtual
+}
+
+// piorouAbaixoDeZero indica se um valor que deve ser não negativo ficou negativo com a operação.
+// Operações que melhoram um valor já negativo continuam permitidas.
+func piorouAbaixoDeZero(antes, depois float64) bool {
+	return centavos(depois) < 0 && depois < antes
+}
+
+// semChequeEspecial rejeita a configuração de cheque especial em tipos de ativo que não o suportam.
+func semChequeEspecial(ativo models.AtivoFinanceiro) error {
+	if ativo.LimiteChequeEspecial != 0 || ativo.TaxaJurosChequeEspecial != 0 {
+		return ErrChequeEspecialIndevido
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"io"
+	"math"
+
+	"controlador/backend/internal/exportacao"
+)
+
+// ExportarRelatoriosService gera os relatórios por categoria e mensal em CSV ou XLSX. Os
+// relatórios já chegam totalizados, com uma linha por grupo, e são pequenos o bastante para
+// serem calculados por inteiro antes da escrita.
+type ExportarRelatoriosService struct {
+	categoriasService *RelatorioCategoriasService
+	mensalService     *RelatorioMensalService
+}
+
+func NewExportarRelatoriosService(categoriasSvc *RelatorioCategoriasService, mensalSvc *RelatorioMensalService) *ExportarRelatoriosService {
+	return &ExportarRelatoriosService{categoriasService: categoriasSvc, mensalService: mensalSvc}
+}
+
+func (s *ExportarRelatoriosService) Categorias(ctx context.Context, periodo Periodo, moeda string, formato exportacao.Formato, localidade exportacao.Localidade, w io.Writer) error {
+	relatorio, err := s.categoriasService.Execute(ctx, periodo, moeda)
+	if err != nil {
+		return err
+	}
+	linhas := make([][]exportacao.Celula, len(relatorio))
+	for i, r := range relatorio {
+		linhas[i] = linhaRelatorioExportado(r.CategoriaNome, r.Moeda, r.Receitas, r.Despesas)
+	}
+	return escreverRelatorio(w, formato, localidade, "Categorias", "Categoria", linhas)
+}
+
+func (s *ExportarRelatoriosService) Mensal(ctx context.Context, periodo Periodo, moeda string, formato exportacao.Formato, localidade exportacao.Localidade, w io.Writer) error {
+	relatorio, err := s.mensalService.Execute(ctx, periodo, moeda)
+	if err != nil {
+		return err
+	}
+	linhas := make([][]exportacao.Celula, len(relatorio))
+	for i, r := range relatorio {
+		linhas[i] = linhaRelatorioExportado(r.Mes, r.Moeda, r.Receitas, r.Despesas)
+	}
+	return escreverRelatorio(w, formato, localidade, "Mensal", "Mês", linhas)
+}
+
+func linhaRelatorioExportado(grupo, moeda string, receitas, despesas float64) []exportacao.Celula {
+	return []exportacao.Celula{
+		exportacao.Texto(grupo), exportacao.Texto(moeda), exportacao.Numero(receitas), exportacao.Numero(despesas),
+		exportacao.Numero(math.Round((receitas-despesas)*100) / 100),
+	}
+}
+
+func escreverRelatorio(w io.Writer, formato exportacao.Formato, localidade exportacao.Localidade, aba, grupo string, linhas [][]exportacao.Celula) error {
+	planilha, err := exportacao.NovaPlanilha(w, formato, localidade, aba)
+	if err != nil {
+		return err
+	}
+	cabecalho := []exportacao.Celula{
+		exportacao.Texto(grupo), exportacao.Texto("Moeda"), exportacao.Texto("Receitas"), exportacao.Texto("Despesas"), exportacao.Texto("Resultado"),
+	}
+	if err := planilha.Linha(cabecalho...); err != nil {
+		return err
+	}
+	for _, l := range linhas {
+		if err := planilha.Linha(l...); err != nil {
+			return err
+		}
+	}
+	return planilha.Fechar()
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"controlador/backend/internal/exportacao"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ExportarTransacoesService struct {
+	repo repositories.TransacaoRepository
+}
+
+func NewExportarTransacoesService(repo repositories.TransacaoRepository) *ExportarTransacoesService {
+	return &ExportarTransacoesService{repo: repo}
+}
+
+// Execute escreve em 'w' as transações que atendem ao filtro, à medida que são lidas do banco.
+// Nada é escrito antes de a consulta responder, então um erro nessa etapa ainda pode ser
+// devolvido ao cliente normalmente.
+func (s *ExportarTransacoesService) Execute(ctx context.Context, filtro models.FiltroTransacoes, formato exportacao.Formato, localidade exportacao.Localidade, w io.Writer) error {
+	filtro.Tag = strings.ToLower(strings.TrimSpace(filtro.Tag))
+	if formato == exportacao.FormatoOFX {
+		return s.ofx(ctx, filtro, w)
+	}
+
+	var planilha exportacao.Planilha
+	abrir := func() error {
+		if planilha != nil {
+			return nil
+		}
+		var err error
+		if planilha, err = exportacao.NovaPlanilha(w, formato, localidade, "Transações"); err != nil {
+			return err
+		}
+		return planilha.Linha(
+			exportacao.Texto("Data"), exportacao.Texto("Descrição"), exportacao.Texto("Valor"), exportacao.Texto("Moeda"),
+			exportacao.Texto("Tipo"), exportacao.Texto("Status"), exportacao.Texto("Ativo"), exportacao.Texto("Categoria"),
+			exportacao.Texto("Tags"), exportacao.Texto("Notas"), exportacao.Texto("ID"))
+	}
+	err := s.repo.Percorrer(ctx, filtro, false, func(e models.TransacaoExportada) error {
+		if err := abrir(); err != nil {
+			return err
+		}
+		return planilha.Linha(
+			exportacao.Data(e.Data), exportacao.Texto(e.Descricao), exportacao.Numero(valorComSinal(e)), exportacao.Texto(e.Moeda),
+			exportacao.Texto(string(e.Tipo)), exportacao.Texto(string(e.Status)), exportacao.Texto(e.AtivoNome), exportacao.Texto(e.CategoriaNome),
+			exportacao.Texto(strings.Join(e.Tags, ", ")), exportacao.Texto(e.Notas), exportacao.Texto(e.ID))
+	})
+	if err != nil {
+		return err
+	}
+	if err := abrir(); err != nil {
+		return err
+	}
+	return planilha.Fechar()
+}
+
+// ofx gera um extrato por ativo com as transações efetivadas; as demais ainda não movimentaram
+// a conta e ficam de fora.
+func (s *ExportarTransacoesService) ofx(ctx context.Context, filtro models.FiltroTransacoes, w io.Writer) error {
+	escritor := exportacao.NovoEscritorOFX(w)
+	hoje := inicioDoDia(time.Now())
+	contaAtual := ""
+	err := s.repo.Percorrer(ctx, filtro, true, func(e models.TransacaoExportada) error {
+		if e.Status != models.StatusEfetivada {
+			return nil
+		}
+		if e.AtivoFinanceiroID != contaAtual {
+			contaAtual = e.AtivoFinanceiroID
+			conta := exportacao.ContaOFX{ID: e.AtivoFinanceiroID, Tipo: tipoContaOFX(e.AtivoTipo), Moeda: e.Moeda, Saldo: e.AtivoSaldo, Inicio: e.Data, Fim: hoje}
+			if filtro.Inicio != nil {
+				conta.Inicio = *filtro.Inicio
+			}
+			if filtro.Fim != nil {
+				conta.Fim = filtro.Fim.AddDate(0, 0, -1)
+			}
+			if err := escritor.Conta(conta); err != nil {
+				return err
+			}
+		}
+		return escritor.Lancamento(exportacao.LancamentoOFX{
+			ID:        e.ID,
+			Data:      e.Data,
+			Valor:     valorComSinal(e),
+			Descricao: e.Descricao,
+			Memo:      e.CategoriaNome,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return escritor.Fechar()
+}
+
+// valorComSinal expressa a transação como movimento do ativo: entradas positivas e saídas
+// negativas. Estornos têm o sinal oposto ao da transação original.
+func valorComSinal(e models.TransacaoExportada) float64 {
+	tipo := e.Tipo
+	sinal := 1.0
+	if tipo == models.TransacaoEstorno && e.TipoOriginal != nil {
+		tipo, sinal = *e.TipoOriginal, -1
+	}
+	if tipo == models.TransacaoDebito || tipo == models.TransacaoCredito {
+		sinal = -sinal
+	}
+	return sinal * e.Valor
+}
+
+func tipoContaOFX(tipo models.TipoAtivo) exportacao.TipoContaOFX {
+	switch tipo {
+	case models.AtivoPoupanca:
+		return exportacao.PoupancaOFX
+	case models.AtivoCartaoCredito, models.AtivoEmprestimo:
+		return exportacao.CreditoOFX
+	}
+	return exportacao.ContaCorrenteOFX
+}
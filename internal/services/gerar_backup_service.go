@@ -0,0 +1,72 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type GerarBackupService struct {
+	db   *pgxpool.Pool
+	repo repositories.BackupRepository
+}
+
+func NewGerarBackupService(db *pgxpool.Pool, repo repositories.BackupRepository) *GerarBackupService {
+	return &GerarBackupService{db: db, repo: repo}
+}
+
+// Execute escreve o backup em 'w' no formato de models.ArquivoBackup, tabela por tabela e linha
+// por linha. Todas as tabelas são lidas do mesmo instante do banco, então alterações feitas
+// durante a geração não deixam o arquivo inconsistente.
+func (s *GerarBackupService) Execute(ctx context.Context, w io.Writer) error {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	geradoEm, err := json.Marshal(time.Now())
+	if err != nil {
+		return err
+	}
+	saida := bufio.NewWriter(w)
+	fmt.Fprintf(saida, `{"formato":%q,"versao":%d,"gerado_em":%s,"dados":`, models.FormatoBackup, models.VersaoBackup, geradoEm)
+
+	// O checksum cobre exatamente os bytes do campo 'dados'.
+	hash := sha256.New()
+	dados := io.MultiWriter(saida, hash)
+	io.WriteString(dados, "{")
+	for i, t := range tabelasBackup {
+		if i > 0 {
+			io.WriteString(dados, ",")
+		}
+		fmt.Fprintf(dados, "%q:[", t.nome)
+		primeira := true
+		err := s.repo.Linhas(ctx, tx, t.nome, t.ordem, func(linha []byte) error {
+			if !primeira {
+				io.WriteString(dados, ",")
+			}
+			primeira = false
+			_, err := dados.Write(linha)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		io.WriteString(dados, "]")
+	}
+	io.WriteString(dados, "}")
+
+	fmt.Fprintf(saida, `,"checksum":"sha256:%x"}`, hash.Sum(nil))
+	return saida.Flush()
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type GetAtivoService struct {
+	repo repositories.AtivoRepository
+}
+
+func NewGetAtivoService(repo repositories.AtivoRepository) *GetAtivoService {
+	return &GetAtivoService{repo: repo}
+}
+
+func (s *GetAtivoService) Execute(ctx context.Context, id string) (*models.AtivoFinanceiro, error) {
+	ativo, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	return ativo, nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type GetFinanciamentoService struct {
+	repo repositories.FinanciamentoRepository
+}
+
+func NewGetFinanciamentoService(repo repositories.FinanciamentoRepository) *GetFinanciamentoService {
+	return &GetFinanciamentoService{repo: repo}
+}
+
+// Execute retorna o financiamento com o cronograma completo, parcelas pagas e pendentes.
+func (s *GetFinanciamentoService) Execute(ctx context.Context, id string) (*models.Financiamento, error) {
+	financiamento, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if financiamento == nil {
+		return nil, ErrFinanciamentoNaoEncontrado
+	}
+	if financiamento.Parcelas, err = s.repo.FindParcelas(ctx, id); err != nil {
+		return nil, err
+	}
+	return financiamento, nil
+}
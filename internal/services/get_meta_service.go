@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type GetMetaService struct {
+	repo          repositories.MetaRepository
+	ativoRepo     repositories.AtivoRepository
+	transacaoRepo repositories.TransacaoRepository
+	conversor     *ConversorMoedas
+}
+
+func NewGetMetaService(repo repositories.MetaRepository, aRepo repositories.AtivoRepository, tRepo repositories.TransacaoRepository, conversor *ConversorMoedas) *GetMetaService {
+	return &GetMetaService{repo: repo, ativoRepo: aRepo, transacaoRepo: tRepo, conversor: conversor}
+}
+
+func (s *GetMetaService) Execute(ctx context.Context, id string, mesesRitmo int) (*models.Meta, error) {
+	if mesesRitmo < 1 || mesesRitmo > mesesRitmoMaximo {
+		return nil, ErrMesesRitmoInvalido
+	}
+	meta, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, ErrMetaNaoEncontrada
+	}
+	meta.Progresso, err = progressoMeta(ctx, s.ativoRepo, s.transacaoRepo, s.conversor, meta, mesesRitmo, time.Now())
+	return meta, err
+}
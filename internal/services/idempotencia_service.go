@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrChaveIdempotenciaInvalida    = erros.Invalido("chave_idempotencia_invalida", "o cabeçalho Idempotency-Key deve ter entre 1 e 255 caracteres")
+	ErrChaveIdempotenciaReutilizada = erros.Conflito("chave_idempotencia_reutilizada", "a chave de idempotência já foi usada com outra requisição")
+	ErrChaveIdempotenciaEmUso       = erros.Conflito("chave_idempotencia_em_uso", "a requisição com esta chave de idempotência ainda está em processamento")
+)
+
+// tamanhoMaximoChaveIdempotencia acompanha o tamanho da coluna 'chaves_idempotencia.chave'.
+const tamanhoMaximoChaveIdempotencia = 255
+
+// IdempotenciaService garante que uma requisição repetida com a mesma Idempotency-Key seja
+// executada uma única vez, devolvendo nas novas tentativas a resposta da primeira execução.
+type IdempotenciaService struct {
+	repo repositories.IdempotenciaRepository
+	ttl  time.Duration
+}
+
+func NewIdempotenciaService(repo repositories.IdempotenciaRepository, ttl time.Duration) *IdempotenciaService {
+	return &IdempotenciaService{repo: repo, ttl: ttl}
+}
+
+// Fingerprint identifica o conteúdo da requisição: método, caminho (com a query) e corpo.
+func Fingerprint(metodo, caminho string, corpo []byte) string {
+	h := sha256.New()
+	h.Write([]byte(metodo + "\n" + caminho + "\n"))
+	h.Write(corpo)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Iniciar reserva a chave para a requisição. Retorna nil quando a requisição deve ser executada,
+// ou o registro concluído cuja resposta deve ser repetida.
+func (s *IdempotenciaService) Iniciar(ctx context.Context, chave, fingerprint string) (*models.ChaveIdempotencia, error) {
+	if chave == "" || len(chave) > tamanhoMaximoChaveIdempotencia {
+		return nil, ErrChaveIdempotenciaInvalida
+	}
+	existente, err := s.repo.Reservar(ctx, chave, fingerprint, time.Now().Add(s.ttl))
+	if err != nil || existente == nil {
+		return nil, err
+	}
+	if existente.Fingerprint != fingerprint {
+		return nil, ErrChaveIdempotenciaReutilizada
+	}
+	if existente.StatusCode == nil {
+		return nil, ErrChaveIdempotenciaEmUso
+	}
+	return existente, nil
+}
+
+// Concluir guarda a resposta da requisição. Respostas de erro interno não são guardadas: a chave
+// é liberada para que o cliente possa tentar de novo, já que nada foi efetivado.
+func (s *IdempotenciaService) Concluir(ctx context.Context, chave string, statusCode int, contentType string, corpo []byte) error {
+	if statusCode >= 500 {
+		return s.repo.Liberar(ctx, chave)
+	}
+	return s.repo.Concluir(ctx, chave, statusCode, contentType, corpo)
+}
+
+// Liberar descarta a reserva de uma requisição que não chegou a produzir resposta.
+func (s *IdempotenciaService) Liberar(ctx context.Context, chave string) error {
+	return s.repo.Liberar(ctx, chave)
+}
+
+// LimparExpiradas remove as chaves cujo prazo de validade já passou.
+func (s *IdempotenciaService) LimparExpiradas(ctx context.Context) (int64, error) {
+	return s.repo.RemoverExpiradas(ctx)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrArquivoCotacoesInvalido = erros.RegraNegocio("arquivo_cotacoes_invalido", "arquivo de cotações inválido")
+
+type ImportarCotacoesService struct {
+	db   *pgxpool.Pool
+	repo repositories.InvestimentoRepository
+}
+
+func NewImportarCotacoesService(db *pgxpool.Pool, repo repositories.InvestimentoRepository) *ImportarCotacoesService {
+	return &ImportarCotacoesService{db: db, repo: repo}
+}
+
+// Execute importa um CSV com as colunas codigo, data (AAAA-MM-DD) e preco; uma linha de
+// cabeçalho é opcional. O arquivo é importado por inteiro ou, havendo qualquer linha inválida
+// ou título desconhecido, não é importado. Retorna a quantidade de cotações gravadas.
+func (s *ImportarCotacoesService) Execute(ctx context.Context, arquivo io.Reader) (int, error) {
+	titulos, err := s.repo.FindTitulos(ctx)
+	if err != nil {
+		return 0, err
+	}
+	porCodigo := make(map[string]string, len(titulos))
+	for _, t := range titulos {
+		porCodigo[t.Codigo] = t.ID
+	}
+
+	// 1. Ler e validar todas as linhas antes de gravar
+	var cotacoes []models.CotacaoTitulo
+	leitor := csv.NewReader(arquivo)
+	leitor.FieldsPerRecord = 3
+	leitor.TrimLeadingSpace = true
+	for linha := 1; ; linha++ {
+		registro, err := leitor.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrArquivoCotacoesInvalido, err)
+		}
+		data, err := time.Parse("2006-01-02", registro[1])
+		if err != nil {
+			if linha == 1 {
+				continue // cabeçalho
+			}
+			return 0, fmt.Errorf("%w: linha %d: data inválida %q", ErrArquivoCotacoesInvalido, linha, registro[1])
+		}
+		tituloID, ok := porCodigo[strings.ToUpper(strings.TrimSpace(registro[0]))]
+		if !ok {
+			return 0, fmt.Errorf("%w: linha %d: título %q não cadastrado", ErrArquivoCotacoesInvalido, linha, registro[0])
+		}
+		preco, err := strconv.ParseFloat(registro[2], 64)
+		if err != nil || preco <= 0 {
+			return 0, fmt.Errorf("%w: linha %d: preço inválido %q", ErrArquivoCotacoesInvalido, linha, registro[2])
+		}
+		cotacoes = append(cotacoes, models.CotacaoTitulo{TituloID: tituloID, Data: data, Preco: preco, Fonte: "ARQUIVO"})
+	}
+
+	// 2. Gravar tudo em uma única transação de banco
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	for i := range cotacoes {
+		if err := s.repo.SaveCotacao(ctx, tx, &cotacoes[i]); err != nil {
+			return 0, err
+		}
+	}
+	return len(cotacoes), tx.Commit(ctx)
+}
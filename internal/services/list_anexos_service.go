@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListAnexosService struct {
+	repo repositories.AnexoRepository
+}
+
+func NewListAnexosService(repo repositories.AnexoRepository) *ListAnexosService {
+	return &ListAnexosService{repo: repo}
+}
+
+func (s *ListAnexosService) Execute(ctx context.Context, transacaoID string) ([]models.Anexo, error) {
+	return s.repo.FindAllByTransacaoID(ctx, transacaoID)
+}
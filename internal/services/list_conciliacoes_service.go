@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListConciliacoesService struct {
+	repo repositories.ConciliacaoRepository
+}
+
+func NewListConciliacoesService(repo repositories.ConciliacaoRepository) *ListConciliacoesService {
+	return &ListConciliacoesService{repo: repo}
+}
+
+func (s *ListConciliacoesService) Execute(ctx context.Context, ativoID string) ([]models.Conciliacao, error) {
+	return s.repo.FindAllByAtivoID(ctx, ativoID)
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+)
+
+type ListDuplicatasService struct {
+	detector *DetectorDuplicatas
+}
+
+func NewListDuplicatasService(detector *DetectorDuplicatas) *ListDuplicatasService {
+	return &ListDuplicatasService{detector: detector}
+}
+
+// Execute lista os pares de transações suspeitos de duplicidade no período.
+func (s *ListDuplicatasService) Execute(ctx context.Context, periodo Periodo) ([]models.ParDuplicata, error) {
+	return s.detector.Pares(ctx, periodo)
+}
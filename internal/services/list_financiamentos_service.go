@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListFinanciamentosService struct {
+	repo repositories.FinanciamentoRepository
+}
+
+func NewListFinanciamentosService(repo repositories.FinanciamentoRepository) *ListFinanciamentosService {
+	return &ListFinanciamentosService{repo: repo}
+}
+
+func (s *ListFinanciamentosService) Execute(ctx context.Context) ([]models.Financiamento, error) {
+	return s.repo.FindAll(ctx)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListMetasService struct {
+	repo          repositories.MetaRepository
+	ativoRepo     repositories.AtivoRepository
+	transacaoRepo repositories.TransacaoRepository
+	conversor     *ConversorMoedas
+}
+
+func NewListMetasService(repo repositories.MetaRepository, aRepo repositories.AtivoRepository, tRepo repositories.TransacaoRepository, conversor *ConversorMoedas) *ListMetasService {
+	return &ListMetasService{repo: repo, ativoRepo: aRepo, transacaoRepo: tRepo, conversor: conversor}
+}
+
+// Execute lista as metas com o progresso de hoje, medindo o ritmo nos últimos 'mesesRitmo' meses.
+func (s *ListMetasService) Execute(ctx context.Context, mesesRitmo int) ([]models.Meta, error) {
+	if mesesRitmo < 1 || mesesRitmo > mesesRitmoMaximo {
+		return nil, ErrMesesRitmoInvalido
+	}
+	metas, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hoje := time.Now()
+	for i := range metas {
+		if metas[i].Progresso, err = progressoMeta(ctx, s.ativoRepo, s.transacaoRepo, s.conversor, &metas[i], mesesRitmo, hoje); err != nil {
+			return nil, err
+		}
+	}
+	return metas, nil
+}
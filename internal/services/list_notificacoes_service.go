@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListNotificacoesService struct {
+	repo repositories.NotificacaoRepository
+}
+
+func NewListNotificacoesService(repo repositories.NotificacaoRepository) *ListNotificacoesService {
+	return &ListNotificacoesService{repo: repo}
+}
+
+// Execute lista a caixa de entrada, opcionalmente só com as notificações ainda não lidas.
+func (s *ListNotificacoesService) Execute(ctx context.Context, apenasNaoLidas bool) ([]models.Notificacao, error) {
+	notificacoes, err := s.repo.FindCaixaEntrada(ctx, apenasNaoLidas)
+	if err != nil {
+		return nil, err
+	}
+	if notificacoes == nil {
+		notificacoes = []models.Notificacao{}
+	}
+	return notificacoes, nil
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListOperacoesInvestimentoService struct {
+	repo repositories.InvestimentoRepository
+}
+
+func NewListOperacoesInvestimentoService(repo repositories.InvestimentoRepository) *ListOperacoesInvestimentoService {
+	return &ListOperacoesInvestimentoService{repo: repo}
+}
+
+// Execute lista as operações em ordem cronológica, opcionalmente de um único título.
+func (s *ListOperacoesInvestimentoService) Execute(ctx context.Context, tituloID string) ([]models.OperacaoInvestimento, error) {
+	return s.repo.FindOperacoes(ctx, tituloID)
+}
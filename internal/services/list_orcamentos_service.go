@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListOrcamentosService struct {
+	repo repositories.OrcamentoRepository
+}
+
+func NewListOrcamentosService(repo repositories.OrcamentoRepository) *ListOrcamentosService {
+	return &ListOrcamentosService{repo: repo}
+}
+
+func (s *ListOrcamentosService) Execute(ctx context.Context) ([]models.Orcamento, error) {
+	return s.repo.FindAll(ctx)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListPreferenciasNotificacaoService struct {
+	repo repositories.NotificacaoRepository
+}
+
+func NewListPreferenciasNotificacaoService(repo repositories.NotificacaoRepository) *ListPreferenciasNotificacaoService {
+	return &ListPreferenciasNotificacaoService{repo: repo}
+}
+
+// Execute retorna a preferência de cada tipo de evento, com os valores padrão para os que
+// ainda não foram configurados.
+func (s *ListPreferenciasNotificacaoService) Execute(ctx context.Context) ([]models.PreferenciaNotificacao, error) {
+	gravadas, err := s.repo.FindPreferencias(ctx)
+	if err != nil {
+		return nil, err
+	}
+	porEvento := make(map[models.TipoEventoNotificacao]models.PreferenciaNotificacao, len(gravadas))
+	for _, p := range gravadas {
+		porEvento[p.Evento] = p
+	}
+
+	preferencias := make([]models.PreferenciaNotificacao, 0, len(models.EventosNotificacao))
+	for _, evento := range models.EventosNotificacao {
+		p, ok := porEvento[evento]
+		if !ok {
+			p = preferenciaPadrao(evento)
+		}
+		preferencias = append(preferencias, p)
+	}
+	return preferencias, nil
+}
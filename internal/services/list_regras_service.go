@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListRegrasService struct {
+	repo repositories.RegraRepository
+}
+
+func NewListRegrasService(repo repositories.RegraRepository) *ListRegrasService {
+	return &ListRegrasService{repo: repo}
+}
+
+// Execute lista as regras na ordem em que são avaliadas.
+func (s *ListRegrasService) Execute(ctx context.Context) ([]models.Regra, error) {
+	return s.repo.FindAll(ctx)
+}
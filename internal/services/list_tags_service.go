@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// limiteSugestoesTags limita quantas tags o autocompletar devolve.
+const limiteSugestoesTags = 10
+
+type ListTagsService struct {
+	repo repositories.TagRepository
+}
+
+func NewListTagsService(repo repositories.TagRepository) *ListTagsService {
+	return &ListTagsService{repo: repo}
+}
+
+// Execute lista todas as tags ou, com um termo informado, sugere as que começam com ele.
+func (s *ListTagsService) Execute(ctx context.Context, termo string) ([]models.Tag, error) {
+	termo = strings.ToLower(strings.TrimSpace(termo))
+	if termo == "" {
+		return s.repo.FindAll(ctx)
+	}
+	return s.repo.Search(ctx, termo, limiteSugestoesTags)
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListTaxasCambioService struct {
+	repo repositories.TaxaCambioRepository
+}
+
+func NewListTaxasCambioService(repo repositories.TaxaCambioRepository) *ListTaxasCambioService {
+	return &ListTaxasCambioService{repo: repo}
+}
+
+// Execute lista as taxas cadastradas, opcionalmente filtradas pelas moedas de origem e destino.
+func (s *ListTaxasCambioService) Execute(ctx context.Context, moedaOrigem, moedaDestino string) ([]models.TaxaCambio, error) {
+	var err error
+	if moedaOrigem != "" {
+		if moedaOrigem, err = models.ParseMoeda(moedaOrigem); err != nil {
+			return nil, ErrMoedaInvalida
+		}
+	}
+	if moedaDestino != "" {
+		if moedaDestino, err = models.ParseMoeda(moedaDestino); err != nil {
+			return nil, ErrMoedaInvalida
+		}
+	}
+	return s.repo.FindAll(ctx, moedaOrigem, moedaDestino)
+}
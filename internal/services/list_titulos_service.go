@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListTitulosService struct {
+	repo repositories.InvestimentoRepository
+}
+
+func NewListTitulosService(repo repositories.InvestimentoRepository) *ListTitulosService {
+	return &ListTitulosService{repo: repo}
+}
+
+func (s *ListTitulosService) Execute(ctx context.Context) ([]models.Titulo, error) {
+	return s.repo.FindTitulos(ctx)
+}
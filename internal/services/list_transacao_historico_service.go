@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListTransacaoHistoricoService struct {
+	repo repositories.TransacaoHistoricoRepository
+}
+
+func NewListTransacaoHistoricoService(repo repositories.TransacaoHistoricoRepository) *ListTransacaoHistoricoService {
+	return &ListTransacaoHistoricoService{repo: repo}
+}
+
+// Execute retorna as versões anteriores da transação, da mais antiga para a mais recente.
+func (s *ListTransacaoHistoricoService) Execute(ctx context.Context, transacaoID string) ([]models.TransacaoHistorico, error) {
+	return s.repo.FindAllByTransacaoID(ctx, transacaoID)
+}
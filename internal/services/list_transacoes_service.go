@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/repositories"
@@ -16,6 +17,8 @@ func NewListTransacoesService(repo repositories.TransacaoRepository) *ListTransa
 	return &ListTransacoesService{repo: repo}
 }
 
-func (s *ListTransacoesService) Execute(ctx context.Context) ([]models.Transacao, error) {
-	return s.repo.FindAll(ctx)
+// Execute lista as transações que atendem ao filtro, das mais recentes para as mais antigas.
+func (s *ListTransacoesService) Execute(ctx context.Context, filtro models.FiltroTransacoes) ([]models.Transacao, error) {
+	filtro.Tag = strings.ToLower(strings.TrimSpace(filtro.Tag))
+	return s.repo.FindAll(ctx, filtro)
 }
\ No newline at end of file
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ListWebhooksService struct {
+	repo repositories.WebhookRepository
+}
+
+func NewListWebhooksService(repo repositories.WebhookRepository) *ListWebhooksService {
+	return &ListWebhooksService{repo: repo}
+}
+
+// Execute lista as assinaturas sem os segredos.
+func (s *ListWebhooksService) Execute(ctx context.Context) ([]models.WebhookAssinatura, error) {
+	assinaturas, err := s.repo.FindAssinaturas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range assinaturas {
+		assinaturas[i].Segredo = ""
+	}
+	return assinaturas, nil
+}
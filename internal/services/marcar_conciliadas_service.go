@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// MarcarConciliadasInput lista as transações conferidas (ou desmarcadas, com 'Conciliada' falso).
+type MarcarConciliadasInput struct {
+	TransacaoIDs []string `json:"transacao_ids" binding:"required,min=1"`
+	Conciliada   *bool    `json:"conciliada"`
+}
+
+type MarcarConciliadasService struct {
+	db              *pgxpool.Pool
+	conciliacaoRepo repositories.ConciliacaoRepository
+	transacaoRepo   repositories.TransacaoRepository
+	ativoRepo       repositories.AtivoRepository
+}
+
+func NewMarcarConciliadasService(db *pgxpool.Pool, cRepo repositories.ConciliacaoRepository, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository) *MarcarConciliadasService {
+	return &MarcarConciliadasService{db: db, conciliacaoRepo: cRepo, transacaoRepo: tRepo, ativoRepo: aRepo}
+}
+
+// Execute marca as transações como conferidas com o extrato (ou desfaz a marcação) e devolve o
+// resumo atualizado da conciliação. Sem 'Conciliada', as transações são marcadas.
+func (s *MarcarConciliadasService) Execute(ctx context.Context, conciliacaoID string, input MarcarConciliadasInput) (*models.ResumoConciliacao, error) {
+	conciliada := input.Conciliada == nil || *input.Conciliada
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	conciliacao, err := s.conciliacaoRepo.FindByIDForUpdate(ctx, tx, conciliacaoID)
+	if err != nil {
+		return nil, err
+	}
+	if conciliacao == nil {
+		return nil, ErrConciliacaoNaoEncontrada
+	}
+	if conciliacao.Status != models.ConciliacaoAberta {
+		return nil, ErrConciliacaoConcluida
+	}
+
+	limite := limiteConciliacao(*conciliacao)
+	for _, id := range input.TransacaoIDs {
+		t, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			return nil, ErrTransacaoNaoEncontrada
+		}
+		if t.Bloqueada {
+			return nil, ErrTransacaoBloqueada
+		}
+		if t.AtivoFinanceiroID != conciliacao.AtivoFinanceiroID || t.Status != models.StatusEfetivada || !t.Data.Before(limite) {
+			return nil, ErrTransacaoNaoConciliavel
+		}
+	}
+	if err := s.transacaoRepo.MarcarConciliadas(ctx, tx, input.TransacaoIDs, conciliada); err != nil {
+		return nil, err
+	}
+
+	// O resumo devolvido é lido na mesma transação de banco, já com as marcações gravadas.
+	ativo, err := s.ativoRepo.FindByIDForUpdate(ctx, tx, conciliacao.AtivoFinanceiroID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	resumo, err := resumirConciliacao(ctx, transacoesNaTx{repo: s.transacaoRepo, tx: tx}, *ativo, *conciliacao)
+	if err != nil {
+		return nil, err
+	}
+	return resumo, tx.Commit(ctx)
+}
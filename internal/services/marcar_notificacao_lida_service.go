@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrNotificacaoNaoEncontrada = erros.NaoEncontrado("notificacao_nao_encontrada", "notificação não encontrada na caixa de entrada")
+
+type MarcarNotificacaoLidaService struct {
+	repo repositories.NotificacaoRepository
+}
+
+func NewMarcarNotificacaoLidaService(repo repositories.NotificacaoRepository) *MarcarNotificacaoLidaService {
+	return &MarcarNotificacaoLidaService{repo: repo}
+}
+
+func (s *MarcarNotificacaoLidaService) Execute(ctx context.Context, id string) error {
+	encontrada, err := s.repo.MarcarLida(ctx, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !encontrada {
+		return ErrNotificacaoNaoEncontrada
+	}
+	return nil
+}
+
+// Todas marca como lidas todas as notificações pendentes e retorna quantas foram marcadas.
+func (s *MarcarNotificacaoLidaService) Todas(ctx context.Context) (int64, error) {
+	return s.repo.MarcarTodasLidas(ctx, time.Now())
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrMesclagemInvalida = erros.RegraNegocio("mesclagem_invalida", "só é possível mesclar transações distintas, não canceladas, do mesmo ativo")
+
+// MesclarTransacoesInput identifica a transação duplicada a ser absorvida.
+type MesclarTransacoesInput struct {
+	DuplicataID string `json:"duplicata_id" binding:"required"`
+}
+
+type MesclarTransacoesService struct {
+	db             *pgxpool.Pool
+	transacaoRepo  repositories.TransacaoRepository
+	historicoRepo  repositories.TransacaoHistoricoRepository
+	reverseService *ReverseTransacaoService
+	cancelService  *CancelarTransacaoService
+}
+
+func NewMesclarTransacoesService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, hRepo repositories.TransacaoHistoricoRepository, reverseSvc *ReverseTransacaoService, cancelSvc *CancelarTransacaoService) *MesclarTransacoesService {
+	return &MesclarTransacoesService{
+		db:             db,
+		transacaoRepo:  tRepo,
+		historicoRepo:  hRepo,
+		reverseService: reverseSvc,
+		cancelService:  cancelSvc,
+	}
+}
+
+// Execute mantém a transação 'mantidaID' e absorve a duplicata: tags e anexos da duplicata passam
+// para a mantida, e a duplicata é estornada, desfazendo seu efeito no saldo. Uma duplicata ainda
+// agendada ou pendente não afetou o saldo e é apenas cancelada. Tudo ocorre em uma única transação
+// de banco. Retorna a transação mantida e o estorno gerado, se houver.
+func (s *MesclarTransacoesService) Execute(ctx context.Context, mantidaID string, input MesclarTransacoesInput) (*models.Transacao, *models.Transacao, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Carregar e bloquear as duas transações
+	mantida, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, mantidaID)
+	if err != nil {
+		return nil, nil, err
+	}
+	duplicata, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, input.DuplicataID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mantida == nil || duplicata == nil {
+		return nil, nil, ErrTransacaoNaoEncontrada
+	}
+	if mantida.ID == duplicata.ID || mantida.AtivoFinanceiroID != duplicata.AtivoFinanceiroID ||
+		mantida.Status == models.StatusCancelada || duplicata.Status == models.StatusCancelada {
+		return nil, nil, ErrMesclagemInvalida
+	}
+	if mantida.ReversalOf != nil || duplicata.ReversalOf != nil {
+		return nil, nil, ErrEstornoDeEstorno
+	}
+	if mantida.Bloqueada || duplicata.Bloqueada {
+		return nil, nil, ErrTransacaoBloqueada
+	}
+	if mantida.Tipo == models.TransacaoSaldoInicial || duplicata.Tipo == models.TransacaoSaldoInicial {
+		return nil, nil, ErrTransacaoSaldoInicial
+	}
+	if mantida.Origem != "" || duplicata.Origem != "" {
+		return nil, nil, ErrTransacaoGerada
+	}
+
+	// 2. Incorporar tags e anexos da duplicata na transação mantida
+	tagsAntes := len(mantida.Tags)
+	atualizada := *mantida
+	for _, tag := range duplicata.Tags {
+		if !contemTag(atualizada.Tags, tag) {
+			atualizada.Tags = append(atualizada.Tags, tag)
+		}
+	}
+	if len(atualizada.Tags) != tagsAntes {
+		if err := s.historicoRepo.Create(ctx, tx, &models.TransacaoHistorico{
+			ID:          uuid.New().String(),
+			TransacaoID: mantida.ID,
+			Operacao:    models.HistoricoAtualizacao,
+			Dados:       *mantida,
+			CreatedAt:   time.Now(),
+		}); err != nil {
+			return nil, nil, err
+		}
+		if err := s.transacaoRepo.Update(ctx, tx, &atualizada); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := s.transacaoRepo.TransferirAnexos(ctx, tx, duplicata.ID, mantida.ID); err != nil {
+		return nil, nil, err
+	}
+
+	// 3. Cancelar a duplicata não efetivada ou estornar o que resta dela
+	if duplicata.Status != models.StatusEfetivada {
+		if _, err := s.cancelService.ExecuteTx(ctx, tx, duplicata.ID); err != nil {
+			return nil, nil, err
+		}
+		return &atualizada, nil, tx.Commit(ctx)
+	}
+	estorno, err := s.reverseService.ExecuteTx(ctx, tx, duplicata.ID, ReverseTransacaoInput{
+		Motivo: fmt.Sprintf("Duplicata mesclada na transação %s", mantida.ID),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &atualizada, estorno, tx.Commit(ctx)
+}
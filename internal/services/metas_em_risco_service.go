@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+)
+
+type MetasEmRiscoService struct {
+	listSvc *ListMetasService
+}
+
+func NewMetasEmRiscoService(listSvc *ListMetasService) *MetasEmRiscoService {
+	return &MetasEmRiscoService{listSvc: listSvc}
+}
+
+// Execute retorna as metas não atingidas cujo ritmo de aportes dos últimos 'mesesRitmo' meses
+// não basta para chegar ao valor alvo na data, incluindo as que já passaram da data.
+func (s *MetasEmRiscoService) Execute(ctx context.Context, mesesRitmo int) ([]models.Meta, error) {
+	metas, err := s.listSvc.Execute(ctx, mesesRitmo)
+	if err != nil {
+		return nil, err
+	}
+	emRisco := []models.Meta{}
+	for _, m := range metas {
+		if m.Progresso.EmRisco {
+			emRisco = append(emRisco, m)
+		}
+	}
+	return emRisco, nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// MotorRegras avalia as regras de categorização ativas sobre uma transação.
+type MotorRegras struct {
+	repo repositories.RegraRepository
+}
+
+func NewMotorRegras(repo repositories.RegraRepository) *MotorRegras {
+	return &MotorRegras{repo: repo}
+}
+
+// Aplicar avalia as regras ativas, em ordem de prioridade, e aplica as ações das que combinam.
+// Categoria e descrição são definidas pela primeira regra que as altera; as tags se acumulam.
+// Com 'preservarCategoria', uma categoria já informada na transação não é substituída.
+// Retorna os IDs das regras aplicadas.
+func (m *MotorRegras) Aplicar(ctx context.Context, t *models.Transacao, preservarCategoria bool) ([]string, error) {
+	regras, err := m.repo.FindAtivas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return aplicarRegras(regras, t, preservarCategoria), nil
+}
+
+func aplicarRegras(regras []models.Regra, t *models.Transacao, preservarCategoria bool) []string {
+	var aplicadas []string
+	categoriaDefinida := preservarCategoria && t.CategoriaID != ""
+	descricaoDefinida := false
+	descricaoOriginal := t.Descricao
+
+	for _, regra := range regras {
+		// As condições são sempre avaliadas sobre a descrição original, para que uma
+		// renomeação não altere quais regras de menor prioridade combinam.
+		avaliada := *t
+		avaliada.Descricao = descricaoOriginal
+		if !regraCombina(regra, avaliada) {
+			continue
+		}
+		aplicadas = append(aplicadas, regra.ID)
+		for _, acao := range regra.Acoes {
+			switch acao.Tipo {
+			case models.AcaoDefinirCategoria:
+				if !categoriaDefinida {
+					t.CategoriaID = acao.Valor
+					categoriaDefinida = true
+				}
+			case models.AcaoRenomearDescricao:
+				if !descricaoDefinida {
+					t.Descricao = acao.Valor
+					descricaoDefinida = true
+				}
+			case models.AcaoAdicionarTag:
+				if !contemTag(t.Tags, acao.Valor) {
+					t.Tags = append(t.Tags, acao.Valor)
+				}
+			}
+		}
+	}
+	return aplicadas
+}
+
+func regraCombina(regra models.Regra, t models.Transacao) bool {
+	for _, c := range regra.Condicoes {
+		if !condicaoCombina(c, t) {
+			return false
+		}
+	}
+	return len(regra.Condicoes) > 0
+}
+
+func condicaoCombina(c models.CondicaoRegra, t models.Transacao) bool {
+	switch c.Campo {
+	case models.CondicaoDescricao:
+		switch c.Operador {
+		case models.OperadorContem:
+			return strings.Contains(strings.ToLower(t.Descricao), strings.ToLower(c.Valor))
+		case models.OperadorRegex:
+			re, err := regexp.Compile(c.Valor)
+			return err == nil && re.MatchString(t.Descricao)
+		case models.OperadorIgual:
+			return strings.EqualFold(t.Descricao, c.Valor)
+		}
+	case models.CondicaoAtivo:
+		return c.Operador == models.OperadorIgual && t.AtivoFinanceiroID == c.Valor
+	case models.CondicaoTipo:
+		return c.Operador == models.OperadorIgual && string(t.Tipo) == c.Valor
+	case models.CondicaoValor:
+		if c.Operador != models.OperadorEntre {
+			return false
+		}
+		return (c.Minimo == nil || t.Valor >= *c.Minimo) && (c.Maximo == nil || t.Valor <= *c.Maximo)
+	}
+	return false
+}
+
+func contemTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/notificacao"
+	"controlador/backend/internal/repositories"
+)
+
+const (
+	antecedenciaPadraoDias = 3
+	formatoDataNotificacao = "02/01/2006"
+)
+
+// NotificadorService registra notificações e as entrega pelos canais escolhidos na preferência
+// do evento. Os canais externos disponíveis são os configurados no ambiente.
+type NotificadorService struct {
+	repo   repositories.NotificacaoRepository
+	canais map[models.CanalNotificacao]notificacao.Canal
+}
+
+func NewNotificadorService(repo repositories.NotificacaoRepository, canais map[models.CanalNotificacao]notificacao.Canal) *NotificadorService {
+	return &NotificadorService{repo: repo, canais: canais}
+}
+
+// preferenciaPadrao é usada enquanto o evento não tem preferência gravada.
+func preferenciaPadrao(evento models.TipoEventoNotificacao) models.PreferenciaNotificacao {
+	return models.PreferenciaNotificacao{
+		Evento:           evento,
+		Ativo:            true,
+		Canais:           []models.CanalNotificacao{models.CanalInApp},
+		AntecedenciaDias: antecedenciaPadraoDias,
+	}
+}
+
+// Preferencia retorna a preferência gravada do evento ou, na falta dela, a padrão.
+func (s *NotificadorService) Preferencia(ctx context.Context, evento models.TipoEventoNotificacao) (models.PreferenciaNotificacao, error) {
+	p, err := s.repo.FindPreferencia(ctx, evento)
+	if err != nil || p == nil {
+		return preferenciaPadrao(evento), err
+	}
+	return *p, nil
+}
+
+// CanalDisponivel informa se o canal pode ser usado: a caixa de entrada sempre, os demais só se configurados.
+func (s *NotificadorService) CanalDisponivel(canal models.CanalNotificacao) bool {
+	_, ok := s.canais[canal]
+	return canal == models.CanalInApp || ok
+}
+
+// Notificar registra e entrega uma ocorrência do evento. 'chave' identifica a ocorrência: uma
+// chave já notificada é ignorada, o que permite aos workers reavaliar as mesmas condições sem
+// repetir avisos. Falhas de entrega em canais externos são registradas no log e não interrompem
+// os demais canais.
+func (s *NotificadorService) Notificar(ctx context.Context, evento models.TipoEventoNotificacao, chave, titulo, mensagem string) error {
+	preferencia, err := s.Preferencia(ctx, evento)
+	if err != nil {
+		return err
+	}
+	if !preferencia.Ativo || len(preferencia.Canais) == 0 {
+		return nil
+	}
+
+	n := models.Notificacao{
+		ID:        uuid.New().String(),
+		Evento:    evento,
+		Chave:     chave,
+		Titulo:    titulo,
+		Mensagem:  mensagem,
+		Canais:    preferencia.Canais,
+		CreatedAt: time.Now(),
+	}
+	nova, err := s.repo.Create(ctx, &n)
+	if err != nil || !nova {
+		return err
+	}
+
+	for _, nome := range n.Canais {
+		if nome == models.CanalInApp {
+			continue
+		}
+		canal, ok := s.canais[nome]
+		if !ok {
+			log.Warn().Str("canal", string(nome)).Str("evento", string(evento)).Msg("Canal de notificação não configurado; entrega ignorada.")
+			continue
+		}
+		if err := canal.Enviar(ctx, n); err != nil {
+			log.Error().Err(err).Str("canal", string(nome)).Str("notificacao_id", n.ID).Msg("Falha ao entregar notificação.")
+		}
+	}
+	return nil
+}
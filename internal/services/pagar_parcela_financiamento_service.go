@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+const (
+	categoriaJurosFinanciamento       = "Juros de financiamento"
+	categoriaAmortizacaoFinanciamento = "Amortização de financiamento"
+)
+
+var ErrFinanciamentoQuitado = erros.Conflito("financiamento_quitado", "o financiamento já está quitado")
+
+type PagarParcelaFinanciamentoService struct {
+	db                *pgxpool.Pool
+	financiamentoRepo repositories.FinanciamentoRepository
+	transacaoRepo     repositories.TransacaoRepository
+	ativoRepo         repositories.AtivoRepository
+	categoriaRepo     repositories.CategoriaRepository
+	eventos           *PublicadorEventos
+}
+
+func NewPagarParcelaFinanciamentoService(db *pgxpool.Pool, fRepo repositories.FinanciamentoRepository, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, cRepo repositories.CategoriaRepository, eventos *PublicadorEventos) *PagarParcelaFinanciamentoService {
+	return &PagarParcelaFinanciamentoService{
+		db:                db,
+		financiamentoRepo: fRepo,
+		transacaoRepo:     tRepo,
+		ativoRepo:         aRepo,
+		categoriaRepo:     cRepo,
+		eventos:           eventos,
+	}
+}
+
+// Execute paga a próxima parcela pendente, vencida ou não, na data informada (hoje, se ausente).
+// As parcelas são sempre pagas em ordem.
+func (s *PagarParcelaFinanciamentoService) Execute(ctx context.Context, financiamentoID string, data *time.Time) (*models.ParcelaFinanciamento, error) {
+	hoje := time.Now()
+	if data == nil {
+		data = &hoje
+	}
+	return s.pagar(ctx, financiamentoID, nil, *data)
+}
+
+// PagarVencida paga a próxima parcela pendente somente se ela vencer até 'ate', lançando o
+// pagamento na data de vencimento. Retorna nil se não houver parcela vencida.
+func (s *PagarParcelaFinanciamentoService) PagarVencida(ctx context.Context, financiamentoID string, ate time.Time) (*models.ParcelaFinanciamento, error) {
+	return s.pagar(ctx, financiamentoID, &ate, time.Time{})
+}
+
+func (s *PagarParcelaFinanciamentoService) pagar(ctx context.Context, financiamentoID string, vencidaAte *time.Time, data time.Time) (*models.ParcelaFinanciamento, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Carregar o financiamento e a próxima parcela pendente
+	financiamento, err := s.financiamentoRepo.FindByIDForUpdate(ctx, tx, financiamentoID)
+	if err != nil {
+		return nil, err
+	}
+	if financiamento == nil {
+		return nil, ErrFinanciamentoNaoEncontrado
+	}
+	if financiamento.Status == models.FinanciamentoQuitado {
+		return nil, ErrFinanciamentoQuitado
+	}
+	parcelas, err := s.financiamentoRepo.FindParcelasTx(ctx, tx, financiamento.ID)
+	if err != nil {
+		return nil, err
+	}
+	parcela := proximaParcelaPendente(parcelas)
+	if parcela == nil {
+		return nil, ErrFinanciamentoQuitado
+	}
+	if vencidaAte != nil {
+		if parcela.DataVencimento.After(*vencidaAte) {
+			return nil, nil
+		}
+		data = parcela.DataVencimento
+	}
+
+	// 2. Lançar o pagamento e baixar a parcela
+	emprestimo, pagamento, err := carregarAtivosFinanciamento(ctx, tx, s.ativoRepo, financiamento)
+	if err != nil {
+		return nil, err
+	}
+	descricao := fmt.Sprintf("Parcela %d/%d - %s", parcela.Numero, parcelas[len(parcelas)-1].Numero, emprestimo.Nome)
+	transacaoID, err := liquidarFinanciamento(ctx, tx, s.transacaoRepo, s.ativoRepo, s.categoriaRepo, s.eventos, emprestimo, pagamento, parcela.Juros, parcela.Amortizacao, data, descricao)
+	if err != nil {
+		return nil, err
+	}
+	agora := time.Now()
+	if err := s.financiamentoRepo.MarcarParcelaPaga(ctx, tx, parcela.ID, transacaoID, agora); err != nil {
+		return nil, err
+	}
+	parcela.Status, parcela.TransacaoID, parcela.PagaEm = models.ParcelaPaga, &transacaoID, &agora
+
+	financiamento.SaldoDevedor = parcela.SaldoDevedor
+	if centavos(financiamento.SaldoDevedor) == 0 {
+		financiamento.Status = models.FinanciamentoQuitado
+	}
+	financiamento.UpdatedAt = agora
+	if err := s.financiamentoRepo.AtualizarSaldo(ctx, tx, financiamento); err != nil {
+		return nil, err
+	}
+
+	return parcela, tx.Commit(ctx)
+}
+
+func proximaParcelaPendente(parcelas []models.ParcelaFinanciamento) *models.ParcelaFinanciamento {
+	for i := range parcelas {
+		if parcelas[i].Status == models.ParcelaPendente {
+			return &parcelas[i]
+		}
+	}
+	return nil
+}
+
+// carregarAtivosFinanciamento bloqueia o empréstimo e o ativo de pagamento, sempre nessa ordem.
+func carregarAtivosFinanciamento(ctx context.Context, tx pgx.Tx, repo repositories.AtivoRepository, f *models.Financiamento) (*models.AtivoFinanceiro, *models.AtivoFinanceiro, error) {
+	emprestimo, err := repo.FindByIDForUpdate(ctx, tx, f.AtivoFinanceiroID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pagamento, err := repo.FindByIDForUpdate(ctx, tx, f.AtivoPagamentoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if emprestimo == nil || pagamento == nil {
+		return nil, nil, ErrAtivoNaoEncontrado
+	}
+	return emprestimo, pagamento, nil
+}
+
+// liquidarFinanciamento lança um pagamento do financiamento: uma saída no ativo de pagamento,
+// rateada entre as categorias de juros e de amortização, e um recebimento da parte de amortização
+// no empréstimo, que reduz a dívida. Retorna o ID da transação de saída.
+func liquidarFinanciamento(ctx context.Context, tx pgx.Tx, transacaoRepo repositories.TransacaoRepository, ativoRepo repositories.AtivoRepository, categoriaRepo repositories.CategoriaRepository, eventos *PublicadorEventos, emprestimo, pagamento *models.AtivoFinanceiro, juros, amortizacao float64, data time.Time, descricao string) (string, error) {
+	categoriaJuros, err := categoriaDoSistema(ctx, categoriaRepo, categoriaJurosFinanciamento, "percent")
+	if err != nil {
+		return "", err
+	}
+	categoriaAmortizacao, err := categoriaDoSistema(ctx, categoriaRepo, categoriaAmortizacaoFinanciamento, "account_balance")
+	if err != nil {
+		return "", err
+	}
+
+	tipoSaida := models.TransacaoDebito
+	if !tipoTransacaoAceito(pagamento.Tipo, tipoSaida) {
+		tipoSaida = models.TransacaoCredito
+	}
+	agora := time.Now()
+	saida := models.Transacao{
+		ID:                uuid.New().String(),
+		AtivoFinanceiroID: pagamento.ID,
+		CategoriaID:       categoriaAmortizacao.ID,
+		Descricao:         descricao,
+		Valor:             math.Round((juros+amortizacao)*100) / 100,
+		Tipo:              tipoSaida,
+		Status:            models.StatusEfetivada,
+		Data:              data,
+		Origem:            models.OrigemFinanciamento,
+		CreatedAt:         agora,
+	}
+	switch {
+	case centavos(amortizacao) == 0:
+		saida.CategoriaID = categoriaJuros.ID
+	case centavos(juros) > 0:
+		saida.CategoriaID = categoriaJuros.ID
+		saida.Divisoes = []models.TransacaoDivisao{
+			{ID: uuid.New().String(), CategoriaID: categoriaJuros.ID, Valor: juros, Memo: "Juros"},
+			{ID: uuid.New().String(), CategoriaID: categoriaAmortizacao.ID, Valor: amortizacao, Memo: "Amortização"},
+		}
+	}
+	type lancamento struct {
+		transacao models.Transacao
+		ativo     *models.AtivoFinanceiro
+	}
+	lancamentos := []lancamento{{saida, pagamento}}
+	if centavos(amortizacao) > 0 {
+		entrada := saida
+		entrada.ID = uuid.New().String()
+		entrada.AtivoFinanceiroID = emprestimo.ID
+		entrada.CategoriaID = categoriaAmortizacao.ID
+		entrada.Valor = amortizacao
+		entrada.Tipo = models.TransacaoRecebimento
+		entrada.Divisoes = nil
+		lancamentos = append(lancamentos, lancamento{entrada, emprestimo})
+	}
+
+	for _, l := range lancamentos {
+		if err := validarTransacao(l.transacao, l.ativo); err != nil {
+			return "", err
+		}
+		if err := transacaoRepo.Create(ctx, tx, &l.transacao); err != nil {
+			return "", err
+		}
+		if err := ativoRepo.UpdateBalance(ctx, tx, l.ativo.ID, efeitoAplicado(l.ativo.Tipo, l.transacao)); err != nil {
+			return "", err
+		}
+		if err := eventos.Publicar(ctx, tx, models.WebhookTransacaoCriada, l.transacao); err != nil {
+			return "", err
+		}
+	}
+	return saida.ID, nil
+}
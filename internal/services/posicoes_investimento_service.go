@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrQuantidadeInsuficiente = erros.RegraNegocio("quantidade_insuficiente", "quantidade em carteira insuficiente para a venda")
+
+// toleranciaQuantidade absorve o arredondamento das quantidades fracionárias gravadas com 8 casas.
+const toleranciaQuantidade = 1e-8
+
+// apuracaoTitulo acumula, em ordem cronológica, as operações de um título.
+type apuracaoTitulo struct {
+	quantidade float64
+	custo      float64
+	realizado  float64
+	proventos  float64
+}
+
+// aplicar incorpora uma operação à apuração pelo método do custo médio: compras somam ao custo
+// (com as taxas), vendas baixam o custo médio da quantidade vendida e realizam a diferença para
+// o valor líquido recebido, e proventos são acumulados à parte.
+func (a *apuracaoTitulo) aplicar(o models.OperacaoInvestimento) error {
+	switch o.Tipo {
+	case models.OperacaoCompra:
+		a.quantidade += o.Quantidade
+		a.custo += o.ValorTotal
+	case models.OperacaoVenda:
+		if o.Quantidade > a.quantidade+toleranciaQuantidade {
+			return fmt.Errorf("%w: %.8g disponíveis em %s", ErrQuantidadeInsuficiente, a.quantidade, o.Data.Format("2006-01-02"))
+		}
+		custoVendido := a.custo * o.Quantidade / a.quantidade
+		a.realizado += o.ValorTotal - custoVendido
+		a.quantidade -= o.Quantidade
+		a.custo -= custoVendido
+		if a.quantidade < toleranciaQuantidade {
+			a.quantidade, a.custo = 0, 0
+		}
+	case models.OperacaoDividendo, models.OperacaoJCP:
+		a.proventos += o.ValorTotal
+	}
+	return nil
+}
+
+// apurarOperacoes reproduz as operações (já em ordem cronológica) e devolve a apuração por título.
+// Uma venda acima da quantidade em carteira naquele momento invalida o conjunto.
+func apurarOperacoes(operacoes []models.OperacaoInvestimento) (map[string]*apuracaoTitulo, error) {
+	apuracoes := make(map[string]*apuracaoTitulo)
+	for _, o := range operacoes {
+		a := apuracoes[o.TituloID]
+		if a == nil {
+			a = &apuracaoTitulo{}
+			apuracoes[o.TituloID] = a
+		}
+		if err := a.aplicar(o); err != nil {
+			return nil, err
+		}
+	}
+	return apuracoes, nil
+}
+
+type PosicoesInvestimentoService struct {
+	repo repositories.InvestimentoRepository
+}
+
+func NewPosicoesInvestimentoService(repo repositories.InvestimentoRepository) *PosicoesInvestimentoService {
+	return &PosicoesInvestimentoService{repo: repo}
+}
+
+// Execute calcula a posição de cada título já operado, avaliada pela cotação mais recente.
+// Sem cotação, o título é avaliado pelo custo e não tem resultado não realizado.
+func (s *PosicoesInvestimentoService) Execute(ctx context.Context) ([]models.PosicaoInvestimento, error) {
+	titulos, err := s.repo.FindTitulos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	operacoes, err := s.repo.FindOperacoes(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	apuracoes, err := apurarOperacoes(operacoes)
+	if err != nil {
+		return nil, err
+	}
+	cotacoes, err := s.repo.FindCotacoesVigentes(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var posicoes []models.PosicaoInvestimento
+	for _, titulo := range titulos {
+		a, ok := apuracoes[titulo.ID]
+		if !ok {
+			continue
+		}
+		p := models.PosicaoInvestimento{
+			Titulo:             titulo,
+			Quantidade:         a.quantidade,
+			CustoTotal:         math.Round(a.custo*100) / 100,
+			ValorMercado:       math.Round(a.custo*100) / 100,
+			ResultadoRealizado: math.Round(a.realizado*100) / 100,
+			Proventos:          math.Round(a.proventos*100) / 100,
+		}
+		if a.quantidade > 0 {
+			p.PrecoMedio = a.custo / a.quantidade
+		}
+		if cotacao, ok := cotacoes[titulo.ID]; ok {
+			preco, data := cotacao.Preco, cotacao.Data
+			p.PrecoAtual, p.DataCotacao = &preco, &data
+			p.ValorMercado = math.Round(a.quantidade*preco*100) / 100
+			p.ResultadoNaoRealizado = math.Round((p.ValorMercado-p.CustoTotal)*100) / 100
+		}
+		posicoes = append(posicoes, p)
+	}
+	return posicoes, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ProcessarAgendadasService struct {
+	transacaoRepo        repositories.TransacaoRepository
+	transferenciaRepo    repositories.TransferenciaRepository
+	efetivarService      *EfetivarTransacaoService
+	transferenciaService *EfetivarTransferenciaService
+}
+
+func NewProcessarAgendadasService(tRepo repositories.TransacaoRepository, trRepo repositories.TransferenciaRepository, efetivarSvc *EfetivarTransacaoService, transferenciaSvc *EfetivarTransferenciaService) *ProcessarAgendadasService {
+	return &ProcessarAgendadasService{transacaoRepo: tRepo, transferenciaRepo: trRepo, efetivarService: efetivarSvc, transferenciaService: transferenciaSvc}
+}
+
+// Execute efetiva as transações agendadas com data até o fim do dia atual, da mais antiga para a
+// mais recente. Cada uma é efetivada em sua própria transação de banco: uma falha, como saldo
+// insuficiente, mantém aquela transação agendada para a próxima execução sem afetar as demais.
+// As duas pontas de uma transferência são efetivadas juntas e contam como um único item.
+func (s *ProcessarAgendadasService) Execute(ctx context.Context) (*RelatorioProcessamento, error) {
+	fimDoDia := inicioDoDia(time.Now()).AddDate(0, 0, 1)
+	log.Info().Time("ate", fimDoDia).Msg("Iniciando efetivação de transações agendadas.")
+
+	agendadas, err := s.transacaoRepo.FindAll(ctx, models.FiltroTransacoes{
+		Status: []models.StatusTransacao{models.StatusAgendada},
+		Fim:    &fimDoDia,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Erro ao buscar transações agendadas.")
+		return nil, err
+	}
+	sort.SliceStable(agendadas, func(i, j int) bool { return agendadas[i].Data.Before(agendadas[j].Data) })
+
+	relatorio := &RelatorioProcessamento{}
+	transferencias := make(map[string]bool)
+	for _, t := range agendadas {
+		id, err := s.efetivar(ctx, t, transferencias)
+		if id == "" {
+			continue
+		}
+		relatorio.TotalParaProcessar++
+		if err != nil {
+			log.Error().Err(err).Str("id", id).Msg("Falha ao efetivar transação agendada.")
+			relatorio.Falhas++
+			relatorio.Erros = append(relatorio.Erros, id+": "+err.Error())
+			continue
+		}
+		relatorio.Sucesso++
+	}
+
+	log.Info().Interface("relatorio", relatorio).Msg("Efetivação de transações agendadas concluída.")
+	return relatorio, nil
+}
+
+// efetivar efetiva a transação ou, se ela for uma ponta de transferência, a transferência inteira,
+// e devolve o ID do que foi processado. Devolve um ID vazio para a segunda ponta de uma
+// transferência já processada nesta execução, registrada em 'transferencias'.
+func (s *ProcessarAgendadasService) efetivar(ctx context.Context, t models.Transacao, transferencias map[string]bool) (string, error) {
+	if t.Origem != models.OrigemTransferencia {
+		_, err := s.efetivarService.Execute(ctx, t.ID)
+		return t.ID, err
+	}
+	transferencia, err := s.transferenciaRepo.FindByTransacaoID(ctx, t.ID)
+	if err != nil {
+		return t.ID, err
+	}
+	if transferencia == nil {
+		return t.ID, ErrTransferenciaNaoEncontrada
+	}
+	if transferencias[transferencia.ID] {
+		return "", nil
+	}
+	transferencias[transferencia.ID] = true
+	_, err = s.transferenciaService.Execute(ctx, transferencia.ID)
+	return transferencia.ID, err
+}
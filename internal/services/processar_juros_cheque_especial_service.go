@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+const (
+	categoriaJurosChequeEspecial = "Juros de cheque especial"
+	// diasMesJuros converte a taxa mensal do cheque especial em taxa diária (mês comercial).
+	diasMesJuros = 30
+)
+
+type ProcessarJurosChequeEspecialService struct {
+	db            *pgxpool.Pool
+	ativoRepo     repositories.AtivoRepository
+	transacaoRepo repositories.TransacaoRepository
+	categoriaRepo repositories.CategoriaRepository
+	eventos       *PublicadorEventos
+}
+
+func NewProcessarJurosChequeEspecialService(db *pgxpool.Pool, aRepo repositories.AtivoRepository, tRepo repositories.TransacaoRepository, cRepo repositories.CategoriaRepository, eventos *PublicadorEventos) *ProcessarJurosChequeEspecialService {
+	return &ProcessarJurosChequeEspecialService{
+		db:            db,
+		ativoRepo:     aRepo,
+		transacaoRepo: tRepo,
+		categoriaRepo: cRepo,
+		eventos:       eventos,
+	}
+}
+
+// Execute lança os juros do dia de cada conta corrente que está usando o cheque especial.
+// Os juros diários são a taxa mensal dividida por 30 aplicada sobre o saldo negativo atual.
+// Cada conta recebe no máximo um lançamento por dia, então o worker pode ser acionado
+// mais de uma vez sem duplicar cobranças.
+func (s *ProcessarJurosChequeEspecialService) Execute(ctx context.Context) (*RelatorioProcessamento, error) {
+	hoje := inicioDoDia(time.Now())
+	log.Info().Time("data", hoje).Msg("Iniciando lançamento de juros de cheque especial.")
+
+	contas, err := s.ativoRepo.FindEmChequeEspecial(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Erro ao buscar contas em cheque especial.")
+		return nil, err
+	}
+	relatorio := &RelatorioProcessamento{TotalParaProcessar: len(contas)}
+	if len(contas) == 0 {
+		return relatorio, nil
+	}
+
+	categoria, err := categoriaDoSistema(ctx, s.categoriaRepo, categoriaJurosChequeEspecial, "percent")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conta := range contas {
+		lancado, err := s.lancarJuros(ctx, conta, categoria.ID, hoje)
+		if err != nil {
+			log.Error().Err(err).Str("ativo_id", conta.ID).Msg("Falha ao lançar juros de cheque especial.")
+			relatorio.Falhas++
+			relatorio.Erros = append(relatorio.Erros, conta.ID+": "+err.Error())
+			continue
+		}
+		if !lancado {
+			relatorio.Ignorados++
+			continue
+		}
+		relatorio.Sucesso++
+	}
+
+	log.Info().Interface("relatorio", relatorio).Msg("Lançamento de juros de cheque especial concluído.")
+	return relatorio, nil
+}
+
+// lancarJuros registra os juros como um débito efetivado na conta. O lançamento não passa pela
+// validação de limite: os juros são devidos mesmo que levem o saldo além do cheque especial.
+// Retorna false, sem erro, quando não há o que lançar: juros abaixo de um centavo ou já lançados no dia.
+func (s *ProcessarJurosChequeEspecialService) lancarJuros(ctx context.Context, conta models.AtivoFinanceiro, categoriaID string, dia time.Time) (bool, error) {
+	valor := math.Round(-conta.SaldoAtual*conta.TaxaJurosChequeEspecial/100/diasMesJuros*100) / 100
+	if valor < 0.01 {
+		return false, nil
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	juros := models.Transacao{
+		ID:                uuid.New().String(),
+		AtivoFinanceiroID: conta.ID,
+		CategoriaID:       categoriaID,
+		Descricao:         categoriaJurosChequeEspecial,
+		Valor:             valor,
+		Tipo:              models.TransacaoDebito,
+		Status:            models.StatusEfetivada,
+		Data:              time.Now(),
+		Notas:             fmt.Sprintf("Saldo devedor de %.2f à taxa de %.4f%% ao mês.", -conta.SaldoAtual, conta.TaxaJurosChequeEspecial),
+		CreatedAt:         time.Now(),
+	}
+	if err := s.transacaoRepo.Create(ctx, tx, &juros); err != nil {
+		return false, err
+	}
+	lancado, err := s.ativoRepo.RegistrarJurosChequeEspecial(ctx, tx, conta.ID, dia, juros.ID, conta.SaldoAtual, valor)
+	if err != nil {
+		return false, err
+	}
+	if !lancado {
+		// Os juros de hoje já foram lançados; descarta a transação criada acima.
+		return false, nil
+	}
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, conta.ID, efeitoAplicado(conta.Tipo, juros)); err != nil {
+		return false, err
+	}
+	if err := s.eventos.Publicar(ctx, tx, models.WebhookTransacaoCriada, juros); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
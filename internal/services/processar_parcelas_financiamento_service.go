@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/repositories"
+)
+
+type ProcessarParcelasFinanciamentoService struct {
+	repo     repositories.FinanciamentoRepository
+	pagarSvc *PagarParcelaFinanciamentoService
+}
+
+func NewProcessarParcelasFinanciamentoService(repo repositories.FinanciamentoRepository, pagarSvc *PagarParcelaFinanciamentoService) *ProcessarParcelasFinanciamentoService {
+	return &ProcessarParcelasFinanciamentoService{repo: repo, pagarSvc: pagarSvc}
+}
+
+// Execute paga, na data de vencimento, todas as parcelas vencidas até hoje. Parcelas atrasadas
+// de um mesmo financiamento são pagas em ordem; se uma falhar (por saldo insuficiente, por
+// exemplo), as seguintes esperam a próxima execução.
+func (s *ProcessarParcelasFinanciamentoService) Execute(ctx context.Context) (*RelatorioProcessamento, error) {
+	hoje := inicioDoDia(time.Now())
+	log.Info().Time("data", hoje).Msg("Iniciando pagamento de parcelas de financiamento vencidas.")
+
+	ids, err := s.repo.FindComParcelasVencidas(ctx, hoje)
+	if err != nil {
+		log.Error().Err(err).Msg("Erro ao buscar financiamentos com parcelas vencidas.")
+		return nil, err
+	}
+	relatorio := &RelatorioProcessamento{TotalParaProcessar: len(ids)}
+
+	for _, id := range ids {
+		pagas := 0
+		for {
+			parcela, err := s.pagarSvc.PagarVencida(ctx, id, hoje)
+			if err != nil {
+				log.Error().Err(err).Str("financiamento_id", id).Msg("Falha ao pagar parcela de financiamento.")
+				relatorio.Falhas++
+				relatorio.Erros = append(relatorio.Erros, id+": "+err.Error())
+				break
+			}
+			if parcela == nil {
+				break
+			}
+			pagas++
+		}
+		if pagas > 0 {
+			relatorio.Sucesso++
+		}
+	}
+
+	log.Info().Interface("relatorio", relatorio).Msg("Pagamento de parcelas de financiamento concluído.")
+	return relatorio, nil
+}
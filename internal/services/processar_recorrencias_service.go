@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog/log"
 
 	"controlador/backend/internal/models"
@@ -15,12 +18,16 @@ import (
 type ProcessarRecorrenciasService struct {
 	trRepo             repositories.TransacaoRecorrenteRepository
 	createTransacaoSvc *CreateTransacaoService
+	notificador        *NotificadorService
+	eventos            *PublicadorEventos
 }
 
-func NewProcessarRecorrenciasService(trr repositories.TransacaoRecorrenteRepository, cts *CreateTransacaoService) *ProcessarRecorrenciasService {
+func NewProcessarRecorrenciasService(trr repositories.TransacaoRecorrenteRepository, cts *CreateTransacaoService, notificador *NotificadorService, eventos *PublicadorEventos) *ProcessarRecorrenciasService {
 	return &ProcessarRecorrenciasService{
 		trRepo:             trr,
 		createTransacaoSvc: cts,
+		notificador:        notificador,
+		eventos:            eventos,
 	}
 }
 
@@ -29,14 +36,16 @@ type RelatorioProcessamento struct {
 	TotalParaProcessar int
 	Sucesso            int
 	Falhas             int
-	Erros              []string
+	// Ignorados conta os itens que não precisavam de processamento, como juros já lançados no dia.
+	Ignorados int
+	Erros     []string
 }
 
 func (s *ProcessarRecorrenciasService) Execute(ctx context.Context) (*RelatorioProcessamento, error) {
-	diaAtual := time.Now().Day()
-	log.Info().Int("dia", diaAtual).Msg("Iniciando processamento de transações recorrentes.")
+	hoje := time.Now()
+	log.Info().Int("dia", hoje.Day()).Msg("Iniciando processamento de transações recorrentes.")
 
-	recorrencias, err := s.trRepo.FindActiveByDay(ctx, diaAtual)
+	recorrencias, err := recorrenciasDoDia(ctx, s.trRepo, hoje)
 	if err != nil {
 		log.Error().Err(err).Msg("Erro ao buscar transações recorrentes do dia.")
 		return nil, err
@@ -60,9 +69,16 @@ func (s *ProcessarRecorrenciasService) Execute(ctx context.Context) (*RelatorioP
 			Descricao:         fmt.Sprintf("Recorrência: %s", recorrencia.Descricao),
 			Valor:             recorrencia.Valor,
 			Tipo:              recorrencia.Tipo,
+			Tags:              recorrencia.Tags,
 		}
 
-		_, err := s.createTransacaoSvc.Execute(ctx, transacao)
+		// O evento da recorrência é gravado junto com a transação gerada.
+		_, err := s.createTransacaoSvc.executar(ctx, transacao, func(tx pgx.Tx, t *models.Transacao) error {
+			return s.eventos.Publicar(ctx, tx, models.WebhookRecorrenciaProcessada, map[string]any{
+				"recorrencia_id": recorrencia.ID,
+				"transacao":      t,
+			})
+		})
 		if err != nil {
 			log.Error().Err(err).Str("recorrencia_id", recorrencia.ID).Msg("Falha ao processar transação recorrente.")
 			relatorio.Falhas++
@@ -74,5 +90,44 @@ func (s *ProcessarRecorrenciasService) Execute(ctx context.Context) (*RelatorioP
 	}
 
 	log.Info().Interface("relatorio", relatorio).Msg("Processamento de transações recorrentes concluído.")
+	if relatorio.Falhas > 0 {
+		s.notificarFalhas(ctx, relatorio)
+	}
 	return relatorio, nil
-}
\ No newline at end of file
+}
+
+// notificarFalhas avisa das falhas da execução. Cada execução é uma ocorrência própria; um erro
+// ao notificar só é registrado no log, sem afetar o resultado do processamento.
+func (s *ProcessarRecorrenciasService) notificarFalhas(ctx context.Context, relatorio *RelatorioProcessamento) {
+	mensagem := fmt.Sprintf("%d de %d recorrências falharam em %s:\n- %s", relatorio.Falhas, relatorio.TotalParaProcessar,
+		time.Now().Format(formatoDataNotificacao), strings.Join(relatorio.Erros, "\n- "))
+	chave := fmt.Sprintf("%s:%s", models.EventoRecorrenciaFalhou, uuid.New().String())
+	if err := s.notificador.Notificar(ctx, models.EventoRecorrenciaFalhou, chave, "Falha no processamento de recorrências", mensagem); err != nil {
+		log.Error().Err(err).Msg("Falha ao notificar erros do processamento de recorrências.")
+	}
+}
+
+// recorrenciasDoDia busca as recorrências ativas que vencem em 'data'. No último dia do mês
+// também vencem as dos dias que o mês não tem: a do dia 31 vence em 30/04 e em 28/02.
+func recorrenciasDoDia(ctx context.Context, repo repositories.TransacaoRecorrenteRepository, data time.Time) ([]models.TransacaoRecorrente, error) {
+	var recorrencias []models.TransacaoRecorrente
+	for _, dia := range diasDeVencimento(data) {
+		doDia, err := repo.FindActiveByDay(ctx, dia)
+		if err != nil {
+			return nil, err
+		}
+		recorrencias = append(recorrencias, doDia...)
+	}
+	return recorrencias, nil
+}
+
+// diasDeVencimento lista os dias do mês cujas recorrências vencem em 'data'.
+func diasDeVencimento(data time.Time) []int {
+	dias := []int{data.Day()}
+	if data.AddDate(0, 0, 1).Day() == 1 {
+		for d := data.Day() + 1; d <= 31; d++ {
+			dias = append(dias, d)
+		}
+	}
+	return dias
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiasDeVencimento(t *testing.T) {
+	casos := []struct {
+		data time.Time
+		dias []int
+	}{
+		{time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), []int{15}},
+		{time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), []int{31}},
+		{time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC), []int{30, 31}},
+		{time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), []int{28, 29, 30, 31}},
+		{time.Date(2028, 2, 28, 0, 0, 0, 0, time.UTC), []int{28}},
+		{time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), []int{29, 30, 31}},
+	}
+	for _, c := range casos {
+		if got := diasDeVencimento(c.data); !reflect.DeepEqual(got, c.dias) {
+			t.Errorf("diasDeVencimento(%s) = %v, esperado %v", c.data.Format("2006-01-02"), got, c.dias)
+		}
+	}
+}
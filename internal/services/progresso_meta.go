@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+const (
+	// MesesRitmoPadrao é a janela, em meses, usada para medir o ritmo de aportes de uma meta.
+	MesesRitmoPadrao = 3
+	mesesRitmoMaximo = 24
+	// limiteProjecaoMeses evita projetar datas absurdas quando o ritmo é quase nulo.
+	limiteProjecaoMeses = 1200
+)
+
+var ErrMesesRitmoInvalido = erros.Invalido("meses_ritmo_invalido", "a janela de ritmo deve ter entre 1 e 24 meses")
+
+// progressoMeta calcula o progresso da meta em 'hoje'. O valor atual é o saldo dos ativos
+// vinculados ou, em metas por tag, a soma das transações efetivadas com a tag, com entradas
+// somando e saídas descontando. O ritmo é a média mensal do que entrou nos últimos 'mesesRitmo' meses.
+func progressoMeta(ctx context.Context, ativoRepo repositories.AtivoRepository, transacaoRepo repositories.TransacaoRepository, conversor *ConversorMoedas, meta *models.Meta, mesesRitmo int, hoje time.Time) (*models.ProgressoMeta, error) {
+	hoje = inicioDoDia(hoje)
+	inicioRitmo := adicionarMeses(hoje, -mesesRitmo)
+	efetivadas := []models.StatusTransacao{models.StatusEfetivada}
+	taxas := make(map[string]float64)
+	converter := func(valor float64, moeda string, data time.Time) (float64, error) {
+		chave := moeda + data.Format("2006-01-02")
+		taxa, ok := taxas[chave]
+		if !ok {
+			var err error
+			if taxa, err = conversor.Taxa(ctx, moeda, meta.Moeda, data); err != nil {
+				return 0, err
+			}
+			taxas[chave] = taxa
+		}
+		return valor * taxa, nil
+	}
+
+	var atual, aportes float64
+	if meta.Tag != nil {
+		transacoes, err := transacaoRepo.FindAll(ctx, models.FiltroTransacoes{Tag: *meta.Tag, Status: efetivadas})
+		if err != nil {
+			return nil, err
+		}
+		tipos, err := tiposOriginais(ctx, transacaoRepo, transacoes)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range transacoes {
+			convertido, err := converter(valorNaMeta(t, tipos), t.Moeda, inicioDoDia(t.Data))
+			if err != nil {
+				return nil, err
+			}
+			atual += convertido
+			if !t.Data.Before(inicioRitmo) {
+				aportes += convertido
+			}
+		}
+	} else {
+		for _, ativoID := range meta.AtivoIDs {
+			ativo, err := ativoRepo.FindByID(ctx, ativoID)
+			if err != nil {
+				return nil, err
+			}
+			if ativo == nil {
+				continue
+			}
+			saldo, err := converter(ativo.SaldoAtual, ativo.Moeda, hoje)
+			if err != nil {
+				return nil, err
+			}
+			atual += saldo
+
+			transacoes, err := transacaoRepo.FindAll(ctx, models.FiltroTransacoes{AtivoFinanceiroID: ativo.ID, Status: efetivadas, Inicio: &inicioRitmo})
+			if err != nil {
+				return nil, err
+			}
+			efeitos, err := efeitosAplicados(ctx, transacaoRepo, ativo.Tipo, transacoes)
+			if err != nil {
+				return nil, err
+			}
+			for i, t := range transacoes {
+				convertido, err := converter(efeitos[i].Saldo, ativo.Moeda, inicioDoDia(t.Data))
+				if err != nil {
+					return nil, err
+				}
+				aportes += convertido
+			}
+		}
+	}
+
+	progresso := &models.ProgressoMeta{
+		ValorAtual:     math.Round(atual*100) / 100,
+		MesesRestantes: mesesAte(hoje, inicioDoDia(meta.DataAlvo)),
+		RitmoMensal:    math.Round(aportes/float64(mesesRitmo)*100) / 100,
+	}
+	progresso.Percentual = math.Round(progresso.ValorAtual/meta.ValorAlvo*10000) / 100
+	progresso.ValorFaltante = math.Max(0, math.Round((meta.ValorAlvo-progresso.ValorAtual)*100)/100)
+	if progresso.ValorFaltante == 0 {
+		return progresso, nil
+	}
+
+	if progresso.MesesRestantes > 0 {
+		progresso.AporteMensalNecessario = math.Round(progresso.ValorFaltante/float64(progresso.MesesRestantes)*100) / 100
+	}
+	if progresso.RitmoMensal > 0 {
+		meses := int(math.Ceil(progresso.ValorFaltante / progresso.RitmoMensal))
+		if meses <= limiteProjecaoMeses {
+			projetada := adicionarMeses(hoje, meses)
+			progresso.DataProjetada = &projetada
+		}
+	}
+	progresso.EmRisco = progresso.MesesRestantes == 0 || progresso.RitmoMensal < progresso.AporteMensalNecessario
+	return progresso, nil
+}
+
+// tiposOriginais liga o ID de cada transação ao seu tipo, incluindo as originais dos estornos
+// que não estão na lista.
+func tiposOriginais(ctx context.Context, repo repositories.TransacaoRepository, transacoes []models.Transacao) (map[string]models.TipoTransacao, error) {
+	tipos := make(map[string]models.TipoTransacao, len(transacoes))
+	for _, t := range transacoes {
+		tipos[t.ID] = t.Tipo
+	}
+	var faltantes []string
+	for _, t := range transacoes {
+		if t.ReversalOf != nil {
+			if _, ok := tipos[*t.ReversalOf]; !ok {
+				faltantes = append(faltantes, *t.ReversalOf)
+			}
+		}
+	}
+	if len(faltantes) == 0 {
+		return tipos, nil
+	}
+	originais, err := repo.FindByIDs(ctx, faltantes)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range originais {
+		tipos[o.ID] = o.Tipo
+	}
+	return tipos, nil
+}
+
+// valorNaMeta expressa a transação como movimento da meta: recebimentos positivos, débitos e
+// créditos negativos. Estornos usam o tipo da transação original, com o sinal invertido.
+func valorNaMeta(t models.Transacao, tipos map[string]models.TipoTransacao) float64 {
+	tipo, sinal := t.Tipo, 1.0
+	if t.ReversalOf != nil {
+		tipo, sinal = tipos[*t.ReversalOf], -1
+	}
+	if tipo == models.TransacaoDebito || tipo == models.TransacaoCredito {
+		sinal = -sinal
+	}
+	return sinal * t.Valor
+}
+
+// mesesAte conta os meses completos de 'de' até 'ate'. Uma data futura a menos de um mês conta
+// como um mês, para que ainda haja um aporte possível; datas passadas resultam em zero.
+func mesesAte(de, ate time.Time) int {
+	if !ate.After(de) {
+		return 0
+	}
+	meses := (ate.Year()-de.Year())*12 + int(ate.Month()-de.Month())
+	if adicionarMeses(de, meses).After(ate) {
+		meses--
+	}
+	return max(meses, 1)
+}
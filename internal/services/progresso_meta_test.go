@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type transacaoRepoMetaFake struct {
+	repositories.TransacaoRepository
+	transacoes []models.Transacao
+	originais  []models.Transacao
+}
+
+func (f *transacaoRepoMetaFake) FindAll(ctx context.Context, filtro models.FiltroTransacoes) ([]models.Transacao, error) {
+	return f.transacoes, nil
+}
+
+func (f *transacaoRepoMetaFake) FindByIDs(ctx context.Context, ids []string) ([]models.Transacao, error) {
+	var encontradas []models.Transacao
+	for _, o := range f.originais {
+		for _, id := range ids {
+			if o.ID == id {
+				encontradas = append(encontradas, o)
+			}
+		}
+	}
+	return encontradas, nil
+}
+
+func TestProgressoMetaPorTagConsideraOTipo(t *testing.T) {
+	data := func(mes, dia int) time.Time { return time.Date(2026, time.Month(mes), dia, 0, 0, 0, 0, time.UTC) }
+	debito, foraDaLista := "debito", "fora"
+	repo := &transacaoRepoMetaFake{
+		transacoes: []models.Transacao{
+			{ID: "recebimento", Tipo: models.TransacaoRecebimento, Valor: 500, Moeda: "BRL", Data: data(9, 10)},
+			{ID: debito, Tipo: models.TransacaoDebito, Valor: 200, Moeda: "BRL", Data: data(10, 1)},
+			{ID: "credito", Tipo: models.TransacaoCredito, Valor: 30, Moeda: "BRL", Data: data(10, 2)},
+			// Estorno parcial do débito: devolve 50 à meta.
+			{ID: "estorno-debito", Tipo: models.TransacaoEstorno, Valor: 50, Moeda: "BRL", Data: data(10, 5), ReversalOf: &debito},
+			// Estorno de um recebimento que não veio na lista: desconta 100.
+			{ID: "estorno-recebimento", Tipo: models.TransacaoEstorno, Valor: 100, Moeda: "BRL", Data: data(10, 6), ReversalOf: &foraDaLista},
+		},
+		originais: []models.Transacao{{ID: foraDaLista, Tipo: models.TransacaoRecebimento, Valor: 100, Moeda: "BRL"}},
+	}
+	tag := "viagem"
+	meta := &models.Meta{ValorAlvo: 2000, DataAlvo: time.Date(2027, 10, 19, 0, 0, 0, 0, time.UTC), Moeda: "BRL", Tag: &tag}
+
+	progresso, err := progressoMeta(context.Background(), nil, repo, NewConversorMoedas(nil, nil), meta, 3, data(10, 19))
+	if err != nil {
+		t.Fatalf("progressoMeta: %v", err)
+	}
+	// 500 - 200 - 30 + 50 - 100
+	if progresso.ValorAtual != 220 {
+		t.Errorf("ValorAtual = %v, esperado 220", progresso.ValorAtual)
+	}
+	if progresso.RitmoMensal != 73.33 {
+		t.Errorf("RitmoMensal = %v, esperado 73.33", progresso.RitmoMensal)
+	}
+	if !progresso.EmRisco {
+		t.Error("meta deveria estar em risco: o ritmo não cobre o aporte necessário")
+	}
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// PublicadorEventos grava eventos do livro-caixa no outbox. O evento é gravado na transação de
+// banco da alteração, então só existe se ela for confirmada; o worker de webhooks o distribui depois.
+type PublicadorEventos struct {
+	repo repositories.WebhookRepository
+}
+
+func NewPublicadorEventos(repo repositories.WebhookRepository) *PublicadorEventos {
+	return &PublicadorEventos{repo: repo}
+}
+
+// Publicar registra o evento com 'dados' serializados em JSON como corpo.
+func (p *PublicadorEventos) Publicar(ctx context.Context, tx pgx.Tx, tipo models.TipoEventoWebhook, dados any) error {
+	corpo, err := json.Marshal(dados)
+	if err != nil {
+		return err
+	}
+	return p.repo.CreateEvento(ctx, tx, &models.EventoOutbox{
+		ID:        uuid.New().String(),
+		Tipo:      tipo,
+		Dados:     corpo,
+		CreatedAt: time.Now(),
+	})
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ReactivateAtivoService struct {
+	db             *pgxpool.Pool
+	repo           repositories.AtivoRepository
+	recorrenteRepo repositories.TransacaoRecorrenteRepository
+}
+
+func NewReactivateAtivoService(db *pgxpool.Pool, repo repositories.AtivoRepository, rRepo repositories.TransacaoRecorrenteRepository) *ReactivateAtivoService {
+	return &ReactivateAtivoService{db: db, repo: repo, recorrenteRepo: rRepo}
+}
+
+// Execute reativa o ativo e retoma as recorrências que foram pausadas por sua desativação.
+// Reativar um ativo já ativo não altera nada.
+func (s *ReactivateAtivoService) Execute(ctx context.Context, id string) (*models.AlteracaoStatusAtivo, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ativo, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	if ativo.IsActive {
+		return &models.AlteracaoStatusAtivo{Ativo: ativo}, nil
+	}
+
+	if err := s.repo.Reactivate(ctx, tx, id); err != nil {
+		return nil, err
+	}
+	retomadas, err := s.recorrenteRepo.RetomarPorAtivo(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ativo.IsActive = true
+	ativo.UpdatedAt = time.Now()
+	return &models.AlteracaoStatusAtivo{Ativo: ativo, RecorrenciasRetomadas: retomadas}, tx.Commit(ctx)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type RecalcularSaldoService struct {
+	db            *pgxpool.Pool
+	ativoRepo     repositories.AtivoRepository
+	transacaoRepo repositories.TransacaoRepository
+}
+
+func NewRecalcularSaldoService(db *pgxpool.Pool, aRepo repositories.AtivoRepository, tRepo repositories.TransacaoRepository) *RecalcularSaldoService {
+	return &RecalcularSaldoService{db: db, ativoRepo: aRepo, transacaoRepo: tRepo}
+}
+
+// Execute recalcula o saldo e o limite disponível do ativo somando o efeito de todas as suas
+// transações efetivadas, a partir do lançamento de saldo inicial, e grava o resultado.
+// A linha do ativo fica bloqueada durante o cálculo: lançamentos concorrentes ainda não
+// confirmados aplicam sua variação sobre o valor recalculado quando forem confirmados.
+func (s *RecalcularSaldoService) Execute(ctx context.Context, id string) (*models.RecalculoSaldo, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	ativo, err := s.ativoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+
+	transacoes, err := s.transacaoRepo.FindAll(ctx, models.FiltroTransacoes{
+		AtivoFinanceiroID: id,
+		Status:            []models.StatusTransacao{models.StatusEfetivada},
+	})
+	if err != nil {
+		return nil, err
+	}
+	efeitos, err := efeitosAplicados(ctx, s.transacaoRepo, ativo.Tipo, transacoes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Soma em centavos para não acumular erro de ponto flutuante.
+	var saldo, limite int64
+	for _, e := range efeitos {
+		saldo += centavos(e.Saldo)
+		limite += centavos(e.Limite)
+	}
+	limite += centavos(ativo.LimiteTotal)
+
+	recalculo := &models.RecalculoSaldo{
+		SaldoAnterior:     ativo.SaldoAtual,
+		SaldoRecalculado:  float64(saldo) / 100,
+		LimiteAnterior:    ativo.LimiteDisponivel,
+		LimiteRecalculado: float64(limite) / 100,
+	}
+	recalculo.Divergente = centavos(recalculo.SaldoAnterior) != saldo || centavos(recalculo.LimiteAnterior) != limite
+
+	if recalculo.Divergente {
+		if err := s.ativoRepo.DefinirSaldo(ctx, tx, id, recalculo.SaldoRecalculado, recalculo.LimiteRecalculado); err != nil {
+			return nil, err
+		}
+		ativo.SaldoAtual = recalculo.SaldoRecalculado
+		ativo.LimiteDisponivel = recalculo.LimiteRecalculado
+		ativo.UpdatedAt = time.Now()
+	}
+	recalculo.Ativo = ativo
+
+	return recalculo, tx.Commit(ctx)
+}
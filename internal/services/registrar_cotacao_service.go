@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrCotacaoTituloInvalida = erros.RegraNegocio("cotacao_titulo_invalida", "a cotação precisa de um preço maior que zero")
+
+type RegistrarCotacaoService struct {
+	db   *pgxpool.Pool
+	repo repositories.InvestimentoRepository
+}
+
+func NewRegistrarCotacaoService(db *pgxpool.Pool, repo repositories.InvestimentoRepository) *RegistrarCotacaoService {
+	return &RegistrarCotacaoService{db: db, repo: repo}
+}
+
+// Execute cadastra manualmente o preço de um título em uma data (hoje, se ausente).
+// Cadastrar de novo o mesmo título e dia substitui a cotação anterior.
+func (s *RegistrarCotacaoService) Execute(ctx context.Context, tituloID string, input models.CotacaoTitulo) (*models.CotacaoTitulo, error) {
+	titulo, err := s.repo.FindTituloByID(ctx, tituloID)
+	if err != nil {
+		return nil, err
+	}
+	if titulo == nil {
+		return nil, ErrTituloNaoEncontrado
+	}
+	if input.Preco <= 0 {
+		return nil, ErrCotacaoTituloInvalida
+	}
+
+	input.TituloID = titulo.ID
+	if input.Data.IsZero() {
+		input.Data = time.Now()
+	}
+	input.Data = inicioDoDia(input.Data)
+	input.Fonte = "MANUAL"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+	if err := s.repo.SaveCotacao(ctx, tx, &input); err != nil {
+		return nil, err
+	}
+	return &input, tx.Commit(ctx)
+}
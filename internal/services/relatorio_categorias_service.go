@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var ErrPeriodoInvalido = erros.Invalido("periodo_invalido", "período inválido: use datas no formato AAAA-MM-DD com início anterior ao fim")
+
+// Periodo delimita um relatório por datas; campos nulos deixam o período aberto.
+// 'Fim' é inclusivo: o dia inteiro é considerado.
+type Periodo struct {
+	Inicio *time.Time
+	Fim    *time.Time
+}
+
+// Limites retorna o período como intervalo semiaberto [inicio, fim+1 dia).
+func (p Periodo) Limites() (*time.Time, *time.Time, error) {
+	if p.Inicio != nil && p.Fim != nil && p.Fim.Before(*p.Inicio) {
+		return nil, nil, ErrPeriodoInvalido
+	}
+	var fim *time.Time
+	if p.Fim != nil {
+		f := p.Fim.AddDate(0, 0, 1)
+		fim = &f
+	}
+	return p.Inicio, fim, nil
+}
+
+type RelatorioCategoriasService struct {
+	repo      repositories.RelatorioRepository
+	conversor *ConversorMoedas
+	moedaBase string
+}
+
+func NewRelatorioCategoriasService(repo repositories.RelatorioRepository, conversor *ConversorMoedas, moedaBase string) *RelatorioCategoriasService {
+	return &RelatorioCategoriasService{repo: repo, conversor: conversor, moedaBase: moedaBase}
+}
+
+// Execute totaliza receitas e despesas por categoria considerando cada linha de rateio,
+// convertidas para 'moeda' (a moeda base configurada, se vazia).
+func (s *RelatorioCategoriasService) Execute(ctx context.Context, periodo Periodo, moeda string) ([]models.RelatorioCategoria, error) {
+	inicio, fim, err := periodo.Limites()
+	if err != nil {
+		return nil, err
+	}
+	linhas, err := s.repo.PorCategoria(ctx, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	moeda, totais, err := consolidarRelatorio(ctx, s.conversor, linhas, moeda, s.moedaBase)
+	if err != nil {
+		return nil, err
+	}
+
+	relatorio := make([]models.RelatorioCategoria, len(totais))
+	for i, t := range totais {
+		relatorio[i] = models.RelatorioCategoria{CategoriaID: t.GrupoID, CategoriaNome: t.GrupoNome, Moeda: moeda, Receitas: t.Receitas, Despesas: t.Despesas}
+	}
+	return relatorio, nil
+}
+
+// consolidarRelatorio converte cada subtotal diário para a moeda do relatório, com a taxa de
+// câmbio do dia, e soma os subtotais de cada grupo mantendo a ordem em que aparecem.
+func consolidarRelatorio(ctx context.Context, conversor *ConversorMoedas, linhas []models.LinhaRelatorio, moeda, moedaBase string) (string, []models.LinhaRelatorio, error) {
+	if moeda == "" {
+		moeda = moedaBase
+	}
+	moeda, err := models.ParseMoeda(moeda)
+	if err != nil {
+		return "", nil, ErrMoedaInvalida
+	}
+
+	type chaveTaxa struct {
+		moeda string
+		dia   time.Time
+	}
+	taxas := make(map[chaveTaxa]float64)
+	indice := make(map[string]int)
+	var totais []models.LinhaRelatorio
+	for _, l := range linhas {
+		chave := chaveTaxa{l.Moeda, l.Dia}
+		taxa, ok := taxas[chave]
+		if !ok {
+			if taxa, err = conversor.Taxa(ctx, l.Moeda, moeda, l.Dia); err != nil {
+				return "", nil, err
+			}
+			taxas[chave] = taxa
+		}
+
+		i, ok := indice[l.GrupoID]
+		if !ok {
+			i = len(totais)
+			indice[l.GrupoID] = i
+			totais = append(totais, models.LinhaRelatorio{GrupoID: l.GrupoID, GrupoNome: l.GrupoNome, Moeda: moeda})
+		}
+		totais[i].Receitas += l.Receitas * taxa
+		totais[i].Despesas += l.Despesas * taxa
+	}
+	for i := range totais {
+		totais[i].Receitas = math.Round(totais[i].Receitas*100) / 100
+		totais[i].Despesas = math.Round(totais[i].Despesas*100) / 100
+	}
+	return moeda, totais, nil
+}
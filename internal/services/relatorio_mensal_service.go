@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type RelatorioMensalService struct {
+	repo      repositories.RelatorioRepository
+	conversor *ConversorMoedas
+	moedaBase string
+}
+
+func NewRelatorioMensalService(repo repositories.RelatorioRepository, conversor *ConversorMoedas, moedaBase string) *RelatorioMensalService {
+	return &RelatorioMensalService{repo: repo, conversor: conversor, moedaBase: moedaBase}
+}
+
+// Execute totaliza receitas e despesas por mês, do mais antigo para o mais recente, convertidas
+// para 'moeda' (a moeda base configurada, se vazia). Meses sem movimento não aparecem.
+func (s *RelatorioMensalService) Execute(ctx context.Context, periodo Periodo, moeda string) ([]models.RelatorioMensal, error) {
+	inicio, fim, err := periodo.Limites()
+	if err != nil {
+		return nil, err
+	}
+	linhas, err := s.repo.PorMes(ctx, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	moeda, totais, err := consolidarRelatorio(ctx, s.conversor, linhas, moeda, s.moedaBase)
+	if err != nil {
+		return nil, err
+	}
+
+	relatorio := make([]models.RelatorioMensal, len(totais))
+	for i, t := range totais {
+		relatorio[i] = models.RelatorioMensal{Mes: t.GrupoID, Moeda: moeda, Receitas: t.Receitas, Despesas: t.Despesas}
+	}
+	return relatorio, nil
+}
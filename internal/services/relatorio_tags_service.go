@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type RelatorioTagsService struct {
+	repo      repositories.RelatorioRepository
+	conversor *ConversorMoedas
+	moedaBase string
+}
+
+func NewRelatorioTagsService(repo repositories.RelatorioRepository, conversor *ConversorMoedas, moedaBase string) *RelatorioTagsService {
+	return &RelatorioTagsService{repo: repo, conversor: conversor, moedaBase: moedaBase}
+}
+
+// Execute totaliza receitas e despesas por tag no período, convertidas para 'moeda'
+// (a moeda base configurada, se vazia).
+func (s *RelatorioTagsService) Execute(ctx context.Context, periodo Periodo, moeda string) ([]models.RelatorioTag, error) {
+	inicio, fim, err := periodo.Limites()
+	if err != nil {
+		return nil, err
+	}
+	linhas, err := s.repo.PorTag(ctx, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+	moeda, totais, err := consolidarRelatorio(ctx, s.conversor, linhas, moeda, s.moedaBase)
+	if err != nil {
+		return nil, err
+	}
+
+	relatorio := make([]models.RelatorioTag, len(totais))
+	for i, t := range totais {
+		relatorio[i] = models.RelatorioTag{TagID: t.GrupoID, TagNome: t.GrupoNome, Moeda: moeda, Receitas: t.Receitas, Despesas: t.Despesas}
+	}
+	return relatorio, nil
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type RestaurarBackupService struct {
+	db   *pgxpool.Pool
+	repo repositories.BackupRepository
+}
+
+func NewRestaurarBackupService(db *pgxpool.Pool, repo repositories.BackupRepository) *RestaurarBackupService {
+	return &RestaurarBackupService{db: db, repo: repo}
+}
+
+// Execute valida o arquivo por inteiro (formato, versão, checksum e referências) e só então o
+// carrega, em uma única transação de banco: ou tudo é restaurado, ou nada muda. 'modo' vazio
+// equivale a models.RestauracaoSubstituir.
+func (s *RestaurarBackupService) Execute(ctx context.Context, r io.Reader, modo models.ModoRestauracao) (*models.ResumoRestauracao, error) {
+	if modo == "" {
+		modo = models.RestauracaoSubstituir
+	}
+	if modo != models.RestauracaoSubstituir && modo != models.RestauracaoMesclar {
+		return nil, ErrModoRestauracaoInvalido
+	}
+
+	dados, err := lerBackup(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := validarBackup(dados); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.repo.MarcarRestauracao(ctx, tx); err != nil {
+		return nil, err
+	}
+	resumo := &models.ResumoRestauracao{Modo: modo, Tabelas: make(map[string]int, len(tabelasBackup))}
+	if modo == models.RestauracaoSubstituir {
+		tabelas := make([]string, 0, len(tabelasBackup)+len(tabelasTransitorias))
+		for _, t := range tabelasBackup {
+			tabelas = append(tabelas, t.nome)
+		}
+		if err := s.repo.Limpar(ctx, tx, append(tabelas, tabelasTransitorias...)); err != nil {
+			return nil, err
+		}
+	} else if err := s.remapear(ctx, tx, dados, resumo); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tabelasBackup {
+		linhas := dados[t.nome]
+		resumo.Tabelas[t.nome] = 0
+		if len(linhas) == 0 {
+			continue
+		}
+		corpo, err := json.Marshal(linhas)
+		if err != nil {
+			return nil, err
+		}
+		inseridas, err := s.repo.Inserir(ctx, tx, t.nome, corpo, modo == models.RestauracaoMesclar && t.ignorarConflitos)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao restaurar a tabela %s: %w", t.nome, err)
+		}
+		resumo.Tabelas[t.nome] = int(inseridas)
+		resumo.Ignorados += len(linhas) - int(inseridas)
+	}
+
+	return resumo, tx.Commit(ctx)
+}
+
+// lerBackup decodifica o envelope, confere formato, versão e checksum e devolve as linhas de
+// cada tabela. Os números são mantidos como no arquivo, sem passar por float64.
+func lerBackup(r io.Reader) (map[string][]map[string]any, error) {
+	var arquivo models.ArquivoBackup
+	if err := json.NewDecoder(r).Decode(&arquivo); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBackupInvalido, err)
+	}
+	if arquivo.Formato != models.FormatoBackup || len(arquivo.Dados) == 0 {
+		return nil, ErrBackupInvalido
+	}
+	if arquivo.Versao != models.VersaoBackup {
+		return nil, fmt.Errorf("%w: %d (suportada: %d)", ErrVersaoBackupNaoSuportada, arquivo.Versao, models.VersaoBackup)
+	}
+	soma := sha256.Sum256(arquivo.Dados)
+	if arquivo.Checksum != "sha256:"+hex.EncodeToString(soma[:]) {
+		return nil, ErrChecksumBackupInvalido
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(arquivo.Dados))
+	decoder.UseNumber()
+	var dados map[string][]map[string]any
+	if err := decoder.Decode(&dados); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBackupInvalido, err)
+	}
+	conhecidas := make(map[string]bool, len(tabelasBackup))
+	for _, t := range tabelasBackup {
+		conhecidas[t.nome] = true
+	}
+	for nome := range dados {
+		if !conhecidas[nome] {
+			return nil, fmt.Errorf("%w: tabela desconhecida %q", ErrBackupInvalido, nome)
+		}
+	}
+	return dados, nil
+}
+
+// remapear prepara o backup para ser somado aos dados atuais: cada ID ganha um valor novo, exceto
+// nas tabelas com chave natural, em que a linha já existente com o mesmo nome ou código é
+// reaproveitada e a do backup deixa de ser carregada.
+func (s *RestaurarBackupService) remapear(ctx context.Context, tx pgx.Tx, dados map[string][]map[string]any, resumo *models.ResumoRestauracao) error {
+	novos := make(map[string]string)
+	for _, t := range tabelasBackup {
+		var existentes map[string]string
+		if t.chaveNatural != "" {
+			var err error
+			if existentes, err = s.repo.ChavesNaturais(ctx, tx, t.nome, t.chaveNatural); err != nil {
+				return err
+			}
+		}
+
+		mantidas := dados[t.nome][:0]
+		for _, linha := range dados[t.nome] {
+			id, ok := linha["id"].(string)
+			if !ok {
+				mantidas = append(mantidas, linha)
+				continue
+			}
+			if chave, ok := linha[t.chaveNatural].(string); ok && existentes[chave] != "" {
+				novos[id] = existentes[chave]
+				resumo.Reutilizados++
+				continue
+			}
+			novos[id] = uuid.New().String()
+			mantidas = append(mantidas, linha)
+		}
+		dados[t.nome] = mantidas
+	}
+
+	for _, linhas := range dados {
+		for _, linha := range linhas {
+			substituirIDs(linha, novos)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type ResumoConciliacaoService struct {
+	conciliacaoRepo repositories.ConciliacaoRepository
+	transacaoRepo   repositories.TransacaoRepository
+	ativoRepo       repositories.AtivoRepository
+}
+
+func NewResumoConciliacaoService(cRepo repositories.ConciliacaoRepository, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository) *ResumoConciliacaoService {
+	return &ResumoConciliacaoService{conciliacaoRepo: cRepo, transacaoRepo: tRepo, ativoRepo: aRepo}
+}
+
+func (s *ResumoConciliacaoService) Execute(ctx context.Context, id string) (*models.ResumoConciliacao, error) {
+	conciliacao, err := s.conciliacaoRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if conciliacao == nil {
+		return nil, ErrConciliacaoNaoEncontrada
+	}
+	ativo, err := s.ativoRepo.FindByID(ctx, conciliacao.AtivoFinanceiroID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	return resumirConciliacao(ctx, s.transacaoRepo, *ativo, *conciliacao)
+}
+
+// leitorTransacoes é a parte de repositories.TransacaoRepository usada nos cálculos de saldo.
+// Dentro de uma transação de banco, use transacoesNaTx para que a leitura enxergue o que ela gravou.
+type leitorTransacoes interface {
+	FindAll(ctx context.Context, filtro models.FiltroTransacoes) ([]models.Transacao, error)
+	FindByIDs(ctx context.Context, ids []string) ([]models.Transacao, error)
+}
+
+// transacoesNaTx lê as transações através de 'tx' em vez do pool.
+type transacoesNaTx struct {
+	repo repositories.TransacaoRepository
+	tx   pgx.Tx
+}
+
+func (l transacoesNaTx) FindAll(ctx context.Context, filtro models.FiltroTransacoes) ([]models.Transacao, error) {
+	return l.repo.FindAllTx(ctx, l.tx, filtro)
+}
+
+func (l transacoesNaTx) FindByIDs(ctx context.Context, ids []string) ([]models.Transacao, error) {
+	return l.repo.FindByIDsTx(ctx, l.tx, ids)
+}
+
+// resumirConciliacao calcula o saldo conciliado partindo do saldo atual do ativo e descontando o
+// efeito das transações efetivadas ainda não conferidas; assim o saldo inicial do ativo, que não é
+// uma transação, entra na conta. Para cartões de crédito, o valor comparado é o limite disponível.
+func resumirConciliacao(ctx context.Context, tRepo leitorTransacoes, ativo models.AtivoFinanceiro, conciliacao models.Conciliacao) (*models.ResumoConciliacao, error) {
+	naoConferidas := false
+	pendentes, err := tRepo.FindAll(ctx, models.FiltroTransacoes{
+		AtivoFinanceiroID: ativo.ID,
+		Status:            []models.StatusTransacao{models.StatusEfetivada},
+		Conciliada:        &naoConferidas,
+	})
+	if err != nil {
+		return nil, err
+	}
+	efeitos, err := efeitosAplicados(ctx, tRepo, ativo.Tipo, pendentes)
+	if err != nil {
+		return nil, err
+	}
+	var pendente int64
+	for _, efeito := range efeitos {
+		pendente += centavos(valorConciliavel(ativo.Tipo, efeito))
+	}
+	atual := valorConciliavel(ativo.Tipo, models.EfeitoSaldo{Saldo: ativo.SaldoAtual, Limite: ativo.LimiteDisponivel})
+	conciliado := centavos(atual) - pendente
+
+	// Transações que ainda podem ser conferidas nesta conciliação: efetivadas, até a data do
+	// extrato e não bloqueadas por uma conciliação anterior.
+	fim := limiteConciliacao(conciliacao)
+	candidatas, err := tRepo.FindAll(ctx, models.FiltroTransacoes{
+		AtivoFinanceiroID: ativo.ID,
+		Status:            []models.StatusTransacao{models.StatusEfetivada},
+		Fim:               &fim,
+	})
+	if err != nil {
+		return nil, err
+	}
+	transacoes := []models.Transacao{}
+	for _, t := range candidatas {
+		if !t.Bloqueada {
+			transacoes = append(transacoes, t)
+		}
+	}
+
+	return &models.ResumoConciliacao{
+		Conciliacao:     conciliacao,
+		SaldoConciliado: float64(conciliado) / 100,
+		Diferenca:       float64(centavos(conciliacao.SaldoExtrato)-conciliado) / 100,
+		Transacoes:      transacoes,
+	}, nil
+}
+
+// efeitosAplicados calcula o efeito de cada transação efetivada sobre o ativo, buscando as
+// transações originais dos estornos para saber o que eles desfazem.
+func efeitosAplicados(ctx context.Context, repo leitorTransacoes, tipoAtivo models.TipoAtivo, transacoes []models.Transacao) ([]models.EfeitoSaldo, error) {
+	var idsOriginais []string
+	for _, t := range transacoes {
+		if t.ReversalOf != nil {
+			idsOriginais = append(idsOriginais, *t.ReversalOf)
+		}
+	}
+	originais := make(map[string]models.Transacao, len(idsOriginais))
+	if len(idsOriginais) > 0 {
+		lista, err := repo.FindByIDs(ctx, idsOriginais)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range lista {
+			originais[o.ID] = o
+		}
+	}
+
+	efeitos := make([]models.EfeitoSaldo, len(transacoes))
+	for i, t := range transacoes {
+		if t.ReversalOf != nil {
+			efeitos[i] = efeitoEstorno(tipoAtivo, originais[*t.ReversalOf], t.Valor)
+		} else {
+			efeitos[i] = efeitoAplicado(tipoAtivo, t)
+		}
+	}
+	return efeitos, nil
+}
+
+// valorConciliavel escolhe, do efeito, a parte comparada com o extrato do tipo de ativo.
+func valorConciliavel(tipo models.TipoAtivo, efeito models.EfeitoSaldo) float64 {
+	if tipo == models.AtivoCartaoCredito {
+		return efeito.Limite
+	}
+	return efeito.Saldo
+}
+
+// limiteConciliacao é o fim (exclusivo) do dia do extrato.
+func limiteConciliacao(c models.Conciliacao) time.Time {
+	return inicioDoDia(c.DataExtrato).AddDate(0, 0, 1)
+}
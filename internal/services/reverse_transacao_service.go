@@ -2,52 +2,121 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"controlador/backend/internal/erros"
 	"controlador/backend/internal/models"
 	"controlador/backend/internal/repositories"
 
 )
 
-var ErrTransacaoJaEstornada = errors.New("transação já foi estornada")
+var (
+	ErrTransacaoJaEstornada     = erros.Conflito("transacao_ja_estornada", "transação já foi estornada")
+	ErrTransacaoNaoEncontrada   = erros.NaoEncontrado("transacao_nao_encontrada", "transação não encontrada")
+	ErrEstornoDeEstorno         = erros.Conflito("estorno_de_estorno", "não é possível estornar um estorno")
+	ErrValorEstornoInvalido     = erros.RegraNegocio("valor_estorno_invalido", "o valor do estorno deve ser maior que zero")
+	ErrValorEstornoExcedeLimite = erros.Conflito("valor_estorno_excede_limite", "o valor do estorno excede o valor ainda estornável da transação")
+	ErrTransacaoNaoEfetivada    = erros.Conflito("transacao_nao_efetivada", "somente transações efetivadas podem ser estornadas; cancele as agendadas ou pendentes")
+)
+
+// ReverseTransacaoInput contém os dados opcionais de um estorno.
+// Sem 'Valor', todo o valor ainda estornável da transação é devolvido.
+type ReverseTransacaoInput struct {
+	Valor  *float64 `json:"valor"`
+	Motivo string   `json:"motivo"`
+}
 
 type ReverseTransacaoService struct {
 	db            *pgxpool.Pool
 	transacaoRepo repositories.TransacaoRepository
 	ativoRepo     repositories.AtivoRepository
+	eventos       *PublicadorEventos
 }
 
-func NewReverseTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository) *ReverseTransacaoService {
-	return &ReverseTransacaoService{db: db, transacaoRepo: tRepo, ativoRepo: aRepo}
+func NewReverseTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, eventos *PublicadorEventos) *ReverseTransacaoService {
+	return &ReverseTransacaoService{db: db, transacaoRepo: tRepo, ativoRepo: aRepo, eventos: eventos}
 }
 
-func (s *ReverseTransacaoService) Execute(ctx context.Context, transacaoID string) (*models.Transacao, error) {
+func (s *ReverseTransacaoService) Execute(ctx context.Context, transacaoID string, input ReverseTransacaoInput) (*models.Transacao, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil { return nil, err }
 	defer tx.Rollback(ctx)
 
-	original, err := s.transacaoRepo.FindByID(ctx, transacaoID)
+	estorno, err := s.ExecuteTx(ctx, tx, transacaoID, input)
+	if err != nil { return nil, err }
+
+	return estorno, tx.Commit(ctx)
+}
+
+// ExecuteTx estorna a transação dentro de uma transação de banco aberta por quem chama,
+// permitindo que o estorno faça parte de uma operação maior (como a mesclagem de duplicatas).
+func (s *ReverseTransacaoService) ExecuteTx(ctx context.Context, tx pgx.Tx, transacaoID string, input ReverseTransacaoInput) (*models.Transacao, error) {
+	// 1. Validar a transação original, bloqueando-a até o fim da transação de banco
+	original, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, transacaoID)
 	if err != nil { return nil, err }
-	if original == nil { return nil, errors.New("transação original não encontrada") }
-	if original.ReversalOf != nil { return nil, ErrTransacaoJaEstornada }
+	if original == nil { return nil, ErrTransacaoNaoEncontrada }
+	if original.ReversalOf != nil { return nil, ErrEstornoDeEstorno }
+	if original.Status != models.StatusEfetivada { return nil, ErrTransacaoNaoEfetivada }
+	if original.Bloqueada { return nil, ErrTransacaoBloqueada }
+	if original.Tipo == models.TransacaoSaldoInicial { return nil, ErrTransacaoSaldoInicial }
+	if original.Origem != "" { return nil, ErrTransacaoGerada }
 
+	restante := original.ValorEstornavel()
+	if restante <= 0 { return nil, ErrTransacaoJaEstornada }
+
+	// 2. Determinar o valor do estorno (total por padrão, ou parcial se informado)
+	valor := restante
+	if input.Valor != nil {
+		valor = math.Round(*input.Valor*100) / 100
+		if valor <= 0 { return nil, ErrValorEstornoInvalido }
+		if valor > restante { return nil, ErrValorEstornoExcedeLimite }
+	}
+
+	// 3. Registrar o valor estornado na original; a atualização é condicional e
+	// falha se outro estorno concorrente já consumiu o saldo estornável.
+	ok, err := s.transacaoRepo.RegistrarEstorno(ctx, tx, original.ID, valor)
+	if err != nil { return nil, err }
+	if !ok { return nil, ErrValorEstornoExcedeLimite }
+
+	// 4. Montar o estorno herdando a categoria da original
+	var motivo *string
+	if m := strings.TrimSpace(input.Motivo); m != "" {
+		motivo = &m
+	}
+	now := time.Now()
 	estorno := &models.Transacao{
 		ID:                uuid.New().String(),
 		AtivoFinanceiroID: original.AtivoFinanceiroID,
+		CategoriaID:       original.CategoriaID,
 		Descricao:         fmt.Sprintf("Estorno de: %s", original.Descricao),
-		Valor:             original.Valor,
+		Valor:             valor,
 		Tipo:              models.TransacaoEstorno,
+		Status:            models.StatusEfetivada,
+		Data:              now,
 		ReversalOf:        &original.ID,
-		CreatedAt:         time.Now(),
+		MotivoEstorno:     motivo,
+		EstornoParcial:    valor < restante,
+		CreatedAt:         now,
+	}
+	// O estorno nunca é datado antes da transação que ele desfaz.
+	if estorno.Data.Before(original.Data) {
+		estorno.Data = original.Data
 	}
-	
+
+	// 5. Persistir o estorno e desfazer o efeito da original sobre o ativo
+	ativo, err := s.ativoRepo.FindByID(ctx, original.AtivoFinanceiroID)
+	if err != nil { return nil, err }
+	if ativo == nil { return nil, ErrAtivoNaoEncontrado }
 	if err := s.transacaoRepo.Create(ctx, tx, estorno); err != nil { return nil, err }
-	if err := s.ativoRepo.UpdateBalance(ctx, tx, estorno.AtivoFinanceiroID, estorno.Valor, estorno.Tipo); err != nil { return nil, err }
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, estorno.AtivoFinanceiroID, efeitoEstorno(ativo.Tipo, *original, valor)); err != nil { return nil, err }
+	if err := s.eventos.Publicar(ctx, tx, models.WebhookTransacaoEstornada, estorno); err != nil { return nil, err }
 
-	return estorno, tx.Commit(ctx)
-}
\ No newline at end of file
+	return estorno, nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+type SaldoProjetadoService struct {
+	ativoRepo     repositories.AtivoRepository
+	transacaoRepo repositories.TransacaoRepository
+}
+
+func NewSaldoProjetadoService(aRepo repositories.AtivoRepository, tRepo repositories.TransacaoRepository) *SaldoProjetadoService {
+	return &SaldoProjetadoService{ativoRepo: aRepo, transacaoRepo: tRepo}
+}
+
+// Execute projeta o saldo e o limite do ativo somando ao valor atual o efeito das transações
+// agendadas e pendentes. Com 'ate', só entram as transações com data até esse dia, inclusive.
+func (s *SaldoProjetadoService) Execute(ctx context.Context, ativoID string, ate *time.Time) (*models.SaldoProjetado, error) {
+	ativo, err := s.ativoRepo.FindByID(ctx, ativoID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	_, fim, err := Periodo{Fim: ate}.Limites()
+	if err != nil {
+		return nil, err
+	}
+
+	pendentes, err := s.transacaoRepo.FindAll(ctx, models.FiltroTransacoes{
+		AtivoFinanceiroID: ativo.ID,
+		Status:            []models.StatusTransacao{models.StatusAgendada, models.StatusPendente},
+		Fim:               fim,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	saldo := &models.SaldoProjetado{
+		AtivoFinanceiroID:   ativo.ID,
+		SaldoAtual:          ativo.SaldoAtual,
+		LimiteDisponivel:    ativo.LimiteDisponivel,
+		TransacoesPendentes: len(pendentes),
+		Ate:                 ate,
+	}
+	var pendenteSaldo, pendenteLimite int64
+	for _, t := range pendentes {
+		efeito := efeitoTransacao(ativo.Tipo, t.Tipo, t.Valor)
+		pendenteSaldo += centavos(efeito.Saldo)
+		pendenteLimite += centavos(efeito.Limite)
+	}
+	saldo.SaldoPendente = float64(pendenteSaldo) / 100
+	saldo.LimitePendente = float64(pendenteLimite) / 100
+	saldo.SaldoProjetado = float64(centavos(ativo.SaldoAtual)+pendenteSaldo) / 100
+	saldo.LimiteProjetado = float64(centavos(ativo.LimiteDisponivel)+pendenteLimite) / 100
+	return saldo, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+const percentualAlertaPadrao = 80
+
+var (
+	ErrOrcamentoInvalido      = erros.RegraNegocio("orcamento_invalido", "o orçamento exige valor mensal maior que zero e percentual de alerta entre 0 e 100")
+	ErrOrcamentoNaoEncontrado = erros.NaoEncontrado("orcamento_nao_encontrado", "a categoria não tem orçamento")
+)
+
+// SalvarOrcamentoInput define o orçamento de uma categoria. Sem 'percentual_alerta', o alerta
+// é dado ao atingir 80% do valor mensal.
+type SalvarOrcamentoInput struct {
+	ValorMensal      float64  `json:"valor_mensal" binding:"required"`
+	PercentualAlerta *float64 `json:"percentual_alerta"`
+}
+
+type SalvarOrcamentoService struct {
+	repo          repositories.OrcamentoRepository
+	categoriaRepo repositories.CategoriaRepository
+}
+
+func NewSalvarOrcamentoService(repo repositories.OrcamentoRepository, cRepo repositories.CategoriaRepository) *SalvarOrcamentoService {
+	return &SalvarOrcamentoService{repo: repo, categoriaRepo: cRepo}
+}
+
+// Execute cria ou substitui o orçamento mensal da categoria, expresso na moeda base.
+func (s *SalvarOrcamentoService) Execute(ctx context.Context, categoriaID string, input SalvarOrcamentoInput) (*models.Orcamento, error) {
+	categoria, err := s.categoriaRepo.FindByID(ctx, categoriaID)
+	if err != nil {
+		return nil, err
+	}
+	if categoria == nil {
+		return nil, ErrCategoriaNaoEncontrada
+	}
+
+	agora := time.Now()
+	orcamento := &models.Orcamento{
+		CategoriaID:      categoria.ID,
+		ValorMensal:      math.Round(input.ValorMensal*100) / 100,
+		PercentualAlerta: percentualAlertaPadrao,
+		CreatedAt:        agora,
+		UpdatedAt:        agora,
+	}
+	if input.PercentualAlerta != nil {
+		orcamento.PercentualAlerta = math.Round(*input.PercentualAlerta*100) / 100
+	}
+	if orcamento.ValorMensal <= 0 || orcamento.PercentualAlerta <= 0 || orcamento.PercentualAlerta > 100 {
+		return nil, ErrOrcamentoInvalido
+	}
+
+	if err := s.repo.Save(ctx, orcamento); err != nil {
+		return nil, err
+	}
+	return orcamento, nil
+}
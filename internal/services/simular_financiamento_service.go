@@ -0,0 +1,69 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+)
+
+// prazoMaximoFinanciamento limita o cronograma a 50 anos, acima do prazo dos financiamentos imobiliários.
+const prazoMaximoFinanciamento = 600
+
+var ErrFinanciamentoInvalido = erros.RegraNegocio("financiamento_invalido", "financiamento inválido: informe sistema SAC ou PRICE, taxa de juros não negativa e prazo entre 1 e 600 meses")
+
+// SimulacaoFinanciamentoInput descreve as condições de um financiamento a simular.
+type SimulacaoFinanciamentoInput struct {
+	Sistema             models.SistemaAmortizacao `json:"sistema" binding:"required"`
+	ValorPrincipal      float64                   `json:"valor_principal"`
+	TaxaJurosMensal     float64                   `json:"taxa_juros_mensal"`
+	PrazoMeses          int                       `json:"prazo_meses"`
+	DataPrimeiraParcela time.Time                 `json:"-"`
+}
+
+// SimulacaoFinanciamento é o cronograma simulado com os totais pagos.
+type SimulacaoFinanciamento struct {
+	Parcelas   []models.ParcelaFinanciamento `json:"parcelas"`
+	TotalJuros float64                       `json:"total_juros"`
+	TotalPago  float64                       `json:"total_pago"`
+}
+
+type SimularFinanciamentoService struct{}
+
+func NewSimularFinanciamentoService() *SimularFinanciamentoService {
+	return &SimularFinanciamentoService{}
+}
+
+// Execute calcula o cronograma sem gravar nada; sem data, a primeira parcela vence daqui a um mês.
+func (s *SimularFinanciamentoService) Execute(input SimulacaoFinanciamentoInput) (*SimulacaoFinanciamento, error) {
+	if err := validarCondicoesFinanciamento(input.Sistema, input.TaxaJurosMensal, input.PrazoMeses); err != nil {
+		return nil, err
+	}
+	principal := math.Round(input.ValorPrincipal*100) / 100
+	if principal <= 0 {
+		return nil, ErrFinanciamentoInvalido
+	}
+	primeira := input.DataPrimeiraParcela
+	if primeira.IsZero() {
+		primeira = adicionarMeses(inicioDoDia(time.Now()), 1)
+	}
+
+	simulacao := &SimulacaoFinanciamento{
+		Parcelas: gerarParcelas(input.Sistema, principal, input.TaxaJurosMensal, input.PrazoMeses, primeira, 1),
+	}
+	for _, p := range simulacao.Parcelas {
+		simulacao.TotalJuros += p.Juros
+		simulacao.TotalPago += p.Valor
+	}
+	simulacao.TotalJuros = math.Round(simulacao.TotalJuros*100) / 100
+	simulacao.TotalPago = math.Round(simulacao.TotalPago*100) / 100
+	return simulacao, nil
+}
+
+func validarCondicoesFinanciamento(sistema models.SistemaAmortizacao, taxa float64, prazo int) error {
+	if (sistema != models.SistemaSAC && sistema != models.SistemaPrice) || taxa < 0 || prazo < 1 || prazo > prazoMaximoFinanciamento {
+		return ErrFinanciamentoInvalido
+	}
+	return nil
+}
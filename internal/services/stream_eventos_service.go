@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+const (
+	// canalStream é o canal do NOTIFY disparado pelos gatilhos de eventos_stream.
+	canalStream = "eventos_stream"
+	// tamanhoBufferStream é quantos eventos um cliente lento pode acumular antes de ser desconectado.
+	tamanhoBufferStream   = 256
+	loteHistoricoStream   = 500
+	esperaReconexaoStream = 5 * time.Second
+)
+
+// FiltroStream restringe os eventos enviados a um cliente; listas vazias aceitam tudo.
+type FiltroStream struct {
+	AtivoIDs []string
+	Tipos    []models.TipoEventoStream
+}
+
+func (f FiltroStream) aceita(e models.EventoStream) bool {
+	if len(f.AtivoIDs) > 0 && !slices.Contains(f.AtivoIDs, e.AtivoFinanceiroID) {
+		return false
+	}
+	return len(f.Tipos) == 0 || slices.Contains(f.Tipos, e.Tipo)
+}
+
+// AssinaturaStream recebe os eventos confirmados a partir do momento em que foi criada. O canal
+// é fechado quando a assinatura é cancelada, quando o cliente não acompanha o ritmo dos eventos
+// ou quando a conexão de LISTEN cai; nos dois últimos casos o cliente deve se reconectar
+// informando o último evento recebido.
+type AssinaturaStream struct {
+	Eventos <-chan models.EventoStream
+	eventos chan models.EventoStream
+	filtro  FiltroStream
+}
+
+// StreamEventosService repassa em tempo real os eventos gravados pelos gatilhos do banco. Cada
+// servidor mantém sua própria conexão em LISTEN, então eventos gerados por qualquer réplica
+// chegam aos clientes de todas.
+type StreamEventosService struct {
+	db       *pgxpool.Pool
+	repo     repositories.StreamRepository
+	retencao time.Duration
+
+	mu          sync.Mutex
+	assinaturas map[*AssinaturaStream]struct{}
+}
+
+func NewStreamEventosService(db *pgxpool.Pool, repo repositories.StreamRepository, retencao time.Duration) *StreamEventosService {
+	return &StreamEventosService{
+		db:          db,
+		repo:        repo,
+		retencao:    retencao,
+		assinaturas: make(map[*AssinaturaStream]struct{}),
+	}
+}
+
+// Ouvir mantém a conexão em LISTEN e distribui os eventos às assinaturas até 'ctx' ser cancelado.
+// Se a conexão cair, os avisos do intervalo se perdem: as assinaturas são encerradas para que os
+// clientes retomem pelo último evento, e a conexão é refeita.
+func (s *StreamEventosService) Ouvir(ctx context.Context) {
+	for {
+		err := s.ouvir(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error().Err(err).Msg("Conexão de LISTEN do stream de eventos perdida; reconectando")
+		s.encerrarAssinaturas()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(esperaReconexaoStream):
+		}
+	}
+}
+
+func (s *StreamEventosService) ouvir(ctx context.Context) error {
+	conexao, err := s.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A conexão fica presa ao LISTEN; ela sai do pool e é fechada ao final.
+	conn := conexao.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+canalStream); err != nil {
+		return err
+	}
+	log.Info().Msg("Stream de eventos aguardando notificações do banco.")
+
+	for {
+		notificacao, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		id, err := strconv.ParseInt(notificacao.Payload, 10, 64)
+		if err != nil {
+			log.Warn().Str("payload", notificacao.Payload).Msg("Notificação do stream de eventos ignorada")
+			continue
+		}
+		evento, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if evento != nil {
+			s.distribuir(*evento)
+		}
+	}
+}
+
+func (s *StreamEventosService) distribuir(e models.EventoStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for a := range s.assinaturas {
+		if !a.filtro.aceita(e) {
+			continue
+		}
+		select {
+		case a.eventos <- e:
+		default:
+			delete(s.assinaturas, a)
+			close(a.eventos)
+		}
+	}
+}
+
+func (s *StreamEventosService) encerrarAssinaturas() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for a := range s.assinaturas {
+		delete(s.assinaturas, a)
+		close(a.eventos)
+	}
+}
+
+func (s *StreamEventosService) Assinar(filtro FiltroStream) *AssinaturaStream {
+	eventos := make(chan models.EventoStream, tamanhoBufferStream)
+	a := &AssinaturaStream{Eventos: eventos, eventos: eventos, filtro: filtro}
+	s.mu.Lock()
+	s.assinaturas[a] = struct{}{}
+	s.mu.Unlock()
+	return a
+}
+
+func (s *StreamEventosService) Cancelar(a *AssinaturaStream) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.assinaturas[a]; ok {
+		delete(s.assinaturas, a)
+		close(a.eventos)
+	}
+}
+
+// Historico chama 'enviar', em ordem, para cada evento guardado posterior a 'desde' que passe no
+// filtro. A retomada só alcança eventos ainda dentro do prazo de retenção. Os IDs são atribuídos
+// na ordem de confirmação, então um evento confirmado depois de 'desde' sempre tem ID maior.
+func (s *StreamEventosService) Historico(ctx context.Context, desde int64, filtro FiltroStream, enviar func(models.EventoStream) error) error {
+	for {
+		eventos, err := s.repo.FindDesde(ctx, desde, loteHistoricoStream)
+		if err != nil {
+			return err
+		}
+		for _, e := range eventos {
+			if filtro.aceita(e) {
+				if err := enviar(e); err != nil {
+					return err
+				}
+			}
+			desde = e.ID
+		}
+		if len(eventos) < loteHistoricoStream {
+			return nil
+		}
+	}
+}
+
+// LimparEventosAntigos remove os eventos mais antigos que o prazo de retenção.
+func (s *StreamEventosService) LimparEventosAntigos(ctx context.Context) (int64, error) {
+	return s.repo.DeleteAntesDe(ctx, time.Now().Add(-s.retencao))
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"controlador/backend/internal/models"
+)
+
+// gerarParcelas monta o cronograma de 'prazo' parcelas mensais para amortizar 'saldo' à taxa
+// mensal 'taxa' (em percentual), com a primeira parcela em 'primeira' e numeradas a partir de
+// 'numeroInicial'. Os valores são arredondados em centavos e a última parcela absorve a diferença
+// de arredondamento, zerando o saldo.
+func gerarParcelas(sistema models.SistemaAmortizacao, saldo, taxa float64, prazo int, primeira time.Time, numeroInicial int) []models.ParcelaFinanciamento {
+	i := taxa / 100
+	prestacao := 0.0
+	if sistema == models.SistemaPrice {
+		prestacao = prestacaoPrice(saldo, i, prazo)
+	}
+	amortizacaoSAC := math.Round(saldo/float64(prazo)*100) / 100
+
+	parcelas := make([]models.ParcelaFinanciamento, 0, prazo)
+	for k := 0; k < prazo; k++ {
+		juros := math.Round(saldo*i*100) / 100
+		amortizacao := amortizacaoSAC
+		if sistema == models.SistemaPrice {
+			amortizacao = math.Round((prestacao-juros)*100) / 100
+		}
+		if k == prazo-1 || amortizacao > saldo {
+			amortizacao = saldo
+		}
+		saldo = math.Round((saldo-amortizacao)*100) / 100
+		parcelas = append(parcelas, models.ParcelaFinanciamento{
+			ID:             uuid.New().String(),
+			Numero:         numeroInicial + k,
+			DataVencimento: adicionarMeses(primeira, k),
+			Valor:          math.Round((juros+amortizacao)*100) / 100,
+			Juros:          juros,
+			Amortizacao:    amortizacao,
+			SaldoDevedor:   saldo,
+			Status:         models.ParcelaPendente,
+		})
+	}
+	return parcelas
+}
+
+// prestacaoPrice é a parcela constante da tabela Price; sem juros, o saldo é dividido igualmente.
+func prestacaoPrice(saldo, i float64, prazo int) float64 {
+	if i == 0 {
+		return math.Round(saldo/float64(prazo)*100) / 100
+	}
+	return math.Round(saldo*i/(1-math.Pow(1+i, -float64(prazo)))*100) / 100
+}
+
+// prazoParaParcela calcula quantos meses são necessários para quitar 'saldo' mantendo a parcela
+// do sistema no nível atual: a amortização constante no SAC ou a prestação na Price. Como a parcela
+// atual foi arredondada em centavos, uma sobra de até meio centavo por mês não justifica uma parcela
+// a mais; a última parcela do novo cronograma a absorve.
+func prazoParaParcela(sistema models.SistemaAmortizacao, saldo, taxa float64, atual models.ParcelaFinanciamento) int {
+	i := taxa / 100
+	var prazo float64
+	var restante func(n float64) float64
+	switch {
+	case sistema == models.SistemaSAC:
+		prazo = saldo / atual.Amortizacao
+		restante = func(n float64) float64 { return saldo - n*atual.Amortizacao }
+	case i == 0:
+		prazo = saldo / atual.Valor
+		restante = func(n float64) float64 { return saldo - n*atual.Valor }
+	case atual.Valor <= saldo*i:
+		// A prestação atual nem cobre os juros do novo saldo; não há como reduzir o prazo.
+		return 0
+	default:
+		prazo = -math.Log(1-saldo*i/atual.Valor) / math.Log(1+i)
+		restante = func(n float64) float64 {
+			fator := math.Pow(1+i, n)
+			return saldo*fator - atual.Valor*(fator-1)/i
+		}
+	}
+	n := math.Floor(prazo)
+	if restante(n) > 0.005*n+1e-9 {
+		n++
+	}
+	return int(n)
+}
+
+// adicionarMeses avança a data em 'meses' mantendo o dia, limitado ao último dia do mês de destino
+// (31/01 + 1 mês = 28/02 ou 29/02).
+func adicionarMeses(data time.Time, meses int) time.Time {
+	ano, mes, dia := data.Date()
+	ultimoDia := time.Date(ano, mes+time.Month(meses)+1, 0, 0, 0, 0, 0, data.Location()).Day()
+	if dia > ultimoDia {
+		dia = ultimoDia
+	}
+	return time.Date(ano, mes+time.Month(meses), dia, 0, 0, 0, 0, data.Location())
+}
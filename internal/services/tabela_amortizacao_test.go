@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"controlador/backend/internal/models"
+)
+
+func TestGerarParcelas(t *testing.T) {
+	primeira := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	casos := []struct {
+		nome          string
+		sistema       models.SistemaAmortizacao
+		saldo, taxa   float64
+		prazo         int
+		primeiraValor float64
+		ultimaValor   float64
+		totalJuros    float64
+	}{
+		// 10.000 a 1% ao mês em 12 meses: amortização de 833,33 e a última absorve os 4 centavos.
+		{"SAC", models.SistemaSAC, 10000, 1, 12, 933.33, 841.70, 650.00},
+		// Prestação de 888,49; a última fica 2 centavos menor para zerar o saldo.
+		{"Price", models.SistemaPrice, 10000, 1, 12, 888.49, 888.47, 661.86},
+		{"Price sem juros", models.SistemaPrice, 1200, 0, 12, 100, 100, 0},
+	}
+	for _, c := range casos {
+		t.Run(c.nome, func(t *testing.T) {
+			parcelas := gerarParcelas(c.sistema, c.saldo, c.taxa, c.prazo, primeira, 1)
+			if len(parcelas) != c.prazo {
+				t.Fatalf("%d parcelas, esperado %d", len(parcelas), c.prazo)
+			}
+			var amortizado, juros int64
+			for k, p := range parcelas {
+				if p.Numero != k+1 {
+					t.Errorf("parcela %d numerada %d", k+1, p.Numero)
+				}
+				if centavos(p.Valor) != centavos(p.Juros)+centavos(p.Amortizacao) {
+					t.Errorf("parcela %d: valor %.2f ≠ juros %.2f + amortização %.2f", p.Numero, p.Valor, p.Juros, p.Amortizacao)
+				}
+				if c.sistema == models.SistemaPrice && k < c.prazo-1 && centavos(p.Valor) != centavos(c.primeiraValor) {
+					t.Errorf("parcela %d da Price = %.2f, esperado %.2f", p.Numero, p.Valor, c.primeiraValor)
+				}
+				amortizado += centavos(p.Amortizacao)
+				juros += centavos(p.Juros)
+			}
+			if amortizado != centavos(c.saldo) {
+				t.Errorf("soma das amortizações = %d centavos, esperado %d", amortizado, centavos(c.saldo))
+			}
+			if juros != centavos(c.totalJuros) {
+				t.Errorf("total de juros = %d centavos, esperado %d", juros, centavos(c.totalJuros))
+			}
+			if centavos(parcelas[0].Valor) != centavos(c.primeiraValor) {
+				t.Errorf("primeira parcela = %.2f, esperado %.2f", parcelas[0].Valor, c.primeiraValor)
+			}
+			ultima := parcelas[len(parcelas)-1]
+			if centavos(ultima.Valor) != centavos(c.ultimaValor) {
+				t.Errorf("última parcela = %.2f, esperado %.2f", ultima.Valor, c.ultimaValor)
+			}
+			if ultima.SaldoDevedor != 0 {
+				t.Errorf("saldo devedor final = %.2f, esperado 0", ultima.SaldoDevedor)
+			}
+			if got := parcelas[1].DataVencimento; !got.Equal(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("segundo vencimento = %s, esperado 2026-02-28", got.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestPrazoParaParcela(t *testing.T) {
+	casos := []struct {
+		nome    string
+		sistema models.SistemaAmortizacao
+		saldo   float64
+		taxa    float64
+		atual   models.ParcelaFinanciamento
+		prazo   int
+	}{
+		// Metade do saldo quitada: 5.000 com amortização de 833,33 são 6 meses, não 7 por 2 centavos.
+		{"SAC", models.SistemaSAC, 5000, 1, models.ParcelaFinanciamento{Amortizacao: 833.33}, 6},
+		{"SAC com sobra", models.SistemaSAC, 5000.10, 1, models.ParcelaFinanciamento{Amortizacao: 833.33}, 7},
+		// Depois da 1ª parcela e de 2.000 extras, a prestação de 888,49 quita 7.211,51 em 9 meses.
+		{"Price", models.SistemaPrice, 7211.51, 1, models.ParcelaFinanciamento{Valor: 888.49}, 9},
+		{"Price sem reduzir", models.SistemaPrice, 10000, 1, models.ParcelaFinanciamento{Valor: 888.49}, 12},
+		{"Price sem juros", models.SistemaPrice, 700, 0, models.ParcelaFinanciamento{Valor: 100}, 7},
+		{"prestação não cobre os juros", models.SistemaPrice, 10000, 1, models.ParcelaFinanciamento{Valor: 100}, 0},
+	}
+	for _, c := range casos {
+		if got := prazoParaParcela(c.sistema, c.saldo, c.taxa, c.atual); got != c.prazo {
+			t.Errorf("%s: prazoParaParcela = %d, esperado %d", c.nome, got, c.prazo)
+		}
+	}
+}
+
+func TestAdicionarMeses(t *testing.T) {
+	casos := []struct {
+		data     time.Time
+		meses    int
+		esperado time.Time
+	}{
+		{time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), 1, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2028, 1, 31, 0, 0, 0, 0, time.UTC), 1, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), 2, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), -1, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC), 3, time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range casos {
+		if got := adicionarMeses(c.data, c.meses); !got.Equal(c.esperado) {
+			t.Errorf("adicionarMeses(%s, %d) = %s, esperado %s", c.data.Format("2006-01-02"), c.meses, got.Format("2006-01-02"), c.esperado.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrNomeAtivoInvalido            = erros.RegraNegocio("nome_ativo_invalido", "o nome do ativo não pode ficar vazio")
+	ErrAjusteLimiteIndevido         = erros.RegraNegocio("ajuste_limite_indevido", "somente cartões de crédito possuem limite de crédito ajustável")
+	ErrLimiteAbaixoDoUtilizado      = erros.RegraNegocio("limite_abaixo_do_utilizado", "o novo limite é menor que o limite já utilizado")
+	ErrChequeEspecialUtilizadoAcima = erros.RegraNegocio("cheque_especial_utilizado_acima", "o novo limite de cheque especial é menor que o valor já utilizado")
+	ErrAjusteELimiteTotal           = erros.Invalido("ajuste_e_limite_total", "informe ajuste_limite ou limite_total, não os dois")
+)
+
+// UpdateAtivoInput contém os campos que podem ser alterados em um ativo.
+// Campos ausentes (nil) mantêm o valor atual. O tipo e o saldo não são editáveis.
+type UpdateAtivoInput struct {
+	Instituicao *string `json:"instituicao"`
+	Nome        *string `json:"nome"`
+	// AjusteLimite aumenta (positivo) ou reduz (negativo) o limite de crédito do cartão.
+	// O limite já utilizado é preservado, então a variação é aplicada ao limite total e ao disponível.
+	AjusteLimite *float64 `json:"ajuste_limite"`
+	// LimiteTotal define o novo limite contratado; equivale a um ajuste pela diferença e não
+	// pode ser enviado junto com AjusteLimite.
+	LimiteTotal             *float64 `json:"limite_total"`
+	LimiteChequeEspecial    *float64 `json:"limite_cheque_especial"`
+	TaxaJurosChequeEspecial *float64 `json:"taxa_juros_cheque_especial"`
+}
+
+type UpdateAtivoService struct {
+	db   *pgxpool.Pool
+	repo repositories.AtivoRepository
+}
+
+func NewUpdateAtivoService(db *pgxpool.Pool, repo repositories.AtivoRepository) *UpdateAtivoService {
+	return &UpdateAtivoService{db: db, repo: repo}
+}
+
+// Execute altera os dados cadastrais e os limites do ativo. A linha do ativo fica bloqueada
+// durante a alteração para que o ajuste de limite não concorra com lançamentos simultâneos.
+func (s *UpdateAtivoService) Execute(ctx context.Context, id string, input UpdateAtivoInput) (*models.AtivoFinanceiro, error) {
+	if input.AjusteLimite != nil && input.LimiteTotal != nil {
+		return nil, ErrAjusteELimiteTotal
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	atual, err := s.repo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if atual == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+
+	ativo := *atual
+	if input.Instituicao != nil {
+		ativo.Instituicao = strings.TrimSpace(*input.Instituicao)
+	}
+	if input.Nome != nil {
+		ativo.Nome = strings.TrimSpace(*input.Nome)
+		if ativo.Nome == "" {
+			return nil, ErrNomeAtivoInvalido
+		}
+	}
+	var ajuste float64
+	if input.AjusteLimite != nil {
+		ajuste = *input.AjusteLimite
+	} else if input.LimiteTotal != nil {
+		ajuste = *input.LimiteTotal - ativo.LimiteTotal
+	}
+	if centavos(ajuste) != 0 {
+		if ativo.Tipo != models.AtivoCartaoCredito {
+			return nil, ErrAjusteLimiteIndevido
+		}
+		ativo.LimiteTotal += ajuste
+		ativo.LimiteDisponivel += ajuste
+		// O limite disponível só fica negativo se o novo limite total for menor que o já utilizado.
+		if piorouAbaixoDeZero(atual.LimiteDisponivel, ativo.LimiteDisponivel) {
+			return nil, ErrLimiteAbaixoDoUtilizado
+		}
+	}
+	if input.LimiteChequeEspecial != nil {
+		ativo.LimiteChequeEspecial = *input.LimiteChequeEspecial
+	}
+	if input.TaxaJurosChequeEspecial != nil {
+		ativo.TaxaJurosChequeEspecial = *input.TaxaJurosChequeEspecial
+	}
+
+	estrategia, err := estrategiaDoAtivo(ativo.Tipo)
+	if err != nil {
+		return nil, err
+	}
+	if err := estrategia.ValidarAtivo(ativo); err != nil {
+		return nil, err
+	}
+	if centavos(ativo.LimiteChequeEspecial) < centavos(atual.LimiteChequeEspecial) &&
+		centavos(ativo.ChequeEspecialUtilizado()) > centavos(ativo.LimiteChequeEspecial) {
+		return nil, ErrChequeEspecialUtilizadoAcima
+	}
+
+	ativo.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, tx, &ativo); err != nil {
+		return nil, err
+	}
+	return &ativo, tx.Commit(ctx)
+}
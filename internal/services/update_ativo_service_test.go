@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUpdateAtivoRecusaAjusteELimiteTotal(t *testing.T) {
+	ajuste, total := 500.0, 3000.0
+	s := NewUpdateAtivoService(nil, nil)
+	_, err := s.Execute(context.Background(), "cartao", UpdateAtivoInput{AjusteLimite: &ajuste, LimiteTotal: &total})
+	if !errors.Is(err, ErrAjusteELimiteTotal) {
+		t.Errorf("err = %v, esperado ErrAjusteELimiteTotal", err)
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrEventoNotificacaoInvalido      = erros.NaoEncontrado("evento_notificacao_invalido", "tipo de evento de notificação inválido")
+	ErrPreferenciaNotificacaoInvalida = erros.RegraNegocio("preferencia_notificacao_invalida", "preferência inválida: um evento ativo exige ao menos um canal e a antecedência deve ficar entre 0 e 31 dias")
+	ErrCanalNaoConfigurado            = erros.RegraNegocio("canal_nao_configurado", "canal de notificação não configurado no servidor")
+)
+
+type UpdatePreferenciaNotificacaoInput struct {
+	Ativo            bool                      `json:"ativo"`
+	Canais           []models.CanalNotificacao `json:"canais"`
+	AntecedenciaDias *int                      `json:"antecedencia_dias"`
+	Limite           float64                   `json:"limite"`
+}
+
+type UpdatePreferenciaNotificacaoService struct {
+	repo        repositories.NotificacaoRepository
+	notificador *NotificadorService
+}
+
+func NewUpdatePreferenciaNotificacaoService(repo repositories.NotificacaoRepository, notificador *NotificadorService) *UpdatePreferenciaNotificacaoService {
+	return &UpdatePreferenciaNotificacaoService{repo: repo, notificador: notificador}
+}
+
+// Execute grava a preferência do evento por inteiro. Sem 'antecedencia_dias', vale o padrão.
+func (s *UpdatePreferenciaNotificacaoService) Execute(ctx context.Context, evento string, input UpdatePreferenciaNotificacaoInput) (*models.PreferenciaNotificacao, error) {
+	tipo, err := models.ParseTipoEventoNotificacao(evento)
+	if err != nil {
+		return nil, ErrEventoNotificacaoInvalido
+	}
+
+	preferencia := &models.PreferenciaNotificacao{
+		Evento:           tipo,
+		Ativo:            input.Ativo,
+		Canais:           []models.CanalNotificacao{},
+		AntecedenciaDias: antecedenciaPadraoDias,
+		Limite:           math.Round(input.Limite*100) / 100,
+		UpdatedAt:        time.Now(),
+	}
+	if input.AntecedenciaDias != nil {
+		preferencia.AntecedenciaDias = *input.AntecedenciaDias
+	}
+	vistos := make(map[models.CanalNotificacao]bool, len(input.Canais))
+	for _, canal := range input.Canais {
+		if vistos[canal] {
+			continue
+		}
+		if !s.notificador.CanalDisponivel(canal) {
+			return nil, ErrCanalNaoConfigurado
+		}
+		vistos[canal] = true
+		preferencia.Canais = append(preferencia.Canais, canal)
+	}
+	if (preferencia.Ativo && len(preferencia.Canais) == 0) || preferencia.AntecedenciaDias < 0 || preferencia.AntecedenciaDias > 31 {
+		return nil, ErrPreferenciaNotificacaoInvalida
+	}
+
+	if err := s.repo.SavePreferencia(ctx, preferencia); err != nil {
+		return nil, err
+	}
+	return preferencia, nil
+}
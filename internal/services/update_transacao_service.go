@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+var (
+	ErrTransacaoEhEstorno      = erros.Conflito("transacao_eh_estorno", "estornos não podem ser editados ou excluídos")
+	ErrTransacaoPossuiEstornos = erros.Conflito("transacao_possui_estornos", "transação com estornos registrados não pode ser editada ou excluída")
+	ErrTransacaoCancelada      = erros.Conflito("transacao_cancelada", "transações canceladas não podem ser editadas")
+	ErrTransacaoBloqueada      = erros.Conflito("transacao_bloqueada", "transação conciliada está bloqueada; desbloqueie-a antes de alterá-la")
+	ErrMoedaDivergente         = erros.RegraNegocio("moeda_divergente", "a transação não pode ser movida para um ativo de outra moeda")
+	ErrTransacaoSaldoInicial   = erros.Conflito("transacao_saldo_inicial", "o lançamento de saldo inicial não pode ser alterado; recalcule o saldo do ativo se necessário")
+	ErrTransacaoGerada         = erros.Conflito("transacao_gerada", "transação gerada automaticamente só pode ser alterada pelo recurso que a originou")
+)
+
+// UpdateTransacaoInput contém os campos que podem ser alterados em uma transação.
+// Campos ausentes (nil) mantêm o valor atual.
+type UpdateTransacaoInput struct {
+	AtivoFinanceiroID *string               `json:"ativo_financeiro_id"`
+	CategoriaID       *string               `json:"categoria_id"`
+	Descricao         *string               `json:"descricao"`
+	Valor             *float64              `json:"valor"`
+	Tipo              *models.TipoTransacao `json:"tipo"`
+	Data              *time.Time            `json:"data"`
+	Notas             *string               `json:"notas"`
+	Tags              *[]string             `json:"tags"`
+	// Divisoes, quando informado, substitui todo o rateio; uma lista vazia remove o rateio.
+	Divisoes *[]models.TransacaoDivisao `json:"divisoes"`
+}
+
+type UpdateTransacaoService struct {
+	db            *pgxpool.Pool
+	transacaoRepo repositories.TransacaoRepository
+	ativoRepo     repositories.AtivoRepository
+	categoriaRepo repositories.CategoriaRepository
+	historicoRepo repositories.TransacaoHistoricoRepository
+}
+
+func NewUpdateTransacaoService(db *pgxpool.Pool, tRepo repositories.TransacaoRepository, aRepo repositories.AtivoRepository, cRepo repositories.CategoriaRepository, hRepo repositories.TransacaoHistoricoRepository) *UpdateTransacaoService {
+	return &UpdateTransacaoService{
+		db:            db,
+		transacaoRepo: tRepo,
+		ativoRepo:     aRepo,
+		categoriaRepo: cRepo,
+		historicoRepo: hRepo,
+	}
+}
+
+// Execute altera a transação e recalcula, na mesma transação de banco, o efeito sobre os ativos:
+// o efeito da versão anterior é desfeito e o da nova versão é aplicado, mesmo que o ativo ou o tipo mudem.
+// O status não é alterado aqui; transações não efetivadas continuam sem efeito sobre os ativos.
+func (s *UpdateTransacaoService) Execute(ctx context.Context, id string, input UpdateTransacaoInput) (*models.Transacao, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Carregar e bloquear a versão atual
+	original, err := s.transacaoRepo.FindByIDForUpdate(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validarTransacaoEditavel(original); err != nil {
+		return nil, err
+	}
+	if original.Status == models.StatusCancelada {
+		return nil, ErrTransacaoCancelada
+	}
+
+	// 2. Montar a nova versão a partir dos campos informados
+	atualizada := *original
+	if input.AtivoFinanceiroID != nil {
+		atualizada.AtivoFinanceiroID = *input.AtivoFinanceiroID
+	}
+	if input.CategoriaID != nil {
+		atualizada.CategoriaID = *input.CategoriaID
+	}
+	if input.Descricao != nil {
+		atualizada.Descricao = *input.Descricao
+	}
+	if input.Valor != nil {
+		atualizada.Valor = *input.Valor
+	}
+	if input.Tipo != nil {
+		atualizada.Tipo = *input.Tipo
+	}
+	if input.Data != nil {
+		atualizada.Data = *input.Data
+	}
+	if input.Notas != nil {
+		atualizada.Notas = *input.Notas
+	}
+	if input.Tags != nil {
+		tags, err := normalizarTags(*input.Tags)
+		if err != nil {
+			return nil, err
+		}
+		atualizada.Tags = tags
+	}
+	if input.Divisoes != nil {
+		atualizada.Divisoes = *input.Divisoes
+	} else {
+		atualizada.Divisoes = append([]models.TransacaoDivisao(nil), original.Divisoes...)
+	}
+
+	// 3. Validar a nova versão com as mesmas regras da criação
+	if err := prepararDivisoes(ctx, s.categoriaRepo, &atualizada); err != nil {
+		return nil, err
+	}
+	if err := validarCategoria(ctx, s.categoriaRepo, atualizada.CategoriaID); err != nil {
+		return nil, err
+	}
+	ativo, err := s.ativoRepo.FindByID(ctx, atualizada.AtivoFinanceiroID)
+	if err != nil {
+		return nil, err
+	}
+	if ativo == nil {
+		return nil, ErrAtivoNaoEncontrado
+	}
+	ativoAnterior := ativo
+	if ativo.ID != original.AtivoFinanceiroID {
+		if ativoAnterior, err = s.ativoRepo.FindByID(ctx, original.AtivoFinanceiroID); err != nil {
+			return nil, err
+		}
+		if ativoAnterior == nil {
+			return nil, ErrAtivoNaoEncontrado
+		}
+		// O valor está na moeda do ativo; mover entre moedas exige uma transferência com cotação.
+		if ativoAnterior.Moeda != ativo.Moeda {
+			return nil, ErrMoedaDivergente
+		}
+	}
+	efeitoAnterior := efeitoAplicado(ativoAnterior.Tipo, *original)
+	if ativo.ID == original.AtivoFinanceiroID {
+		// No mesmo ativo, o saldo disponível para a nova versão já conta com a devolução da anterior.
+		ativo.Aplicar(efeitoAnterior.Inverso())
+	}
+	if err := validarTransacao(atualizada, ativo); err != nil {
+		return nil, err
+	}
+
+	// 4. Registrar a versão anterior no histórico
+	if err := s.historicoRepo.Create(ctx, tx, &models.TransacaoHistorico{
+		ID:          uuid.New().String(),
+		TransacaoID: original.ID,
+		Operacao:    models.HistoricoAtualizacao,
+		Dados:       *original,
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	// 5. Desfazer o efeito anterior, aplicar o novo e persistir
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, original.AtivoFinanceiroID, efeitoAnterior.Inverso()); err != nil {
+		return nil, err
+	}
+	if err := s.ativoRepo.UpdateBalance(ctx, tx, atualizada.AtivoFinanceiroID, efeitoAplicado(ativo.Tipo, atualizada)); err != nil {
+		return nil, err
+	}
+	if err := s.transacaoRepo.Update(ctx, tx, &atualizada); err != nil {
+		return nil, err
+	}
+
+	return &atualizada, tx.Commit(ctx)
+}
+
+// validarTransacaoEditavel rejeita alterações que quebrariam o vínculo entre transações e estornos
+// ou que alterariam uma transação já conciliada sem que ela tenha sido desbloqueada.
+func validarTransacaoEditavel(t *models.Transacao) error {
+	if t == nil {
+		return ErrTransacaoNaoEncontrada
+	}
+	if t.Bloqueada {
+		return ErrTransacaoBloqueada
+	}
+	if t.ReversalOf != nil {
+		return ErrTransacaoEhEstorno
+	}
+	if t.Tipo == models.TransacaoSaldoInicial {
+		return ErrTransacaoSaldoInicial
+	}
+	if t.Origem != "" {
+		return ErrTransacaoGerada
+	}
+	if t.ValorEstornado > 0 {
+		return ErrTransacaoPossuiEstornos
+	}
+	return nil
+}
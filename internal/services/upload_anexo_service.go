@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/erros"
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+	"controlador/backend/internal/storage"
+)
+
+var (
+	ErrAnexoMuitoGrande      = erros.MuitoGrande("anexo_muito_grande", "o arquivo excede o tamanho máximo permitido")
+	ErrAnexoTipoNaoPermitido = erros.NaoSuportado("anexo_tipo_nao_permitido", "tipo de arquivo não permitido: envie JPEG, PNG, GIF ou PDF")
+	ErrAnexoVazio            = erros.Invalido("anexo_vazio", "o arquivo enviado está vazio")
+)
+
+// tiposAnexoPermitidos relaciona os tipos MIME aceitos, detectados pelo conteúdo do arquivo
+// e não pela extensão ou pelo cabeçalho enviado pelo cliente.
+var tiposAnexoPermitidos = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"application/pdf": true,
+}
+
+// ladoMiniatura é o tamanho máximo, em pixels, do maior lado da miniatura gerada para imagens.
+const ladoMiniatura = 256
+
+type UploadAnexoService struct {
+	storage       storage.Storage
+	anexoRepo     repositories.AnexoRepository
+	transacaoRepo repositories.TransacaoRepository
+	tamanhoMaximo int64
+}
+
+func NewUploadAnexoService(st storage.Storage, aRepo repositories.AnexoRepository, tRepo repositories.TransacaoRepository, tamanhoMaximo int64) *UploadAnexoService {
+	return &UploadAnexoService{
+		storage:       st,
+		anexoRepo:     aRepo,
+		transacaoRepo: tRepo,
+		tamanhoMaximo: tamanhoMaximo,
+	}
+}
+
+func (s *UploadAnexoService) Execute(ctx context.Context, transacaoID, nomeArquivo string, conteudo io.Reader) (*models.Anexo, error) {
+	// 1. Validar a transação
+	transacao, err := s.transacaoRepo.FindByID(ctx, transacaoID)
+	if err != nil {
+		return nil, err
+	}
+	if transacao == nil {
+		return nil, ErrTransacaoNaoEncontrada
+	}
+
+	// 2. Ler o arquivo respeitando o tamanho máximo e validar o tipo real
+	dados, err := io.ReadAll(io.LimitReader(conteudo, s.tamanhoMaximo+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(dados) == 0 {
+		return nil, ErrAnexoVazio
+	}
+	if int64(len(dados)) > s.tamanhoMaximo {
+		return nil, ErrAnexoMuitoGrande
+	}
+	contentType := http.DetectContentType(dados)
+	if !tiposAnexoPermitidos[contentType] {
+		return nil, ErrAnexoTipoNaoPermitido
+	}
+
+	// 3. Preparar o anexo
+	anexo := &models.Anexo{
+		ID:          uuid.New().String(),
+		TransacaoID: transacaoID,
+		NomeArquivo: filepath.Base(strings.ReplaceAll(nomeArquivo, "\\", "/")),
+		ContentType: contentType,
+		Tamanho:     int64(len(dados)),
+		CreatedAt:   time.Now(),
+	}
+	anexo.Chave = fmt.Sprintf("anexos/%s/%s", transacaoID, anexo.ID)
+
+	// 4. Gravar o arquivo e, para imagens, a miniatura
+	if err := s.storage.Put(ctx, anexo.Chave, dados, contentType); err != nil {
+		return nil, err
+	}
+	chaves := []string{anexo.Chave}
+	if strings.HasPrefix(contentType, "image/") {
+		miniatura, err := gerarMiniatura(dados)
+		if err != nil {
+			// Uma imagem que não decodifica ainda é um anexo válido, apenas sem miniatura.
+			log.Warn().Err(err).Str("anexo_id", anexo.ID).Msg("Não foi possível gerar a miniatura do anexo.")
+		} else {
+			chave := anexo.Chave + "-miniatura.jpg"
+			if err := s.storage.Put(ctx, chave, miniatura, "image/jpeg"); err != nil {
+				s.descartar(ctx, chaves)
+				return nil, err
+			}
+			anexo.ChaveMiniatura = &chave
+			anexo.PossuiMiniatura = true
+			chaves = append(chaves, chave)
+		}
+	}
+
+	// 5. Registrar os metadados; se falhar, os arquivos gravados são descartados
+	if err := s.anexoRepo.Create(ctx, anexo); err != nil {
+		s.descartar(ctx, chaves)
+		return nil, err
+	}
+	return anexo, nil
+}
+
+func (s *UploadAnexoService) descartar(ctx context.Context, chaves []string) {
+	for _, chave := range chaves {
+		if err := s.storage.Delete(ctx, chave); err != nil {
+			log.Error().Err(err).Str("chave", chave).Msg("Falha ao descartar arquivo de anexo.")
+		}
+	}
+}
+
+// gerarMiniatura reduz a imagem para caber em ladoMiniatura x ladoMiniatura, mantendo a proporção,
+// usando a média de cada bloco de pixels de origem, e a codifica em JPEG.
+func gerarMiniatura(dados []byte) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(dados))
+	if err != nil {
+		return nil, err
+	}
+	b := src.Bounds()
+	largura, altura := b.Dx(), b.Dy()
+	escala := float64(ladoMiniatura) / float64(max(largura, altura))
+	if escala > 1 {
+		escala = 1
+	}
+	novaLargura := max(1, int(float64(largura)*escala))
+	novaAltura := max(1, int(float64(altura)*escala))
+
+	dst := image.NewRGBA(image.Rect(0, 0, novaLargura, novaAltura))
+	for y := 0; y < novaAltura; y++ {
+		y0 := b.Min.Y + y*altura/novaAltura
+		y1 := max(y0+1, b.Min.Y+(y+1)*altura/novaAltura)
+		for x := 0; x < novaLargura; x++ {
+			x0 := b.Min.X + x*largura/novaLargura
+			x1 := max(x0+1, b.Min.X+(x+1)*largura/novaLargura)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
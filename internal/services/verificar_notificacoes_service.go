@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"controlador/backend/internal/models"
+	"controlador/backend/internal/repositories"
+)
+
+// VerificarNotificacoesService é o worker que avalia as condições de aviso: recorrências a vencer,
+// saldos baixos e orçamentos no limite. Cada ocorrência é notificada uma única vez.
+type VerificarNotificacoesService struct {
+	notificador    *NotificadorService
+	recorrenteRepo repositories.TransacaoRecorrenteRepository
+	ativoRepo      repositories.AtivoRepository
+	orcamentoRepo  repositories.OrcamentoRepository
+	relatorioSvc   *RelatorioCategoriasService
+	conversor      *ConversorMoedas
+	moedaBase      string
+}
+
+func NewVerificarNotificacoesService(notificador *NotificadorService, trRepo repositories.TransacaoRecorrenteRepository, aRepo repositories.AtivoRepository, oRepo repositories.OrcamentoRepository, relatorioSvc *RelatorioCategoriasService, conversor *ConversorMoedas, moedaBase string) *VerificarNotificacoesService {
+	return &VerificarNotificacoesService{
+		notificador:    notificador,
+		recorrenteRepo: trRepo,
+		ativoRepo:      aRepo,
+		orcamentoRepo:  oRepo,
+		relatorioSvc:   relatorioSvc,
+		conversor:      conversor,
+		moedaBase:      moedaBase,
+	}
+}
+
+// aviso é uma ocorrência detectada, ainda não entregue.
+type aviso struct {
+	evento   models.TipoEventoNotificacao
+	chave    string
+	titulo   string
+	mensagem string
+}
+
+func (s *VerificarNotificacoesService) Execute(ctx context.Context) (*RelatorioProcessamento, error) {
+	hoje := inicioDoDia(time.Now())
+	relatorio := &RelatorioProcessamento{}
+
+	var avisos []aviso
+	for _, verificar := range []func(context.Context, time.Time) ([]aviso, error){s.recorrenciasProximas, s.saldosBaixos, s.orcamentosNoLimite} {
+		encontrados, err := verificar(ctx, hoje)
+		if err != nil {
+			return nil, err
+		}
+		avisos = append(avisos, encontrados...)
+	}
+
+	relatorio.TotalParaProcessar = len(avisos)
+	for _, a := range avisos {
+		if err := s.notificador.Notificar(ctx, a.evento, a.chave, a.titulo, a.mensagem); err != nil {
+			log.Error().Err(err).Str("chave", a.chave).Msg("Falha ao registrar notificação.")
+			relatorio.Falhas++
+			relatorio.Erros = append(relatorio.Erros, fmt.Sprintf("%s: %v", a.chave, err))
+			continue
+		}
+		relatorio.Sucesso++
+	}
+
+	log.Info().Interface("relatorio", relatorio).Msg("Verificação de notificações concluída.")
+	return relatorio, nil
+}
+
+// recorrenciasProximas avisa das recorrências ativas que vencem nos próximos dias da antecedência.
+func (s *VerificarNotificacoesService) recorrenciasProximas(ctx context.Context, hoje time.Time) ([]aviso, error) {
+	preferencia, err := s.notificador.Preferencia(ctx, models.EventoRecorrenciaProxima)
+	if err != nil || !preferencia.Ativo {
+		return nil, err
+	}
+
+	var avisos []aviso
+	for d := 1; d <= preferencia.AntecedenciaDias; d++ {
+		vencimento := hoje.AddDate(0, 0, d)
+		recorrencias, err := recorrenciasDoDia(ctx, s.recorrenteRepo, vencimento)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range recorrencias {
+			avisos = append(avisos, aviso{
+				evento:   models.EventoRecorrenciaProxima,
+				chave:    fmt.Sprintf("%s:%s:%s", models.EventoRecorrenciaProxima, r.ID, vencimento.Format("2006-01-02")),
+				titulo:   fmt.Sprintf("Vencimento próximo: %s", r.Descricao),
+				mensagem: fmt.Sprintf("A recorrência \"%s\" de %.2f vence em %s.", r.Descricao, r.Valor, vencimento.Format(formatoDataNotificacao)),
+			})
+		}
+	}
+	return avisos, nil
+}
+
+// saldosBaixos avisa, no máximo uma vez por dia, dos ativos cujo saldo convertido para a moeda
+// base está abaixo do limite da preferência. Cartões e empréstimos não entram: o saldo deles é
+// uma dívida. Um ativo sem taxa de câmbio para a moeda base é pulado, sem impedir os demais.
+func (s *VerificarNotificacoesService) saldosBaixos(ctx context.Context, hoje time.Time) ([]aviso, error) {
+	preferencia, err := s.notificador.Preferencia(ctx, models.EventoSaldoBaixo)
+	if err != nil || !preferencia.Ativo {
+		return nil, err
+	}
+	ativos, err := s.ativoRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var avisos []aviso
+	for _, a := range ativos {
+		if !a.IsActive || a.Tipo == models.AtivoCartaoCredito || a.Tipo == models.AtivoEmprestimo {
+			continue
+		}
+		taxa, err := s.conversor.Taxa(ctx, a.Moeda, s.moedaBase, hoje)
+		if err != nil {
+			log.Warn().Err(err).Str("ativo_id", a.ID).Str("moeda", a.Moeda).Msg("Saldo baixo não verificado: sem taxa de câmbio para a moeda base.")
+			continue
+		}
+		if math.Round(a.SaldoAtual*taxa*100)/100 >= preferencia.Limite {
+			continue
+		}
+		avisos = append(avisos, aviso{
+			evento:   models.EventoSaldoBaixo,
+			chave:    fmt.Sprintf("%s:%s:%s", models.EventoSaldoBaixo, a.ID, hoje.Format("2006-01-02")),
+			titulo:   fmt.Sprintf("Saldo baixo: %s", a.Nome),
+			mensagem: fmt.Sprintf("O saldo de \"%s\" é %.2f %s, abaixo do limite de %.2f %s.", a.Nome, a.SaldoAtual, a.Moeda, preferencia.Limite, s.moedaBase),
+		})
+	}
+	return avisos, nil
+}
+
+// orcamentosNoLimite compara as despesas do mês corrente com os orçamentos. Cada categoria gera
+// no máximo dois avisos por mês: ao atingir o percentual de alerta e ao atingir o valor total.
+func (s *VerificarNotificacoesService) orcamentosNoLimite(ctx context.Context, hoje time.Time) ([]aviso, error) {
+	preferencia, err := s.notificador.Preferencia(ctx, models.EventoOrcamentoLimite)
+	if err != nil || !preferencia.Ativo {
+		return nil, err
+	}
+	orcamentos, err := s.orcamentoRepo.FindAll(ctx)
+	if err != nil || len(orcamentos) == 0 {
+		return nil, err
+	}
+
+	inicioMes := time.Date(hoje.Year(), hoje.Month(), 1, 0, 0, 0, 0, hoje.Location())
+	totais, err := s.relatorioSvc.Execute(ctx, Periodo{Inicio: &inicioMes, Fim: &hoje}, s.moedaBase)
+	if err != nil {
+		return nil, err
+	}
+	porCategoria := make(map[string]models.RelatorioCategoria, len(totais))
+	for _, t := range totais {
+		porCategoria[t.CategoriaID] = t
+	}
+
+	var avisos []aviso
+	for _, o := range orcamentos {
+		total, ok := porCategoria[o.CategoriaID]
+		if !ok {
+			continue
+		}
+		percentual := total.Despesas / o.ValorMensal * 100
+		var nivel, titulo string
+		switch {
+		case percentual >= 100:
+			nivel, titulo = "EXCEDIDO", fmt.Sprintf("Orçamento esgotado: %s", total.CategoriaNome)
+		case percentual >= o.PercentualAlerta:
+			nivel, titulo = "ALERTA", fmt.Sprintf("Orçamento perto do limite: %s", total.CategoriaNome)
+		default:
+			continue
+		}
+		avisos = append(avisos, aviso{
+			evento:   models.EventoOrcamentoLimite,
+			chave:    fmt.Sprintf("%s:%s:%s:%s", models.EventoOrcamentoLimite, o.CategoriaID, inicioMes.Format("2006-01"), nivel),
+			titulo:   titulo,
+			mensagem: fmt.Sprintf("As despesas de \"%s\" no mês somam %.2f %s, %.0f%% do orçamento de %.2f %s.", total.CategoriaNome, total.Despesas, s.moedaBase, math.Floor(percentual), o.ValorMensal, s.moedaBase),
+		})
+	}
+	return avisos, nil
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage guarda os arquivos em um diretório do sistema de arquivos.
+type LocalStorage struct {
+	dir string
+}
+
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("falha ao criar diretório de anexos: %w", err)
+	}
+	return &LocalStorage{dir: dir}, nil
+}
+
+// caminho converte a chave em um caminho dentro do diretório base, rejeitando chaves que escapem dele.
+func (s *LocalStorage) caminho(chave string) (string, error) {
+	limpo := filepath.Clean("/" + chave)
+	if strings.Contains(chave, "..") || limpo == "/" {
+		return "", fmt.Errorf("chave de armazenamento inválida: %s", chave)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(limpo)), nil
+}
+
+// Put grava o arquivo em um temporário e o renomeia, para nunca expor um arquivo pela metade.
+func (s *LocalStorage) Put(ctx context.Context, chave string, dados []byte, contentType string) error {
+	destino, err := s.caminho(chave)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(destino), 0o755); err != nil {
+		return err
+	}
+	tmp := destino + ".tmp"
+	if err := os.WriteFile(tmp, dados, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, destino)
+}
+
+func (s *LocalStorage) Get(ctx context.Context, chave string) (io.ReadCloser, error) {
+	origem, err := s.caminho(chave)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(origem)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrArquivoNaoEncontrado
+	}
+	return f, err
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, chave string) error {
+	alvo, err := s.caminho(chave)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(alvo); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
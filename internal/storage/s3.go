@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config reúne os dados de acesso a um serviço compatível com S3.
+type S3Config struct {
+	Endpoint  string // ex.: http://minio:9000
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Storage guarda os arquivos em um bucket compatível com S3, endereçado no estilo de caminho
+// (endpoint/bucket/chave), o que funciona tanto na AWS quanto no MinIO. As requisições são
+// assinadas com AWS Signature Version 4.
+type S3Storage struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("configuração S3 incompleta: informe S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY e S3_SECRET_KEY")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("S3_ENDPOINT inválido: %w", err)
+	}
+	s := &S3Storage{cfg: cfg, endpoint: endpoint, client: &http.Client{Timeout: 60 * time.Second}}
+	if err := s.garantirBucket(context.Background()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// garantirBucket cria o bucket se ele ainda não existir, o que simplifica o uso com um MinIO local.
+func (s *S3Storage) garantirBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, "")
+	if err != nil {
+		return fmt.Errorf("falha ao acessar bucket S3: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("falha ao acessar bucket S3: status %d", resp.StatusCode)
+	}
+	resp, err = s.do(ctx, http.MethodPut, "", nil, "")
+	if err != nil {
+		return fmt.Errorf("falha ao criar bucket S3: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("falha ao criar bucket S3: %s", lerErroS3(resp))
+	}
+	return nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, chave string, dados []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, chave, dados, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("falha ao enviar arquivo ao S3: %s", lerErroS3(resp))
+	}
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, chave string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, chave, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrArquivoNaoEncontrado
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("falha ao baixar arquivo do S3: %s", lerErroS3(resp))
+	}
+}
+
+func (s *S3Storage) Delete(ctx context.Context, chave string) error {
+	resp, err := s.do(ctx, http.MethodDelete, chave, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("falha ao excluir arquivo do S3: %s", lerErroS3(resp))
+	}
+	return nil
+}
+
+// do monta, assina e executa uma requisição para o bucket ou, com 'chave', para um objeto.
+func (s *S3Storage) do(ctx context.Context, metodo, chave string, corpo []byte, contentType string) (*http.Response, error) {
+	caminho := "/" + s.cfg.Bucket
+	if chave != "" {
+		segmentos := strings.Split(chave, "/")
+		for i, seg := range segmentos {
+			segmentos[i] = url.PathEscape(seg)
+		}
+		caminho += "/" + strings.Join(segmentos, "/")
+	}
+	u := *s.endpoint
+	u.RawPath = strings.TrimRight(u.Path, "/") + caminho
+	u.Path, _ = url.PathUnescape(u.RawPath)
+
+	req, err := http.NewRequestWithContext(ctx, metodo, u.String(), bytes.NewReader(corpo))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(corpo))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.assinar(req, corpo, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// assinar aplica a AWS Signature Version 4 assinando os cabeçalhos host, x-amz-content-sha256 e x-amz-date.
+func (s *S3Storage) assinar(req *http.Request, corpo []byte, agora time.Time) {
+	amzDate := agora.Format("20060102T150405Z")
+	data := agora.Format("20060102")
+	hashCorpo := sha256Hex(corpo)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", hashCorpo)
+
+	cabecalhosAssinados := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + hashCorpo + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		cabecalhosAssinados,
+		hashCorpo,
+	}, "\n")
+
+	escopo := data + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + escopo + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	chave := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), data)
+	chave = hmacSHA256(chave, s.cfg.Region)
+	chave = hmacSHA256(chave, "s3")
+	chave = hmacSHA256(chave, "aws4_request")
+	assinatura := hex.EncodeToString(hmacSHA256(chave, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, escopo, cabecalhosAssinados, assinatura))
+}
+
+func sha256Hex(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(chave []byte, dados string) []byte {
+	m := hmac.New(sha256.New, chave)
+	m.Write([]byte(dados))
+	return m.Sum(nil)
+}
+
+func lerErroS3(resp *http.Response) string {
+	corpo, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(corpo)))
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"controlador/backend/internal/config"
+)
+
+// ErrArquivoNaoEncontrado é retornado quando a chave não existe no armazenamento.
+var ErrArquivoNaoEncontrado = errors.New("arquivo não encontrado no armazenamento")
+
+// Storage abstrai onde os arquivos anexados são guardados.
+// As chaves são caminhos relativos separados por '/', gerados pela aplicação.
+type Storage interface {
+	Put(ctx context.Context, chave string, dados []byte, contentType string) error
+	Get(ctx context.Context, chave string) (io.ReadCloser, error)
+	Delete(ctx context.Context, chave string) error
+}
+
+// NewFromEnv cria o armazenamento configurado pela variável STORAGE_DRIVER.
+// "local" (padrão) grava em STORAGE_LOCAL_DIR; "s3" usa um serviço compatível com S3,
+// como o MinIO, configurado pelas variáveis S3_*.
+func NewFromEnv() (Storage, error) {
+	switch driver := config.Getenv("STORAGE_DRIVER", "local"); driver {
+	case "local":
+		return NewLocalStorage(config.Getenv("STORAGE_LOCAL_DIR", "./data/anexos"))
+	case "s3":
+		return NewS3Storage(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			Region:    config.Getenv("S3_REGION", "us-east-1"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("STORAGE_DRIVER desconhecido: %s", driver)
+	}
+}
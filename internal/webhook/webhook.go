@@ -0,0 +1,86 @@
+// Package webhook envia requisições POST assinadas para URLs de terceiros.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// CabecalhoAssinatura carrega a assinatura do timestamp e do corpo, no formato "sha256=<hex>".
+	CabecalhoAssinatura = "X-Controlador-Assinatura"
+	// CabecalhoTimestamp carrega o momento do envio em segundos Unix, coberto pela assinatura.
+	CabecalhoTimestamp = "X-Controlador-Timestamp"
+)
+
+// Assinar calcula a assinatura HMAC-SHA256 de "<timestamp>.<corpo>" com o segredo, no formato do
+// cabeçalho. O destinatário a confere recalculando o HMAC sobre o timestamp e o corpo recebidos,
+// byte a byte, e rejeita timestamps antigos para que uma entrega capturada não possa ser reenviada.
+func Assinar(segredo string, timestamp int64, corpo []byte) string {
+	mac := hmac.New(sha256.New, []byte(segredo))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(corpo)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verificar confere a assinatura de uma entrega recebida e se o timestamp está a no máximo
+// 'tolerancia' de 'agora'. É o que um destinatário escrito em Go deve fazer com os cabeçalhos.
+func Verificar(segredo, assinatura, timestamp string, corpo []byte, tolerancia time.Duration, agora time.Time) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if d := agora.Sub(time.Unix(ts, 0)); d > tolerancia || d < -tolerancia {
+		return false
+	}
+	return hmac.Equal([]byte(assinatura), []byte(Assinar(segredo, ts, corpo)))
+}
+
+// Cliente faz os envios com um tempo limite por requisição.
+type Cliente struct {
+	http *http.Client
+}
+
+func NewCliente(timeout time.Duration) *Cliente {
+	return &Cliente{http: &http.Client{Timeout: timeout}}
+}
+
+// Enviar publica o corpo JSON na URL, assinado quando há segredo, com os cabeçalhos extras.
+// Retorna o status HTTP recebido (zero se não houve resposta) e um erro se o status não for 2xx.
+func (c *Cliente) Enviar(ctx context.Context, url, segredo string, cabecalhos map[string]string, corpo []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(corpo))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Controlador-Webhook/1.0")
+	for chave, valor := range cabecalhos {
+		req.Header.Set(chave, valor)
+	}
+	if segredo != "" {
+		// Cada tentativa leva um timestamp novo, para não ser rejeitada como reenvio antigo.
+		agora := time.Now().Unix()
+		req.Header.Set(CabecalhoTimestamp, strconv.FormatInt(agora, 10))
+		req.Header.Set(CabecalhoAssinatura, Assinar(segredo, agora, corpo))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// A resposta é descartada, mas lida para que a conexão possa ser reaproveitada.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("o destino respondeu com status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
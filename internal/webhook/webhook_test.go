@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAssinarCobreTimestamp(t *testing.T) {
+	corpo := []byte(`{"evento":"transacao.criada"}`)
+	if Assinar("segredo", 1000, corpo) == Assinar("segredo", 1001, corpo) {
+		t.Error("assinaturas iguais para timestamps diferentes")
+	}
+}
+
+func TestEnviarAssinaVerificavel(t *testing.T) {
+	corpo := []byte(`{"evento":"transacao.criada"}`)
+	var recebida *http.Request
+	var recebido []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recebida = r
+		recebido, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	if _, err := NewCliente(5*time.Second).Enviar(context.Background(), srv.URL, "segredo", nil, corpo); err != nil {
+		t.Fatalf("Enviar: %v", err)
+	}
+	assinatura := recebida.Header.Get(CabecalhoAssinatura)
+	timestamp := recebida.Header.Get(CabecalhoTimestamp)
+	if !Verificar("segredo", assinatura, timestamp, recebido, 5*time.Minute, time.Now()) {
+		t.Fatalf("entrega não verificada: %s=%s %s=%s", CabecalhoTimestamp, timestamp, CabecalhoAssinatura, assinatura)
+	}
+
+	// A mesma entrega reenviada depois da tolerância é rejeitada.
+	if Verificar("segredo", assinatura, timestamp, recebido, 5*time.Minute, time.Now().Add(time.Hour)) {
+		t.Error("entrega antiga aceita")
+	}
+	// Trocar o timestamp invalida a assinatura.
+	ts, _ := strconv.ParseInt(timestamp, 10, 64)
+	if Verificar("segredo", assinatura, strconv.FormatInt(ts+1, 10), recebido, 5*time.Minute, time.Now()) {
+		t.Error("assinatura aceita com timestamp alterado")
+	}
+}